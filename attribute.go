@@ -14,7 +14,7 @@ import (
 type Attribute struct {
 	SimpleAttribute
 	ID        int       `json:"id"`
-	EntityID  int       `json:"entity_id"`
+	EntityID  EntityID  `json:"entity_id"`
 	CreatedAt time.Time `json:"created_at"`
 	CreatedBy int       `json:"created_by"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -57,6 +57,9 @@ type AttributeService service
 // entID in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return Attributes that have
 // been changed since that time.
+//
+// Deprecated: Use EntityHandle.Attributes, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (as *AttributeService) Index(campID int, entID int, sync *time.Time) ([]*Attribute, error) {
 	var err error
 	end := EndpointCampaign
@@ -88,6 +91,9 @@ func (as *AttributeService) Index(campID int, entID int, sync *time.Time) ([]*At
 
 // Get returns the Attribute associated with atrID for the entity associated
 // with entID from the Campaign associated with campID.
+//
+// Deprecated: Use EntityHandle.Attributes, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (as *AttributeService) Get(campID int, entID int, atrID int) (*Attribute, error) {
 	var err error
 	end := EndpointCampaign
@@ -120,6 +126,9 @@ func (as *AttributeService) Get(campID int, entID int, atrID int) (*Attribute, e
 // Create creates a new Attribute for the entity associated with entID in the
 // Campaign associated with campID using the provided SimpleAttribute data.
 // Create returns the newly created Attribute.
+//
+// Deprecated: Use EntityHandle.Attributes, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (as *AttributeService) Create(campID int, entID int, atr SimpleAttribute) (*Attribute, error) {
 	var err error
 	end := EndpointCampaign
@@ -154,6 +163,9 @@ func (as *AttributeService) Create(campID int, entID int, atr SimpleAttribute) (
 // associated with entID from the Campaign associated with campID using the
 // provided SimpleAttribute data.
 // Update returns the newly updated Attribute.
+//
+// Deprecated: Use EntityHandle.Attributes, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (as *AttributeService) Update(campID int, entID int, atrID int, atr SimpleAttribute) (*Attribute, error) {
 	var err error
 	end := EndpointCampaign
@@ -190,6 +202,9 @@ func (as *AttributeService) Update(campID int, entID int, atrID int, atr SimpleA
 
 // Delete deletes an existing Attribute associated with atrID from the
 // Campaign associated with campID.
+//
+// Deprecated: Use EntityHandle.Attributes, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (as *AttributeService) Delete(campID int, entID int, atrID int) error {
 	var err error
 	end := EndpointCampaign
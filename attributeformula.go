@@ -0,0 +1,413 @@
+package kanka
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// attributeRef matches a reference to another attribute, such as
+// "{Strength}".
+var attributeRef = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// AttributeEvaluator computes the values of a set of attributes that
+// reference each other, such as "floor(({Strength} - 10) / 2)".
+//
+// References are replaced by the computed values of the attributes they
+// name. A value containing references, or starting with "=", is then
+// evaluated as arithmetic if it is a valid expression. Expressions support
+// numbers, parentheses, the operators + - * / % ^, and the functions abs,
+// ceil, floor, round, min, and max. Any other value is kept as text.
+type AttributeEvaluator struct {
+	attrs map[string]*Attribute
+	names []string
+	cache map[string]string
+	stack []string
+}
+
+// NewAttributeEvaluator returns an AttributeEvaluator of the provided
+// attributes. If several attributes share a name, the first is used.
+func NewAttributeEvaluator(attrs []*Attribute) *AttributeEvaluator {
+	e := &AttributeEvaluator{
+		attrs: make(map[string]*Attribute),
+		cache: make(map[string]string),
+	}
+	for _, a := range attrs {
+		if _, ok := e.attrs[a.Name]; !ok {
+			e.attrs[a.Name] = a
+			e.names = append(e.names, a.Name)
+		}
+	}
+
+	return e
+}
+
+// Value returns the computed value of the attribute associated with name.
+// Value returns an error if the attribute does not exist, references a
+// missing attribute, references itself through a cycle, or starts with "="
+// but is not a valid expression.
+func (e *AttributeEvaluator) Value(name string) (string, error) {
+	a, ok := e.lookup(name)
+	if !ok {
+		return "", fmt.Errorf("cannot find attribute '%s'", name)
+	}
+	if v, ok := e.cache[a.Name]; ok {
+		return v, nil
+	}
+
+	for i, n := range e.stack {
+		if n == a.Name {
+			cycle := append(append([]string{}, e.stack[i:]...), a.Name)
+			return "", fmt.Errorf("attribute '%s' references itself: %s", a.Name, strings.Join(cycle, " -> "))
+		}
+	}
+
+	e.stack = append(e.stack, a.Name)
+	v, err := e.Eval(a.Value)
+	e.stack = e.stack[:len(e.stack)-1]
+	if err != nil {
+		return "", fmt.Errorf("cannot evaluate attribute '%s': %w", a.Name, err)
+	}
+
+	e.cache[a.Name] = v
+	return v, nil
+}
+
+// lookup returns the attribute associated with name, ignoring case if no
+// attribute has the exact name.
+func (e *AttributeEvaluator) lookup(name string) (*Attribute, bool) {
+	name = strings.TrimSpace(name)
+	if a, ok := e.attrs[name]; ok {
+		return a, true
+	}
+
+	for _, n := range e.names {
+		if strings.EqualFold(n, name) {
+			return e.attrs[n], true
+		}
+	}
+
+	return nil, false
+}
+
+// Values returns the computed value of every attribute keyed by name.
+// Section attributes are skipped. Values returns the error of the first
+// attribute, in the order provided, that cannot be evaluated.
+func (e *AttributeEvaluator) Values() (map[string]string, error) {
+	vals := make(map[string]string)
+	for _, name := range e.names {
+		if e.attrs[name].AttributeType() == AttributeSection {
+			continue
+		}
+
+		v, err := e.Value(name)
+		if err != nil {
+			return nil, err
+		}
+		vals[name] = v
+	}
+
+	return vals, nil
+}
+
+// Eval computes the provided text as if it were the value of an attribute
+// in the AttributeEvaluator's set.
+func (e *AttributeEvaluator) Eval(s string) (string, error) {
+	formula := strings.HasPrefix(strings.TrimSpace(s), "=")
+	if formula {
+		s = strings.TrimPrefix(strings.TrimSpace(s), "=")
+	}
+
+	var err error
+	refs := false
+	out := attributeRef.ReplaceAllStringFunc(s, func(m string) string {
+		refs = true
+		if err != nil {
+			return m
+		}
+
+		var v string
+		v, err = e.Value(m[1 : len(m)-1])
+		return v
+	})
+	if err != nil {
+		return "", err
+	}
+	if !formula && !refs {
+		return out, nil
+	}
+
+	x, ferr := EvalFormula(out)
+	if ferr != nil {
+		if formula {
+			return "", ferr
+		}
+		return out, nil
+	}
+
+	return formatNumber(x), nil
+}
+
+// Evaluate returns the computed value of every attribute of the entity
+// associated with entID in the Campaign associated with campID, keyed by
+// name, as an AttributeEvaluator computes them.
+func (as *AttributeService) Evaluate(campID CampaignID, entID EntityID) (map[string]string, error) {
+	attrs, err := as.Index(int(campID), int(entID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot evaluate attributes of Entity (ID: %d): %w", entID, err)
+	}
+
+	vals, err := NewAttributeEvaluator(attrs).Values()
+	if err != nil {
+		return nil, fmt.Errorf("cannot evaluate attributes of Entity (ID: %d): %w", entID, err)
+	}
+
+	return vals, nil
+}
+
+// formatNumber returns the shortest representation of x.
+func formatNumber(x float64) string {
+	if x == 0 {
+		return "0"
+	}
+	return strconv.FormatFloat(x, 'f', -1, 64)
+}
+
+// EvalFormula evaluates an arithmetic expression without references.
+func EvalFormula(expr string) (float64, error) {
+	p := &formulaParser{s: expr}
+
+	x, err := p.expr()
+	if err != nil {
+		return 0, fmt.Errorf("cannot evaluate formula '%s': %w", expr, err)
+	}
+
+	p.space()
+	if p.pos < len(p.s) {
+		return 0, fmt.Errorf("cannot evaluate formula '%s': unexpected '%c' at position %d", expr, p.s[p.pos], p.pos)
+	}
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return 0, fmt.Errorf("cannot evaluate formula '%s': result is not a number", expr)
+	}
+
+	return x, nil
+}
+
+// formulaParser is a recursive descent parser of arithmetic expressions.
+type formulaParser struct {
+	s   string
+	pos int
+}
+
+// space skips whitespace.
+func (p *formulaParser) space() {
+	for p.pos < len(p.s) && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t' || p.s[p.pos] == '\n' || p.s[p.pos] == '\r') {
+		p.pos++
+	}
+}
+
+// peek returns the next non-whitespace byte, or 0 at the end.
+func (p *formulaParser) peek() byte {
+	p.space()
+	if p.pos >= len(p.s) {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+// expr parses a sum of terms.
+func (p *formulaParser) expr() (float64, error) {
+	x, err := p.term()
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		op := p.peek()
+		if op != '+' && op != '-' {
+			return x, nil
+		}
+		p.pos++
+
+		y, err := p.term()
+		if err != nil {
+			return 0, err
+		}
+		if op == '+' {
+			x += y
+		} else {
+			x -= y
+		}
+	}
+}
+
+// term parses a product of factors.
+func (p *formulaParser) term() (float64, error) {
+	x, err := p.unary()
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		op := p.peek()
+		if op != '*' && op != '/' && op != '%' {
+			return x, nil
+		}
+		p.pos++
+
+		y, err := p.unary()
+		if err != nil {
+			return 0, err
+		}
+
+		switch {
+		case op == '*':
+			x *= y
+		case y == 0:
+			return 0, fmt.Errorf("division by zero")
+		case op == '/':
+			x /= y
+		default:
+			x = math.Mod(x, y)
+		}
+	}
+}
+
+// unary parses a signed power.
+func (p *formulaParser) unary() (float64, error) {
+	switch p.peek() {
+	case '-':
+		p.pos++
+		x, err := p.unary()
+		return -x, err
+	case '+':
+		p.pos++
+		return p.unary()
+	default:
+		return p.power()
+	}
+}
+
+// power parses a right-associative exponentiation.
+func (p *formulaParser) power() (float64, error) {
+	x, err := p.primary()
+	if err != nil {
+		return 0, err
+	}
+
+	if p.peek() != '^' {
+		return x, nil
+	}
+	p.pos++
+
+	y, err := p.unary()
+	if err != nil {
+		return 0, err
+	}
+
+	return math.Pow(x, y), nil
+}
+
+// primary parses a number, a parenthesized expression, or a function call.
+func (p *formulaParser) primary() (float64, error) {
+	c := p.peek()
+	switch {
+	case c == '(':
+		p.pos++
+		x, err := p.expr()
+		if err != nil {
+			return 0, err
+		}
+		if p.peek() != ')' {
+			return 0, fmt.Errorf("missing ')' at position %d", p.pos)
+		}
+		p.pos++
+		return x, nil
+	case c >= '0' && c <= '9' || c == '.':
+		start := p.pos
+		for p.pos < len(p.s) && (p.s[p.pos] >= '0' && p.s[p.pos] <= '9' || p.s[p.pos] == '.') {
+			p.pos++
+		}
+		x, err := strconv.ParseFloat(p.s[start:p.pos], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number '%s' at position %d", p.s[start:p.pos], start)
+		}
+		return x, nil
+	case c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+		return p.call()
+	case c == 0:
+		return 0, fmt.Errorf("unexpected end of formula")
+	default:
+		return 0, fmt.Errorf("unexpected '%c' at position %d", c, p.pos)
+	}
+}
+
+// call parses a function call.
+func (p *formulaParser) call() (float64, error) {
+	start := p.pos
+	for p.pos < len(p.s) && (p.s[p.pos] >= 'a' && p.s[p.pos] <= 'z' || p.s[p.pos] >= 'A' && p.s[p.pos] <= 'Z') {
+		p.pos++
+	}
+	name := strings.ToLower(p.s[start:p.pos])
+
+	if p.peek() != '(' {
+		return 0, fmt.Errorf("unexpected '%s' at position %d", name, start)
+	}
+	p.pos++
+
+	var args []float64
+	for p.peek() != ')' {
+		if len(args) > 0 {
+			if p.peek() != ',' {
+				return 0, fmt.Errorf("missing ',' at position %d", p.pos)
+			}
+			p.pos++
+		}
+
+		x, err := p.expr()
+		if err != nil {
+			return 0, err
+		}
+		args = append(args, x)
+	}
+	p.pos++
+
+	return callFormula(name, args)
+}
+
+// callFormula returns the result of the named function.
+func callFormula(name string, args []float64) (float64, error) {
+	unary := map[string]func(float64) float64{
+		"abs":   math.Abs,
+		"ceil":  math.Ceil,
+		"floor": math.Floor,
+		"round": math.Round,
+	}
+
+	if fn, ok := unary[name]; ok {
+		if len(args) != 1 {
+			return 0, fmt.Errorf("function '%s' takes 1 argument, got %d", name, len(args))
+		}
+		return fn(args[0]), nil
+	}
+
+	if name != "min" && name != "max" {
+		return 0, fmt.Errorf("unknown function '%s'", name)
+	}
+	if len(args) == 0 {
+		return 0, fmt.Errorf("function '%s' takes at least 1 argument", name)
+	}
+
+	x := args[0]
+	for _, y := range args[1:] {
+		if name == "min" {
+			x = math.Min(x, y)
+		} else {
+			x = math.Max(x, y)
+		}
+	}
+
+	return x, nil
+}
@@ -0,0 +1,136 @@
+package kanka
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// testSheet returns the attributes of a small character sheet with
+// computed modifiers.
+func testSheet() []*Attribute {
+	return []*Attribute{
+		{ID: 1, SimpleAttribute: SimpleAttribute{Name: "Strength", Value: "15", Type: "number"}},
+		{ID: 2, SimpleAttribute: SimpleAttribute{Name: "Str Mod", Value: "floor(({Strength} - 10) / 2)"}},
+		{ID: 3, SimpleAttribute: SimpleAttribute{Name: "Level", Value: "3", Type: "number"}},
+		{ID: 4, SimpleAttribute: SimpleAttribute{Name: "Attack", Value: "{str mod} + {Proficiency}"}},
+		{ID: 5, SimpleAttribute: SimpleAttribute{Name: "Proficiency", Value: "=2 + ceil({Level} / 4)"}},
+		{ID: 6, SimpleAttribute: SimpleAttribute{Name: "Title", Value: "Level {Level} fighter"}},
+		{ID: 7, SimpleAttribute: SimpleAttribute{Name: "Damage", Value: "1-6"}},
+		{ID: 8, SimpleAttribute: SimpleAttribute{Name: "Combat", Type: "section"}},
+	}
+}
+
+func TestEvalFormula(t *testing.T) {
+	tests := []struct {
+		expr    string
+		want    float64
+		wantErr bool
+	}{
+		{"1 + 2 * 3", 7, false},
+		{"(1 + 2) * 3", 9, false},
+		{"-2 ^ 2", -4, false},
+		{"2 ^ 3 ^ 2", 512, false},
+		{"7 / 2", 3.5, false},
+		{"7 % 3", 1, false},
+		{"floor(-1.5) + ceil(1.2) + round(2.5) + abs(-1)", 4, false},
+		{"max(1, 5, 3) - min(4, 2)", 3, false},
+		{"1 / 0", 0, true},
+		{"(1 + 2", 0, true},
+		{"1 +", 0, true},
+		{"2 3", 0, true},
+		{"sqrt(4)", 0, true},
+		{"floor(1, 2)", 0, true},
+		{"Arya", 0, true},
+		{"", 0, true},
+	}
+	for _, test := range tests {
+		got, err := EvalFormula(test.expr)
+		if (err != nil) != test.wantErr {
+			t.Errorf("got err <%v> for formula <%s>, want err <%t>", err, test.expr, test.wantErr)
+		}
+		if got != test.want {
+			t.Errorf("got <%v> for formula <%s>, want <%v>", got, test.expr, test.want)
+		}
+	}
+}
+
+func TestAttributeEvaluator_Values(t *testing.T) {
+	got, err := NewAttributeEvaluator(testSheet()).Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"Strength":    "15",
+		"Str Mod":     "2",
+		"Level":       "3",
+		"Attack":      "5",
+		"Proficiency": "3",
+		"Title":       "Level 3 fighter",
+		"Damage":      "1-6",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAttributeEvaluator_Value(t *testing.T) {
+	tests := []struct {
+		name    string
+		attrs   []SimpleAttribute
+		want    string
+		wantErr string
+	}{
+		{"A", []SimpleAttribute{{Name: "A", Value: "{B} * 2"}, {Name: "B", Value: "-1.5"}}, "-3", ""},
+		{"A", []SimpleAttribute{{Name: "A", Value: "=0 * -1"}}, "0", ""},
+		{"A", []SimpleAttribute{{Name: "A", Value: "{B}"}, {Name: "B", Value: "Winterfell"}}, "Winterfell", ""},
+		{"C", []SimpleAttribute{{Name: "A", Value: "1"}}, "", "cannot find attribute 'C'"},
+		{"A", []SimpleAttribute{{Name: "A", Value: "{C} + 1"}}, "", "cannot find attribute 'C'"},
+		{"A", []SimpleAttribute{{Name: "A", Value: "{A}"}}, "", "references itself: A -> A"},
+		{"A", []SimpleAttribute{{Name: "A", Value: "{B}"}, {Name: "B", Value: "{C} + 1"}, {Name: "C", Value: "{A}"}}, "", "references itself: A -> B -> C -> A"},
+		{"A", []SimpleAttribute{{Name: "A", Value: "={B} / 0"}, {Name: "B", Value: "1"}}, "", "division by zero"},
+		{"A", []SimpleAttribute{{Name: "A", Value: "=Arya"}}, "", "cannot evaluate attribute 'A'"},
+	}
+	for _, test := range tests {
+		var attrs []*Attribute
+		for i, sa := range test.attrs {
+			attrs = append(attrs, &Attribute{ID: i + 1, SimpleAttribute: sa})
+		}
+
+		got, err := NewAttributeEvaluator(attrs).Value(test.name)
+		if test.wantErr == "" && err != nil {
+			t.Errorf("got err <%v> for attributes <%v>, want nil", err, test.attrs)
+		}
+		if test.wantErr != "" && (err == nil || !strings.Contains(err.Error(), test.wantErr)) {
+			t.Errorf("got err <%v> for attributes <%v>, want err containing <%s>", err, test.attrs, test.wantErr)
+		}
+		if got != test.want {
+			t.Errorf("got <%s> for attributes <%v>, want <%s>", got, test.attrs, test.want)
+		}
+	}
+}
+
+func TestAttributeService_Evaluate(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+	fk.seed(t, "/campaigns/1/entities/130/attributes", &Attribute{ID: 91, SimpleAttribute: SimpleAttribute{Name: "Carry", Value: "{Strength} * 15"}})
+
+	got, err := c.Attributes.Evaluate(1, 130)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{"Strength": "12", "Carry": "180"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	fk.seed(t, "/campaigns/1/entities/130/attributes", &Attribute{ID: 92, SimpleAttribute: SimpleAttribute{Name: "Loop", Value: "{Loop}"}})
+	if _, err = c.Attributes.Evaluate(1, 130); err == nil {
+		t.Error("got nil error for reference cycle, want error")
+	}
+}
@@ -0,0 +1,107 @@
+package kanka
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AttributeField describes a single expected attribute.
+type AttributeField struct {
+	Name     string
+	Type     AttributeType
+	Required bool
+}
+
+// AttributeSchema describes the attributes expected on entities of a single
+// type, such as the stat block of every character.
+type AttributeSchema struct {
+	Type   EntityType
+	Fields []AttributeField
+}
+
+// AttributeError describes an attribute that does not match its
+// AttributeField. An attribute is either missing, of the wrong type, or has
+// a value that cannot be converted into its type.
+type AttributeError struct {
+	Name    string
+	Missing bool
+	Want    AttributeType
+	Got     AttributeType
+	Value   string
+}
+
+// Error returns a description of the mismatched attribute.
+func (e *AttributeError) Error() string {
+	switch {
+	case e.Missing:
+		return fmt.Sprintf("attribute '%s' is missing", e.Name)
+	case e.Want != e.Got:
+		return fmt.Sprintf("attribute '%s' has type '%s', want '%s'", e.Name, e.Got, e.Want)
+	default:
+		return fmt.Sprintf("attribute '%s' has invalid %s value '%s'", e.Name, e.Want, e.Value)
+	}
+}
+
+// AttributeErrors contains every mismatched attribute of an entity.
+type AttributeErrors []*AttributeError
+
+// Error returns the descriptions of every mismatched attribute.
+func (es AttributeErrors) Error() string {
+	msgs := make([]string, len(es))
+	for i, e := range es {
+		msgs[i] = e.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// Validate returns an AttributeError for every field of the AttributeSchema
+// that is required but missing from the attributes, or whose attribute is of
+// the wrong type or holds an invalid value. Attributes without a field are
+// ignored.
+func (s *AttributeSchema) Validate(attrs []*Attribute) AttributeErrors {
+	byName := make(map[string]*Attribute)
+	for _, a := range attrs {
+		if _, ok := byName[a.Name]; !ok {
+			byName[a.Name] = a
+		}
+	}
+
+	var errs AttributeErrors
+	for _, f := range s.Fields {
+		a, ok := byName[f.Name]
+		if !ok {
+			if f.Required {
+				errs = append(errs, &AttributeError{Name: f.Name, Missing: true, Want: f.Type})
+			}
+			continue
+		}
+
+		got := a.AttributeType()
+		if got != f.Type {
+			errs = append(errs, &AttributeError{Name: f.Name, Want: f.Type, Got: got, Value: a.Value})
+			continue
+		}
+		if a.validValue() != nil {
+			errs = append(errs, &AttributeError{Name: f.Name, Want: f.Type, Got: got, Value: a.Value})
+		}
+	}
+
+	return errs
+}
+
+// ValidateCharacter validates the attributes of the Character against the
+// AttributeSchema. ValidateCharacter returns an error if the AttributeSchema
+// describes another entity type, or AttributeErrors if any attribute does
+// not match.
+func (s *AttributeSchema) ValidateCharacter(ch *Character) error {
+	if s.Type != "" && s.Type != TypeCharacter {
+		return fmt.Errorf("cannot validate Character (ID: %d) against schema of type '%s'", ch.ID, s.Type)
+	}
+
+	if errs := s.Validate(ch.Attributes.Data); len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package kanka
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// testSchema returns the AttributeSchema of a character stat block.
+func testSchema() *AttributeSchema {
+	return &AttributeSchema{
+		Type: TypeCharacter,
+		Fields: []AttributeField{
+			{Name: "Strength", Type: AttributeNumber, Required: true},
+			{Name: "Alive", Type: AttributeCheckbox, Required: true},
+			{Name: "Title", Type: AttributeStandard},
+			{Name: "Level", Type: AttributeNumber, Required: true},
+			{Name: "Notes", Type: AttributeMultiline},
+		},
+	}
+}
+
+func TestAttributeSchema_ValidateCharacter(t *testing.T) {
+	ch := &Character{
+		ID: 30,
+		Attributes: Attributes{Data: []*Attribute{
+			{SimpleAttribute: NewNumberAttribute("Strength", 12)},
+			{SimpleAttribute: SimpleAttribute{Name: "Alive", Value: "perhaps", Type: "checkbox"}},
+			{SimpleAttribute: NewNumberAttribute("Title", 3)},
+			{SimpleAttribute: NewStandardAttribute("Extra", "ignored")},
+		}},
+	}
+
+	err := testSchema().ValidateCharacter(ch)
+
+	var errs AttributeErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("got error <%v>, want AttributeErrors", err)
+	}
+
+	want := []string{
+		"attribute 'Alive' has invalid checkbox value 'perhaps'",
+		"attribute 'Title' has type 'number', want 'standard'",
+		"attribute 'Level' is missing",
+	}
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.Error())
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAttributeSchema_ValidateCharacter_Valid(t *testing.T) {
+	ch := &Character{
+		Attributes: Attributes{Data: []*Attribute{
+			{SimpleAttribute: NewNumberAttribute("Strength", 12)},
+			{SimpleAttribute: NewCheckboxAttribute("Alive", true)},
+			{SimpleAttribute: NewNumberAttribute("Level", 3)},
+		}},
+	}
+
+	if err := testSchema().ValidateCharacter(ch); err != nil {
+		t.Errorf("got error <%v>, want nil", err)
+	}
+
+	s := testSchema()
+	s.Type = TypeLocation
+	if err := s.ValidateCharacter(ch); err == nil {
+		t.Error("got nil error for location schema, want error")
+	}
+}
@@ -0,0 +1,290 @@
+package kanka
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// attributeTag is the parsed `kanka` struct tag of a field. The tag holds
+// the attribute's name, optional type, and the options "private" and
+// "omitempty", such as `kanka:"Strength,number,private"`.
+type attributeTag struct {
+	index     int
+	name      string
+	typ       AttributeType
+	private   bool
+	omitEmpty bool
+}
+
+// attributeTags returns the parsed tags of the tagged fields of the struct
+// type. Fields tagged with "-" are skipped. A tag without a name uses the
+// field's name and a tag without a type uses the type matching the field.
+func attributeTags(t reflect.Type) ([]attributeTag, error) {
+	var tags []attributeTag
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, ok := f.Tag.Lookup("kanka")
+		if !ok || tag == "-" || f.PkgPath != "" {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		at := attributeTag{index: i, name: parts[0]}
+		if at.name == "" {
+			at.name = f.Name
+		}
+
+		typ, err := fieldAttributeType(f.Type)
+		if err != nil {
+			return nil, fmt.Errorf("cannot use field '%s' as attribute: %w", f.Name, err)
+		}
+		at.typ = typ
+
+		for _, opt := range parts[1:] {
+			switch opt {
+			case "private":
+				at.private = true
+			case "omitempty":
+				at.omitEmpty = true
+			case "standard", "":
+				at.typ = AttributeStandard
+			case "multiline":
+				at.typ = AttributeMultiline
+			case string(AttributeMultiline), string(AttributeNumber), string(AttributeCheckbox), string(AttributeRandom):
+				at.typ = AttributeType(opt)
+			default:
+				return nil, fmt.Errorf("cannot use field '%s' as attribute: unknown option '%s'", f.Name, opt)
+			}
+		}
+
+		tags = append(tags, at)
+	}
+
+	return tags, nil
+}
+
+// fieldAttributeType returns the AttributeType matching the kind of a
+// field, or an error if the kind cannot be stored in an attribute.
+func fieldAttributeType(t reflect.Type) (AttributeType, error) {
+	switch t.Kind() {
+	case reflect.String:
+		return AttributeStandard, nil
+	case reflect.Bool:
+		return AttributeCheckbox, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return AttributeNumber, nil
+	default:
+		return "", fmt.Errorf("unsupported kind '%s'", t.Kind())
+	}
+}
+
+// structValue returns the struct pointed to by v.
+func structValue(v interface{}) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("cannot use %T: want non-nil pointer to struct", v)
+	}
+
+	return rv.Elem(), nil
+}
+
+// UnmarshalAttributes stores the values of the attributes in the fields of
+// the struct pointed to by v according to the fields' `kanka` tags. Fields
+// without a matching attribute are left unchanged. Attributes without a
+// matching field are ignored.
+func UnmarshalAttributes(attrs []*Attribute, v interface{}) error {
+	rv, err := structValue(v)
+	if err != nil {
+		return fmt.Errorf("cannot unmarshal attributes: %w", err)
+	}
+
+	tags, err := attributeTags(rv.Type())
+	if err != nil {
+		return fmt.Errorf("cannot unmarshal attributes: %w", err)
+	}
+
+	byName := make(map[string]*Attribute)
+	for _, a := range attrs {
+		if _, ok := byName[a.Name]; !ok {
+			byName[a.Name] = a
+		}
+	}
+
+	for _, tag := range tags {
+		a, ok := byName[tag.name]
+		if !ok {
+			continue
+		}
+
+		if err = setField(rv.Field(tag.index), a.SimpleAttribute); err != nil {
+			return fmt.Errorf("cannot unmarshal attribute '%s': %w", a.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// setField stores the value of the attribute in the field.
+func setField(f reflect.Value, sa SimpleAttribute) error {
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(sa.Value)
+	case reflect.Bool:
+		b, err := sa.AsBool()
+		if err != nil {
+			return err
+		}
+		f.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimSpace(sa.Value), 10, f.Type().Bits())
+		if err != nil && strings.TrimSpace(sa.Value) != "" {
+			return err
+		}
+		f.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(strings.TrimSpace(sa.Value), 10, f.Type().Bits())
+		if err != nil && strings.TrimSpace(sa.Value) != "" {
+			return err
+		}
+		f.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		x, err := sa.AsFloat()
+		if err != nil {
+			return err
+		}
+		f.SetFloat(x)
+	}
+
+	return nil
+}
+
+// MarshalAttributes returns a SimpleAttribute for every field of the struct
+// pointed to by v according to the fields' `kanka` tags, ordered as the
+// fields are. Empty fields tagged "omitempty" are skipped.
+func MarshalAttributes(v interface{}) ([]SimpleAttribute, error) {
+	rv, err := structValue(v)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal attributes: %w", err)
+	}
+
+	tags, err := attributeTags(rv.Type())
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal attributes: %w", err)
+	}
+
+	var attrs []SimpleAttribute
+	for i, tag := range tags {
+		f := rv.Field(tag.index)
+		if tag.omitEmpty && isEmptyValue(f) {
+			continue
+		}
+
+		attrs = append(attrs, SimpleAttribute{
+			Name:         tag.name,
+			Value:        fieldValue(f),
+			Type:         string(tag.typ),
+			IsPrivate:    tag.private,
+			DefaultOrder: i,
+		})
+	}
+
+	return attrs, nil
+}
+
+// fieldValue returns the value of the field as an attribute value.
+func fieldValue(f reflect.Value) string {
+	switch f.Kind() {
+	case reflect.Bool:
+		if f.Bool() {
+			return "1"
+		}
+		return "0"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(f.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(f.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(f.Float(), 'f', -1, f.Type().Bits())
+	default:
+		return f.String()
+	}
+}
+
+// SyncAttributes updates the attributes of the entity associated with entID
+// in the Campaign associated with campID to match the struct pointed to by
+// v, as marshaled by MarshalAttributes. Missing attributes are created and
+// attributes with a different value, type, or privacy are updated. Duplicate
+// attributes and the attributes of empty fields tagged "omitempty" are
+// deleted. Attributes without a matching field are left unchanged.
+func (as *AttributeService) SyncAttributes(campID CampaignID, entID EntityID, v interface{}) error {
+	rv, err := structValue(v)
+	if err != nil {
+		return fmt.Errorf("cannot sync attributes: %w", err)
+	}
+
+	tags, err := attributeTags(rv.Type())
+	if err != nil {
+		return fmt.Errorf("cannot sync attributes: %w", err)
+	}
+
+	want, err := MarshalAttributes(v)
+	if err != nil {
+		return err
+	}
+
+	current, err := as.Index(int(campID), int(entID), nil)
+	if err != nil {
+		return fmt.Errorf("cannot sync attributes of Entity (ID: %d): %w", entID, err)
+	}
+
+	byName := make(map[string][]*Attribute)
+	for _, a := range current {
+		byName[a.Name] = append(byName[a.Name], a)
+	}
+
+	wanted := make(map[string]bool)
+	for _, sa := range want {
+		wanted[sa.Name] = true
+
+		existing := byName[sa.Name]
+		if len(existing) == 0 {
+			if _, err = as.Create(int(campID), int(entID), sa); err != nil {
+				return fmt.Errorf("cannot sync attribute '%s' of Entity (ID: %d): %w", sa.Name, entID, err)
+			}
+			continue
+		}
+
+		a := existing[0]
+		if a.Value != sa.Value || a.AttributeType() != sa.AttributeType() || a.IsPrivate != sa.IsPrivate {
+			upd := a.SimpleAttribute
+			upd.Value, upd.Type, upd.IsPrivate = sa.Value, sa.Type, sa.IsPrivate
+			if _, err = as.Update(int(campID), int(entID), a.ID, upd); err != nil {
+				return fmt.Errorf("cannot sync attribute '%s' of Entity (ID: %d): %w", sa.Name, entID, err)
+			}
+		}
+
+		for _, dup := range existing[1:] {
+			if err = as.Delete(int(campID), int(entID), dup.ID); err != nil {
+				return fmt.Errorf("cannot sync attribute '%s' of Entity (ID: %d): %w", sa.Name, entID, err)
+			}
+		}
+	}
+
+	for _, tag := range tags {
+		if wanted[tag.name] {
+			continue
+		}
+
+		for _, a := range byName[tag.name] {
+			if err = as.Delete(int(campID), int(entID), a.ID); err != nil {
+				return fmt.Errorf("cannot sync attribute '%s' of Entity (ID: %d): %w", a.Name, entID, err)
+			}
+		}
+	}
+
+	return nil
+}
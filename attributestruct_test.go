@@ -0,0 +1,154 @@
+package kanka
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// statBlock is a character stat block stored in attributes.
+type statBlock struct {
+	Strength int     `kanka:"Strength,number,private"`
+	Alive    bool    `kanka:"Alive"`
+	Title    string  `kanka:",multiline"`
+	Speed    float64 `kanka:"Speed"`
+	Level    uint8   `kanka:"Level"`
+	Motto    string  `kanka:"Motto,omitempty"`
+	Ignored  string
+	Skipped  int `kanka:"-"`
+}
+
+func TestMarshalAttributes(t *testing.T) {
+	got, err := MarshalAttributes(&statBlock{Strength: 14, Alive: true, Title: "Lady", Speed: 1.5, Level: 3, Ignored: "x", Skipped: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []SimpleAttribute{
+		{Name: "Strength", Value: "14", Type: "number", IsPrivate: true},
+		{Name: "Alive", Value: "1", Type: "checkbox", DefaultOrder: 1},
+		{Name: "Title", Value: "Lady", Type: "text", DefaultOrder: 2},
+		{Name: "Speed", Value: "1.5", Type: "number", DefaultOrder: 3},
+		{Name: "Level", Value: "3", Type: "number", DefaultOrder: 4},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err = MarshalAttributes(statBlock{}); err == nil {
+		t.Error("got nil error for non-pointer, want error")
+	}
+	if _, err = MarshalAttributes(&struct {
+		Tags []int `kanka:"Tags"`
+	}{}); err == nil {
+		t.Error("got nil error for unsupported kind, want error")
+	}
+	if _, err = MarshalAttributes(&struct {
+		Name string `kanka:"Name,bogus"`
+	}{}); err == nil {
+		t.Error("got nil error for unknown option, want error")
+	}
+}
+
+func TestUnmarshalAttributes(t *testing.T) {
+	attr := func(name, value string) *Attribute {
+		return &Attribute{SimpleAttribute: SimpleAttribute{Name: name, Value: value}}
+	}
+
+	tests := []struct {
+		name    string
+		attrs   []*Attribute
+		want    statBlock
+		wantErr bool
+	}{
+		{
+			name:  "All fields",
+			attrs: []*Attribute{attr("Strength", "14"), attr("Alive", "1"), attr("Title", "Lady"), attr("Speed", "1.5"), attr("Level", "3"), attr("Motto", "Winter"), attr("Ignored", "x")},
+			want:  statBlock{Strength: 14, Alive: true, Title: "Lady", Speed: 1.5, Level: 3, Motto: "Winter", Skipped: 7},
+		},
+		{
+			name:  "Missing attributes",
+			attrs: []*Attribute{attr("Strength", ""), attr("Title", "Lady")},
+			want:  statBlock{Title: "Lady", Skipped: 7},
+		},
+		{
+			name:    "Invalid number",
+			attrs:   []*Attribute{attr("Strength", "strong")},
+			wantErr: true,
+		},
+		{
+			name:    "Overflow",
+			attrs:   []*Attribute{attr("Level", "300")},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid checkbox",
+			attrs:   []*Attribute{attr("Alive", "perhaps")},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := statBlock{Skipped: 7}
+			err := UnmarshalAttributes(test.attrs, &got)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err <%v>, want err <%t>", err, test.wantErr)
+			}
+			if test.wantErr {
+				return
+			}
+
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if err := UnmarshalAttributes(nil, (*statBlock)(nil)); err == nil {
+		t.Error("got nil error for nil pointer, want error")
+	}
+}
+
+func TestAttributeService_SyncAttributes(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+	attrs := "/campaigns/1/entities/130/attributes"
+	fk.seed(t, attrs, &Attribute{ID: 87, SimpleAttribute: NewCheckboxAttribute("Alive", false)})
+	fk.seed(t, attrs, &Attribute{ID: 88, SimpleAttribute: NewStandardAttribute("Motto", "Winter")})
+	fk.seed(t, attrs, &Attribute{ID: 89, SimpleAttribute: NewNumberAttribute("Strength", 3)})
+	fk.seed(t, attrs, &Attribute{ID: 90, SimpleAttribute: NewStandardAttribute("Hair", "Brown")})
+
+	err := c.Attributes.SyncAttributes(1, 130, &statBlock{Strength: 14, Title: "Lady", Speed: 1.5, Level: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]string)
+	for _, obj := range fk.list(attrs) {
+		got[obj["name"].(string)] = obj["value"].(string)
+	}
+	want := map[string]string{"Strength": "14", "Alive": "0", "Title": "Lady", "Speed": "1.5", "Level": "1", "Hair": "Brown"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	if obj := fk.object(attrs, 80); obj["is_private"] != true {
+		t.Errorf("got attribute <%v>, want private Strength", obj)
+	}
+	if n := fk.count("PUT " + attrs); n != 1 {
+		t.Errorf("got <%d> updates, want <%d>", n, 1)
+	}
+	if n := fk.count("DELETE " + attrs); n != 2 {
+		t.Errorf("got <%d> deletions, want <%d>", n, 2)
+	}
+
+	fk.fail["POST "+attrs] = http.StatusInternalServerError
+	if err = c.Attributes.SyncAttributes(1, 130, &struct {
+		Eyes string `kanka:"Eyes"`
+	}{"Grey"}); err == nil {
+		t.Error("got nil error, want error")
+	}
+}
@@ -0,0 +1,145 @@
+package kanka
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Henry-Sarabia/blank"
+)
+
+// AttributeTemplate is an ordered list of attributes to apply to entities.
+// The Value of each attribute is its default value.
+type AttributeTemplate struct {
+	Name       string
+	Attributes []SimpleAttribute
+}
+
+// NewAttributeTemplate returns an AttributeTemplate of the attributes of an
+// existing entity ordered by DefaultOrder and then ID.
+func NewAttributeTemplate(name string, attrs []*Attribute) *AttributeTemplate {
+	sorted := append([]*Attribute(nil), attrs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].DefaultOrder != sorted[j].DefaultOrder {
+			return sorted[i].DefaultOrder < sorted[j].DefaultOrder
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	tmpl := &AttributeTemplate{Name: name}
+	for _, a := range sorted {
+		sa := a.SimpleAttribute
+		sa.APIKey = ""
+		tmpl.Attributes = append(tmpl.Attributes, sa)
+	}
+
+	return tmpl
+}
+
+// validate returns an error if an attribute of the AttributeTemplate has no
+// name or shares its name with another.
+func (t *AttributeTemplate) validate() error {
+	seen := make(map[string]bool)
+	for i, sa := range t.Attributes {
+		if blank.Is(sa.Name) {
+			return fmt.Errorf("attribute template '%s' has attribute %d with a missing Name", t.Name, i)
+		}
+		if seen[sa.Name] {
+			return fmt.Errorf("attribute template '%s' has duplicate attribute '%s'", t.Name, sa.Name)
+		}
+		seen[sa.Name] = true
+	}
+
+	return nil
+}
+
+// TemplateOptions configures how an AttributeTemplate is applied.
+// UpdateLayout sets the order and type of existing attributes to those of
+// the template.
+type TemplateOptions struct {
+	UpdateLayout bool
+}
+
+// TemplateResult is the outcome of applying an AttributeTemplate to a
+// single entity. Created and Updated hold the names of the affected
+// attributes. Err is set if the template could not be fully applied.
+type TemplateResult struct {
+	EntityID EntityID
+	Created  []string
+	Updated  []string
+	Err      error
+}
+
+// ApplyTemplate applies the AttributeTemplate to the entity associated with
+// entID in the Campaign associated with campID. Missing attributes are
+// created in the template's order with its default values, and existing
+// attributes without a value receive the default value. Values already
+// filled in are never overwritten.
+func (as *AttributeService) ApplyTemplate(campID CampaignID, entID EntityID, tmpl *AttributeTemplate, opts *TemplateOptions) (*TemplateResult, error) {
+	if opts == nil {
+		opts = &TemplateOptions{}
+	}
+
+	res := &TemplateResult{EntityID: entID}
+	if err := tmpl.validate(); err != nil {
+		return res, fmt.Errorf("cannot apply attribute template: %w", err)
+	}
+
+	current, err := as.Index(int(campID), int(entID), nil)
+	if err != nil {
+		return res, fmt.Errorf("cannot apply attribute template '%s' to Entity (ID: %d): %w", tmpl.Name, entID, err)
+	}
+
+	byName := make(map[string]*Attribute)
+	for _, a := range current {
+		if _, ok := byName[a.Name]; !ok {
+			byName[a.Name] = a
+		}
+	}
+
+	for i, sa := range tmpl.Attributes {
+		sa.DefaultOrder = i
+
+		a, ok := byName[sa.Name]
+		if !ok {
+			if _, err = as.Create(int(campID), int(entID), sa); err != nil {
+				return res, fmt.Errorf("cannot apply attribute template '%s' to Entity (ID: %d): %w", tmpl.Name, entID, err)
+			}
+			res.Created = append(res.Created, sa.Name)
+			continue
+		}
+
+		upd := a.SimpleAttribute
+		if blank.Is(upd.Value) && !blank.Is(sa.Value) {
+			upd.Value = sa.Value
+		}
+		if opts.UpdateLayout {
+			upd.DefaultOrder = sa.DefaultOrder
+			upd.Type = sa.Type
+		}
+		if upd == a.SimpleAttribute {
+			continue
+		}
+
+		if _, err = as.Update(int(campID), int(entID), a.ID, upd); err != nil {
+			return res, fmt.Errorf("cannot apply attribute template '%s' to Entity (ID: %d): %w", tmpl.Name, entID, err)
+		}
+		res.Updated = append(res.Updated, sa.Name)
+	}
+
+	return res, nil
+}
+
+// ApplyTemplateAll applies the AttributeTemplate to every entity associated
+// with entIDs in the Campaign associated with campID, as ApplyTemplate does.
+// A failure for one entity does not stop the others. ApplyTemplateAll
+// returns the TemplateResult of every entity in the order of entIDs.
+func (as *AttributeService) ApplyTemplateAll(campID CampaignID, entIDs []EntityID, tmpl *AttributeTemplate, opts *TemplateOptions) []*TemplateResult {
+	results := make([]*TemplateResult, len(entIDs))
+	for i, id := range entIDs {
+		res, err := as.ApplyTemplate(campID, id, tmpl, opts)
+		res.Err = err
+		results[i] = res
+	}
+
+	return results
+}
@@ -0,0 +1,107 @@
+package kanka
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// testTemplate returns an AttributeTemplate of a small stat block.
+func testTemplate() *AttributeTemplate {
+	wis := NewNumberAttribute("Wisdom", 8)
+	wis.IsPrivate = true
+
+	return &AttributeTemplate{
+		Name: "Stats",
+		Attributes: []SimpleAttribute{
+			NewNumberAttribute("Strength", 10),
+			NewNumberAttribute("Dexterity", 10),
+			wis,
+			NewMultilineAttribute("Notes", ""),
+		},
+	}
+}
+
+func TestNewAttributeTemplate(t *testing.T) {
+	attrs := []*Attribute{
+		{ID: 2, SimpleAttribute: SimpleAttribute{Name: "Dexterity", DefaultOrder: 1, APIKey: "dex"}},
+		{ID: 3, SimpleAttribute: SimpleAttribute{Name: "Wisdom", DefaultOrder: 1}},
+		{ID: 1, SimpleAttribute: SimpleAttribute{Name: "Strength", Value: "10"}},
+	}
+
+	want := &AttributeTemplate{
+		Name: "Stats",
+		Attributes: []SimpleAttribute{
+			{Name: "Strength", Value: "10"},
+			{Name: "Dexterity", DefaultOrder: 1},
+			{Name: "Wisdom", DefaultOrder: 1},
+		},
+	}
+	if diff := cmp.Diff(want, NewAttributeTemplate("Stats", attrs)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAttributeService_ApplyTemplate(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+	attrs := "/campaigns/1/entities/130/attributes"
+	fk.seed(t, attrs, &Attribute{ID: 91, SimpleAttribute: SimpleAttribute{Name: "Dexterity", Type: "number", DefaultOrder: 5}})
+
+	res, err := c.Attributes.ApplyTemplate(1, 130, testTemplate(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &TemplateResult{EntityID: 130, Created: []string{"Wisdom", "Notes"}, Updated: []string{"Dexterity"}}
+	if diff := cmp.Diff(want, res); diff != "" {
+		t.Errorf("result mismatch (-want +got):\n%s", diff)
+	}
+
+	if got := fk.object(attrs, 80)["value"]; got != "12" {
+		t.Errorf("got Strength <%v>, want filled value <%s> kept", got, "12")
+	}
+	if got := fk.object(attrs, 91); got["value"] != "10" || intField(got, "default_order") != 5 {
+		t.Errorf("got Dexterity <%v>, want default value at order <%d>", got, 5)
+	}
+
+	res, err = c.Attributes.ApplyTemplate(1, 130, testTemplate(), &TemplateOptions{UpdateLayout: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"Dexterity"}, res.Updated); diff != "" || len(res.Created) != 0 {
+		t.Errorf("got result <%+v>, want only Dexterity updated", *res)
+	}
+	if got := intField(fk.object(attrs, 91), "default_order"); got != 1 {
+		t.Errorf("got Dexterity order <%d>, want <%d>", got, 1)
+	}
+
+	tmpl := testTemplate()
+	tmpl.Attributes = append(tmpl.Attributes, NewStandardAttribute("Strength", ""))
+	if _, err = c.Attributes.ApplyTemplate(1, 130, tmpl, nil); err == nil {
+		t.Error("got nil error for duplicate attribute, want error")
+	}
+}
+
+func TestAttributeService_ApplyTemplateAll(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+	fk.fail["GET /campaigns/1/entities/131/attributes"] = http.StatusInternalServerError
+
+	results := c.Attributes.ApplyTemplateAll(1, []EntityID{131, 150}, testTemplate(), nil)
+	if len(results) != 2 {
+		t.Fatalf("got <%d> results, want <%d>", len(results), 2)
+	}
+
+	if results[0].EntityID != 131 || results[0].Err == nil {
+		t.Errorf("got result <%+v>, want failure for Entity 131", *results[0])
+	}
+	if results[1].EntityID != 150 || results[1].Err != nil || len(results[1].Created) != 4 {
+		t.Errorf("got result <%+v>, want four attributes created for Entity 150", *results[1])
+	}
+}
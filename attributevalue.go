@@ -0,0 +1,182 @@
+package kanka
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AttributeType identifies the kind of value an attribute holds.
+type AttributeType string
+
+// Available Kanka attribute types. Kanka stores standard single-line
+// attributes without a type and multiline attributes as "text".
+const (
+	AttributeStandard  AttributeType = ""
+	AttributeMultiline AttributeType = "text"
+	AttributeNumber    AttributeType = "number"
+	AttributeCheckbox  AttributeType = "checkbox"
+	AttributeSection   AttributeType = "section"
+	AttributeRandom    AttributeType = "random"
+)
+
+// String returns the name of the AttributeType.
+func (t AttributeType) String() string {
+	if t == AttributeStandard {
+		return "standard"
+	}
+	return string(t)
+}
+
+// NewStandardAttribute returns a single-line SimpleAttribute.
+func NewStandardAttribute(name string, value string) SimpleAttribute {
+	return SimpleAttribute{Name: name, Value: value, Type: string(AttributeStandard)}
+}
+
+// NewMultilineAttribute returns a multiline SimpleAttribute.
+func NewMultilineAttribute(name string, value string) SimpleAttribute {
+	return SimpleAttribute{Name: name, Value: value, Type: string(AttributeMultiline)}
+}
+
+// NewNumberAttribute returns a number SimpleAttribute.
+func NewNumberAttribute(name string, value int) SimpleAttribute {
+	return SimpleAttribute{Name: name, Value: strconv.Itoa(value), Type: string(AttributeNumber)}
+}
+
+// NewCheckboxAttribute returns a checkbox SimpleAttribute.
+func NewCheckboxAttribute(name string, checked bool) SimpleAttribute {
+	value := "0"
+	if checked {
+		value = "1"
+	}
+
+	return SimpleAttribute{Name: name, Value: value, Type: string(AttributeCheckbox)}
+}
+
+// NewSectionAttribute returns a section SimpleAttribute. Sections group the
+// attributes ordered after them.
+func NewSectionAttribute(name string) SimpleAttribute {
+	return SimpleAttribute{Name: name, Type: string(AttributeSection)}
+}
+
+// NewRandomAttribute returns a random SimpleAttribute choosing between the
+// provided choices.
+func NewRandomAttribute(name string, choices ...string) SimpleAttribute {
+	return SimpleAttribute{Name: name, Value: strings.Join(choices, ", "), Type: string(AttributeRandom)}
+}
+
+// AttributeType returns the type of the SimpleAttribute. The type
+// "standard" is treated as AttributeStandard.
+func (sa SimpleAttribute) AttributeType() AttributeType {
+	if sa.Type == "standard" {
+		return AttributeStandard
+	}
+	return AttributeType(sa.Type)
+}
+
+// AsInt returns the SimpleAttribute's Value as an integer. An empty Value is
+// 0.
+func (sa SimpleAttribute) AsInt() (int, error) {
+	v := strings.TrimSpace(sa.Value)
+	if v == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("cannot convert value of attribute '%s' into integer: %w", sa.Name, err)
+	}
+
+	return n, nil
+}
+
+// AsFloat returns the SimpleAttribute's Value as a floating-point number. An
+// empty Value is 0.
+func (sa SimpleAttribute) AsFloat() (float64, error) {
+	v := strings.TrimSpace(sa.Value)
+	if v == "" {
+		return 0, nil
+	}
+
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, fmt.Errorf("cannot convert value of attribute '%s' into float: %w", sa.Name, err)
+	}
+
+	return f, nil
+}
+
+// AsBool returns the SimpleAttribute's Value as a boolean, such as the state
+// of a checkbox. An empty Value is false.
+func (sa SimpleAttribute) AsBool() (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(sa.Value)) {
+	case "1", "true", "yes", "on", "checked":
+		return true, nil
+	case "", "0", "false", "no", "off":
+		return false, nil
+	default:
+		return false, fmt.Errorf("cannot convert value '%s' of attribute '%s' into boolean", sa.Value, sa.Name)
+	}
+}
+
+// Choices returns the comma-separated choices of a random SimpleAttribute.
+func (sa SimpleAttribute) Choices() []string {
+	var choices []string
+	for _, c := range strings.Split(sa.Value, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			choices = append(choices, c)
+		}
+	}
+
+	return choices
+}
+
+// validValue returns an error if the SimpleAttribute's Value cannot be
+// converted into its type.
+func (sa SimpleAttribute) validValue() error {
+	var err error
+	switch sa.AttributeType() {
+	case AttributeNumber:
+		_, err = sa.AsFloat()
+	case AttributeCheckbox:
+		_, err = sa.AsBool()
+	}
+
+	return err
+}
+
+// AttributeGroup contains a section attribute and the attributes ordered
+// after it. Section is nil for the attributes ordered before every section.
+type AttributeGroup struct {
+	Section    *Attribute
+	Attributes []*Attribute
+}
+
+// GroupSections orders the attributes by DefaultOrder and then ID and groups
+// them by the section attributes preceding them.
+func GroupSections(attrs []*Attribute) []*AttributeGroup {
+	sorted := append([]*Attribute(nil), attrs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].DefaultOrder != sorted[j].DefaultOrder {
+			return sorted[i].DefaultOrder < sorted[j].DefaultOrder
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	var groups []*AttributeGroup
+	for _, a := range sorted {
+		if a.AttributeType() == AttributeSection {
+			groups = append(groups, &AttributeGroup{Section: a})
+			continue
+		}
+		if len(groups) == 0 {
+			groups = append(groups, &AttributeGroup{})
+		}
+
+		g := groups[len(groups)-1]
+		g.Attributes = append(g.Attributes, a)
+	}
+
+	return groups
+}
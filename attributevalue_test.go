@@ -0,0 +1,132 @@
+package kanka
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewAttributes(t *testing.T) {
+	tests := []struct {
+		name string
+		got  SimpleAttribute
+		want SimpleAttribute
+	}{
+		{"Standard", NewStandardAttribute("Title", "Lady"), SimpleAttribute{Name: "Title", Value: "Lady"}},
+		{"Multiline", NewMultilineAttribute("Notes", "a\nb"), SimpleAttribute{Name: "Notes", Value: "a\nb", Type: "text"}},
+		{"Number", NewNumberAttribute("Strength", -2), SimpleAttribute{Name: "Strength", Value: "-2", Type: "number"}},
+		{"Checked", NewCheckboxAttribute("Alive", true), SimpleAttribute{Name: "Alive", Value: "1", Type: "checkbox"}},
+		{"Unchecked", NewCheckboxAttribute("Alive", false), SimpleAttribute{Name: "Alive", Value: "0", Type: "checkbox"}},
+		{"Section", NewSectionAttribute("Stats"), SimpleAttribute{Name: "Stats", Type: "section"}},
+		{"Random", NewRandomAttribute("Mood", "Grim", "Merry"), SimpleAttribute{Name: "Mood", Value: "Grim, Merry", Type: "random"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if diff := cmp.Diff(test.want, test.got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSimpleAttribute_AsInt(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{"12", 12, false},
+		{" -3 ", -3, false},
+		{"", 0, false},
+		{"1.5", 0, true},
+		{"twelve", 0, true},
+	}
+	for _, test := range tests {
+		got, err := SimpleAttribute{Name: "Strength", Value: test.value}.AsInt()
+		if (err != nil) != test.wantErr {
+			t.Errorf("got err <%v> for value <%s>, want err <%t>", err, test.value, test.wantErr)
+		}
+		if got != test.want {
+			t.Errorf("got <%d> for value <%s>, want <%d>", got, test.value, test.want)
+		}
+	}
+
+	if got, err := (SimpleAttribute{Value: "1.5"}).AsFloat(); err != nil || got != 1.5 {
+		t.Errorf("got <%v> and err <%v>, want <%v>", got, err, 1.5)
+	}
+	if _, err := (SimpleAttribute{Value: "x"}).AsFloat(); err == nil {
+		t.Error("got nil error for invalid float, want error")
+	}
+}
+
+func TestSimpleAttribute_AsBool(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    bool
+		wantErr bool
+	}{
+		{"1", true, false},
+		{"True", true, false},
+		{"on", true, false},
+		{"0", false, false},
+		{"", false, false},
+		{"maybe", false, true},
+	}
+	for _, test := range tests {
+		got, err := SimpleAttribute{Name: "Alive", Value: test.value}.AsBool()
+		if (err != nil) != test.wantErr {
+			t.Errorf("got err <%v> for value <%s>, want err <%t>", err, test.value, test.wantErr)
+		}
+		if got != test.want {
+			t.Errorf("got <%t> for value <%s>, want <%t>", got, test.value, test.want)
+		}
+	}
+}
+
+func TestSimpleAttribute_Choices(t *testing.T) {
+	got := SimpleAttribute{Value: "Grim, Merry,, Sly "}.Choices()
+	if diff := cmp.Diff([]string{"Grim", "Merry", "Sly"}, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	if got := (SimpleAttribute{Type: "standard"}).AttributeType(); got != AttributeStandard {
+		t.Errorf("got type <%s> for standard, want <%s>", got, AttributeStandard)
+	}
+}
+
+func TestGroupSections(t *testing.T) {
+	attr := func(id, order int, name string, typ AttributeType) *Attribute {
+		return &Attribute{ID: id, SimpleAttribute: SimpleAttribute{Name: name, DefaultOrder: order, Type: string(typ)}}
+	}
+
+	attrs := []*Attribute{
+		attr(1, 4, "Wisdom", AttributeNumber),
+		attr(2, 2, "Strength", AttributeNumber),
+		attr(3, 1, "Stats", AttributeSection),
+		attr(4, 0, "Title", AttributeStandard),
+		attr(5, 5, "Background", AttributeSection),
+		attr(6, 6, "Notes", AttributeMultiline),
+		attr(7, 2, "Dexterity", AttributeNumber),
+	}
+
+	var got [][]string
+	for _, g := range GroupSections(attrs) {
+		names := []string{""}
+		if g.Section != nil {
+			names[0] = g.Section.Name
+		}
+		for _, a := range g.Attributes {
+			names = append(names, a.Name)
+		}
+		got = append(got, names)
+	}
+
+	want := [][]string{
+		{"", "Title"},
+		{"Stats", "Strength", "Dexterity", "Wisdom"},
+		{"Background", "Notes"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
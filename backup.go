@@ -0,0 +1,642 @@
+package kanka
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const (
+	backupFormat string = "kanka-backup"
+
+	// BackupVersion is the version of the archive format written by
+	// WriteArchive. ReadArchive rejects archives with a newer version.
+	BackupVersion int = 1
+)
+
+// Manifest describes the contents of a backup archive.
+type Manifest struct {
+	Format     string     `json:"format"`
+	Version    int        `json:"version"`
+	CampaignID CampaignID `json:"campaign_id"`
+	CreatedAt  time.Time  `json:"created_at"`
+	Files      []string   `json:"files"`
+}
+
+// EntityData contains the entity-level sub-resources of a single entity.
+// Type and ID identify the object the entity belongs to.
+type EntityData struct {
+	EntityID     EntityID           `json:"entity_id"`
+	Type         EntityType         `json:"type"`
+	ID           ObjectID           `json:"id"`
+	Attributes   []*Attribute       `json:"attributes"`
+	EntityEvents []*EntityEvent     `json:"entity_events"`
+	EntityNotes  []*EntityNote      `json:"entity_notes"`
+	EntityTags   []*EntityTag       `json:"entity_tags"`
+	Inventory    []*EntityInventory `json:"inventory"`
+	Relations    []*Relation        `json:"relations"`
+}
+
+// Image contains the image of a single entity.
+// Data is stored in the archive under Path rather than inline.
+type Image struct {
+	EntityID EntityID `json:"entity_id"`
+	Path     string   `json:"path"`
+	Data     []byte   `json:"-"`
+}
+
+// Backup contains a complete copy of a campaign.
+type Backup struct {
+	Version   int
+	CreatedAt time.Time
+	Campaign  *Campaign
+
+	Characters          []*Character
+	Locations           []*Location
+	Families            []*Family
+	Organizations       []*Organization
+	OrganizationMembers []*OrganizationMember
+	Items               []*Item
+	Notes               []*Note
+	Events              []*Event
+	Races               []*Race
+	Quests              []*Quest
+	QuestCharacters     []*QuestCharacter
+	QuestLocations      []*QuestLocation
+	QuestItems          []*QuestItem
+	QuestOrganizations  []*QuestOrganization
+	Journals            []*Journal
+	Tags                []*Tag
+	MapPoints           []*MapPoint
+
+	Entities []*EntityData
+	Images   []*Image
+}
+
+// BackupOptions configures the behavior of Backup.
+type BackupOptions struct {
+	// IncludeImages downloads the custom image of every entity into the
+	// backup.
+	IncludeImages bool
+}
+
+// Backup walks every service of the Client and returns a complete copy of
+// the Campaign associated with campID.
+// If opts is nil, images are not included.
+func (c *Client) Backup(campID CampaignID, opts *BackupOptions) (*Backup, error) {
+	if opts == nil {
+		opts = &BackupOptions{}
+	}
+
+	b := &Backup{
+		Version:   BackupVersion,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	var err error
+	if b.Campaign, err = c.Campaigns.Get(int(campID)); err != nil {
+		return nil, fmt.Errorf("cannot back up Campaign (ID: %d): %w", campID, err)
+	}
+
+	if err = c.backupObjects(int(campID), b); err != nil {
+		return nil, fmt.Errorf("cannot back up Campaign (ID: %d): %w", campID, err)
+	}
+
+	if err = c.backupChildren(int(campID), b); err != nil {
+		return nil, fmt.Errorf("cannot back up Campaign (ID: %d): %w", campID, err)
+	}
+
+	for _, ref := range b.refs() {
+		ent, err := c.backupEntity(int(campID), ref)
+		if err != nil {
+			return nil, fmt.Errorf("cannot back up Campaign (ID: %d): %w", campID, err)
+		}
+		b.Entities = append(b.Entities, ent)
+	}
+
+	if opts.IncludeImages {
+		for _, ref := range b.refs() {
+			if ref.image == "" {
+				continue
+			}
+
+			img, err := c.backupImage(ref.EntityID, ref.image)
+			if err != nil {
+				return nil, fmt.Errorf("cannot back up Campaign (ID: %d): %w", campID, err)
+			}
+			b.Images = append(b.Images, img)
+		}
+	}
+
+	return b, nil
+}
+
+// backupObjects stores every core object of the Campaign associated with
+// campID in the provided Backup.
+func (c *Client) backupObjects(campID int, b *Backup) error {
+	var err error
+
+	if b.Characters, err = c.Characters.Index(campID, nil); err != nil {
+		return err
+	}
+	if b.Locations, err = c.Locations.Index(campID, nil); err != nil {
+		return err
+	}
+	if b.Families, err = c.Families.Index(campID, nil); err != nil {
+		return err
+	}
+	if b.Organizations, err = c.Organizations.Index(campID, nil); err != nil {
+		return err
+	}
+	if b.Items, err = c.Items.Index(campID, nil); err != nil {
+		return err
+	}
+	if b.Notes, err = c.Notes.Index(campID, nil); err != nil {
+		return err
+	}
+	if b.Events, err = c.Events.Index(campID, nil); err != nil {
+		return err
+	}
+	if b.Races, err = c.Races.Index(campID, nil); err != nil {
+		return err
+	}
+	if b.Quests, err = c.Quests.Index(campID, nil); err != nil {
+		return err
+	}
+	if b.Journals, err = c.Journals.Index(campID, nil); err != nil {
+		return err
+	}
+	if b.Tags, err = c.Tags.Index(campID, nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// backupChildren stores the organization members, quest elements, and map
+// points of the core objects already stored in the provided Backup.
+func (c *Client) backupChildren(campID int, b *Backup) error {
+	for _, org := range b.Organizations {
+		mems, err := c.OrganizationMembers.Index(campID, int(org.ID), nil)
+		if err != nil {
+			return err
+		}
+		b.OrganizationMembers = append(b.OrganizationMembers, mems...)
+	}
+
+	for _, qst := range b.Quests {
+		chars, err := c.QuestCharacters.Index(campID, int(qst.ID), nil)
+		if err != nil {
+			return err
+		}
+		b.QuestCharacters = append(b.QuestCharacters, chars...)
+
+		locs, err := c.QuestLocations.Index(campID, int(qst.ID), nil)
+		if err != nil {
+			return err
+		}
+		b.QuestLocations = append(b.QuestLocations, locs...)
+
+		items, err := c.QuestItems.Index(campID, int(qst.ID), nil)
+		if err != nil {
+			return err
+		}
+		b.QuestItems = append(b.QuestItems, items...)
+
+		orgs, err := c.QuestOrganizations.Index(campID, int(qst.ID), nil)
+		if err != nil {
+			return err
+		}
+		b.QuestOrganizations = append(b.QuestOrganizations, orgs...)
+	}
+
+	for _, loc := range b.Locations {
+		pts, err := c.MapPoints.Index(campID, int(loc.ID), nil)
+		if err != nil {
+			return err
+		}
+		b.MapPoints = append(b.MapPoints, pts...)
+	}
+
+	return nil
+}
+
+// backupEntity returns the sub-resources of the entity described by ref.
+func (c *Client) backupEntity(campID int, ref entityRef) (*EntityData, error) {
+	var err error
+	ent := &EntityData{
+		EntityID: EntityID(ref.EntityID),
+		Type:     ref.Type,
+		ID:       ObjectID(ref.ID),
+	}
+
+	if ent.Attributes, err = c.Attributes.Index(campID, ref.EntityID, nil); err != nil {
+		return nil, err
+	}
+	if ent.EntityEvents, err = c.EntityEvents.Index(campID, ref.EntityID, nil); err != nil {
+		return nil, err
+	}
+	if ent.EntityNotes, err = c.EntityNotes.Index(campID, ref.EntityID, nil); err != nil {
+		return nil, err
+	}
+	if ent.EntityTags, err = c.EntityTags.Index(campID, ref.EntityID, nil); err != nil {
+		return nil, err
+	}
+	if ent.Inventory, err = c.EntityInventories.Index(campID, ref.EntityID, nil); err != nil {
+		return nil, err
+	}
+	if ent.Relations, err = c.Relations.Index(campID, ref.EntityID, nil); err != nil {
+		return nil, err
+	}
+
+	return ent, nil
+}
+
+// backupImage downloads the image found at the provided URL for the entity
+// associated with entID. The request is sent without the Client's token
+// because images are served by a separate host.
+func (c *Client) backupImage(entID int, url string) (*Image, error) {
+	resp, err := c.http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("cannot download image for Entity (ID: %d): %w", entID, err)
+	}
+	defer resp.Body.Close()
+
+	if !isSuccess(resp.StatusCode) {
+		err = &serverError{code: resp.StatusCode, status: resp.Status, temporary: isTemporary(resp.StatusCode)}
+		return nil, fmt.Errorf("cannot download image for Entity (ID: %d): %w", entID, err)
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read image for Entity (ID: %d): %w", entID, err)
+	}
+
+	ext := path.Ext(strings.SplitN(url, "?", 2)[0])
+
+	return &Image{
+		EntityID: EntityID(entID),
+		Path:     fmt.Sprintf("images/%d%s", entID, ext),
+		Data:     data,
+	}, nil
+}
+
+// refs returns a reference to every core object in the Backup.
+func (b *Backup) refs() []entityRef {
+	var refs []entityRef
+
+	for _, v := range b.Characters {
+		refs = append(refs, entityRef{Type: TypeCharacter, ID: int(v.ID), EntityID: int(v.EntityID), Name: v.Name, Tags: tagInts(v.Tags), IsPrivate: v.IsPrivate, image: customImage(v.HasCustomImage, v.ImageFull)})
+	}
+	for _, v := range b.Locations {
+		refs = append(refs, entityRef{Type: TypeLocation, ID: int(v.ID), EntityID: int(v.EntityID), Name: v.Name, Parent: int(v.ParentLocationID), Tags: tagInts(v.Tags), IsPrivate: v.IsPrivate, image: customImage(v.HasCustomImage, v.ImageFull)})
+	}
+	for _, v := range b.Families {
+		refs = append(refs, entityRef{Type: TypeFamily, ID: int(v.ID), EntityID: int(v.EntityID), Name: v.Name, Parent: int(v.FamilyID), Tags: tagInts(v.Tags), IsPrivate: v.IsPrivate, image: customImage(v.HasCustomImage, v.ImageFull)})
+	}
+	for _, v := range b.Organizations {
+		refs = append(refs, entityRef{Type: TypeOrganization, ID: int(v.ID), EntityID: int(v.EntityID), Name: v.Name, Parent: int(v.OrganizationID), Tags: tagInts(v.Tags), IsPrivate: v.IsPrivate, image: customImage(v.HasCustomImage, v.ImageFull)})
+	}
+	for _, v := range b.Items {
+		refs = append(refs, entityRef{Type: TypeItem, ID: int(v.ID), EntityID: int(v.EntityID), Name: v.Name, Tags: tagInts(v.Tags), IsPrivate: v.IsPrivate, image: customImage(v.HasCustomImage, v.ImageFull)})
+	}
+	for _, v := range b.Notes {
+		refs = append(refs, entityRef{Type: TypeNote, ID: int(v.ID), EntityID: int(v.EntityID), Name: v.Name, Tags: tagInts(v.Tags), IsPrivate: v.IsPrivate, image: customImage(v.HasCustomImage, v.ImageFull)})
+	}
+	for _, v := range b.Events {
+		refs = append(refs, entityRef{Type: TypeEvent, ID: int(v.ID), EntityID: int(v.EntityID), Name: v.Name, Tags: tagInts(v.Tags), IsPrivate: v.IsPrivate, image: customImage(v.HasCustomImage, v.ImageFull)})
+	}
+	for _, v := range b.Races {
+		refs = append(refs, entityRef{Type: TypeRace, ID: int(v.ID), EntityID: int(v.EntityID), Name: v.Name, Parent: int(v.RaceID), Tags: tagInts(v.Tags), IsPrivate: v.IsPrivate, image: customImage(v.HasCustomImage, v.ImageFull)})
+	}
+	for _, v := range b.Quests {
+		refs = append(refs, entityRef{Type: TypeQuest, ID: int(v.ID), EntityID: int(v.EntityID), Name: v.Name, Parent: int(v.QuestID), Tags: tagInts(v.Tags), IsPrivate: v.IsPrivate, image: customImage(v.HasCustomImage, v.ImageFull)})
+	}
+	for _, v := range b.Journals {
+		refs = append(refs, entityRef{Type: TypeJournal, ID: int(v.ID), EntityID: int(v.EntityID), Name: v.Name, Tags: tagInts(v.Tags), IsPrivate: v.IsPrivate, image: customImage(v.HasCustomImage, v.ImageFull)})
+	}
+	for _, v := range b.Tags {
+		refs = append(refs, entityRef{Type: TypeTag, ID: int(v.ID), EntityID: int(v.EntityID), Name: v.Name, Parent: int(v.TagID), Tags: tagInts(v.Tags), IsPrivate: v.IsPrivate, image: customImage(v.HasCustomImage, v.ImageFull)})
+	}
+
+	return refs
+}
+
+// backupFile pairs the name of a file in a backup archive with the Backup
+// field it stores.
+type backupFile struct {
+	name string
+	v    interface{}
+}
+
+// files returns every JSON file of a backup archive paired with the Backup
+// field it stores.
+func (b *Backup) files() []backupFile {
+	return []backupFile{
+		{"campaign.json", &b.Campaign},
+		{"characters.json", &b.Characters},
+		{"locations.json", &b.Locations},
+		{"families.json", &b.Families},
+		{"organisations.json", &b.Organizations},
+		{"organisation_members.json", &b.OrganizationMembers},
+		{"items.json", &b.Items},
+		{"notes.json", &b.Notes},
+		{"events.json", &b.Events},
+		{"races.json", &b.Races},
+		{"quests.json", &b.Quests},
+		{"quest_characters.json", &b.QuestCharacters},
+		{"quest_locations.json", &b.QuestLocations},
+		{"quest_items.json", &b.QuestItems},
+		{"quest_organisations.json", &b.QuestOrganizations},
+		{"journals.json", &b.Journals},
+		{"tags.json", &b.Tags},
+		{"map_points.json", &b.MapPoints},
+		{"entities.json", &b.Entities},
+		{"images.json", &b.Images},
+	}
+}
+
+const manifestFile string = "manifest.json"
+
+// WriteArchive writes the Backup to the provided writer as a zip archive of
+// JSON files described by a manifest. Images, if any, are stored alongside
+// the JSON files.
+func (b *Backup) WriteArchive(w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	m := Manifest{
+		Format:    backupFormat,
+		Version:   b.Version,
+		CreatedAt: b.CreatedAt,
+	}
+	if b.Campaign != nil {
+		m.CampaignID = b.Campaign.ID
+	}
+	for _, f := range b.files() {
+		m.Files = append(m.Files, f.name)
+	}
+
+	if err := writeArchiveJSON(zw, manifestFile, m); err != nil {
+		return err
+	}
+
+	for _, f := range b.files() {
+		if err := writeArchiveJSON(zw, f.name, f.v); err != nil {
+			return err
+		}
+	}
+
+	for _, img := range b.Images {
+		fw, err := zw.Create(img.Path)
+		if err != nil {
+			return fmt.Errorf("cannot create archive file '%s': %w", img.Path, err)
+		}
+		if _, err = fw.Write(img.Data); err != nil {
+			return fmt.Errorf("cannot write archive file '%s': %w", img.Path, err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("cannot close archive: %w", err)
+	}
+
+	return nil
+}
+
+// writeArchiveJSON writes the JSON encoding of v to a new file with the
+// provided name in the zip archive.
+func writeArchiveJSON(zw *zip.Writer, name string, v interface{}) error {
+	b, err := marshalRecord(v)
+	if err != nil {
+		return fmt.Errorf("cannot marshal archive file '%s': %w", name, err)
+	}
+
+	fw, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("cannot create archive file '%s': %w", name, err)
+	}
+
+	if _, err = fw.Write(b); err != nil {
+		return fmt.Errorf("cannot write archive file '%s': %w", name, err)
+	}
+
+	return nil
+}
+
+// ReadArchive reads a Backup from a zip archive previously written by
+// WriteArchive.
+func ReadArchive(r io.ReaderAt, size int64) (*Backup, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open archive: %w", err)
+	}
+
+	files := make(map[string]*zip.File)
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	var m Manifest
+	if err = readArchiveJSON(files, manifestFile, &m); err != nil {
+		return nil, err
+	}
+
+	if m.Format != backupFormat {
+		return nil, fmt.Errorf("archive format '%s' is not a Kanka backup", m.Format)
+	}
+
+	if m.Version < 1 {
+		return nil, fmt.Errorf("archive version (%d) is invalid", m.Version)
+	}
+
+	if m.Version > BackupVersion {
+		return nil, fmt.Errorf("archive version (%d) is newer than supported version (%d)", m.Version, BackupVersion)
+	}
+
+	b := &Backup{
+		Version:   m.Version,
+		CreatedAt: m.CreatedAt,
+	}
+
+	for _, f := range b.files() {
+		if _, ok := files[f.name]; !ok {
+			continue
+		}
+		if err = readArchiveJSON(files, f.name, f.v); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, img := range b.Images {
+		f, ok := files[img.Path]
+		if !ok {
+			return nil, fmt.Errorf("cannot find archive file '%s'", img.Path)
+		}
+		if img.Data, err = readArchiveFile(f); err != nil {
+			return nil, err
+		}
+	}
+
+	return b, nil
+}
+
+// readArchiveJSON unmarshals the file with the provided name from the zip
+// archive into v.
+func readArchiveJSON(files map[string]*zip.File, name string, v interface{}) error {
+	f, ok := files[name]
+	if !ok {
+		return fmt.Errorf("cannot find archive file '%s'", name)
+	}
+
+	b, err := readArchiveFile(f)
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("cannot unmarshal archive file '%s': %w", name, err)
+	}
+
+	return nil
+}
+
+// readArchiveFile returns the contents of the provided zip file.
+func readArchiveFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("cannot open archive file '%s': %w", f.Name, err)
+	}
+	defer rc.Close()
+
+	b, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read archive file '%s': %w", f.Name, err)
+	}
+
+	return b, nil
+}
+
+// marshalRecord returns the JSON encoding of v like json.Marshal, except that
+// the MarshalJSON methods of this package's types are ignored. This prevents
+// the MarshalJSON methods of embedded Simple types from hiding the remaining
+// fields of the types embedding them, such as the ID of a Character.
+func marshalRecord(v interface{}) ([]byte, error) {
+	return json.Marshal(recordValue(reflect.ValueOf(v)))
+}
+
+var pkgPath = reflect.TypeOf(Backup{}).PkgPath()
+
+// recordValue returns a representation of v which encodes into the same JSON
+// as v would without the MarshalJSON methods of this package's types.
+func recordValue(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return recordValue(v.Elem())
+	case reflect.Struct:
+		if v.Type().PkgPath() != pkgPath {
+			return v.Interface()
+		}
+		m := make(map[string]interface{})
+		recordFields(v, m)
+		return m
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		fallthrough
+	case reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Interface()
+		}
+		s := make([]interface{}, v.Len())
+		for i := range s {
+			s[i] = recordValue(v.Index(i))
+		}
+		return s
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		m := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			m[fmt.Sprint(iter.Key().Interface())] = recordValue(iter.Value())
+		}
+		return m
+	default:
+		return v.Interface()
+	}
+}
+
+// recordFields stores the JSON fields of the struct v in m, flattening
+// embedded structs.
+func recordFields(v reflect.Value, m map[string]interface{}) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		fv := v.Field(i)
+
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			recordFields(fv, m)
+			continue
+		}
+
+		if f.PkgPath != "" {
+			continue
+		}
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name := f.Name
+		opts := strings.Split(tag, ",")
+		if opts[0] != "" {
+			name = opts[0]
+		}
+
+		omit := false
+		for _, o := range opts[1:] {
+			if o == "omitempty" {
+				omit = true
+			}
+		}
+		if omit && isEmptyValue(fv) {
+			continue
+		}
+
+		m[name] = recordValue(fv)
+	}
+}
+
+// isEmptyValue reports whether v is empty according to the omitempty option
+// of encoding/json.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
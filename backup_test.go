@@ -0,0 +1,256 @@
+package kanka
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testFileBackup string = "test_data/backup.json"
+
+// testBackup returns the Backup stored in the shared test fixture.
+func testBackup(t *testing.T) *Backup {
+	f, err := ioutil.ReadFile(testFileBackup)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b Backup
+	if err = json.Unmarshal(f, &b); err != nil {
+		t.Fatal(err)
+	}
+
+	return &b
+}
+
+// seedCampaign stores a small campaign in the provided fakeKanka.
+func seedCampaign(t *testing.T, fk *fakeKanka, imageURL string) {
+	fk.seed(t, "/campaigns", &Campaign{ID: 1, Name: "Westeros"})
+
+	fk.seed(t, "/campaigns/1/locations", &Location{ID: 10, EntityID: 110, SimpleLocation: SimpleLocation{Name: "Winterfell"}})
+	fk.seed(t, "/campaigns/1/families", &Family{ID: 20, EntityID: 120, SimpleFamily: SimpleFamily{Name: "Stark", LocationID: 10}})
+	fk.seed(t, "/campaigns/1/characters", &Character{
+		ID:             30,
+		EntityID:       130,
+		ImageFull:      imageURL,
+		HasCustomImage: imageURL != "",
+		SimpleCharacter: SimpleCharacter{
+			Name:       "Arya Stark",
+			FamilyID:   20,
+			LocationID: 10,
+			Entry:      "<p>Lives in [location:10]</p>",
+		},
+	})
+	fk.seed(t, "/campaigns/1/characters", &Character{ID: 31, EntityID: 131, SimpleCharacter: SimpleCharacter{Name: "Jon Snow", FamilyID: 20, IsPrivate: true}})
+	fk.seed(t, "/campaigns/1/items", &Item{ID: 40, EntityID: 140, SimpleItem: SimpleItem{Name: "Needle", CharacterID: 30}})
+	fk.seed(t, "/campaigns/1/organisations", &Organization{ID: 50, EntityID: 150, SimpleOrganization: SimpleOrganization{Name: "Night's Watch"}})
+	fk.seed(t, "/campaigns/1/quests", &Quest{ID: 60, EntityID: 160, SimpleQuest: SimpleQuest{Name: "Beyond the Wall"}})
+	fk.seed(t, "/campaigns/1/tags", &Tag{ID: 70, EntityID: 170, SimpleTag: SimpleTag{Name: "North"}})
+
+	fk.seed(t, "/campaigns/1/organisations/50/organisation_members", &OrganizationMember{ID: 51, SimpleOrganizationMember: SimpleOrganizationMember{CharacterID: 31, OrganizationID: 50, Role: "Lord Commander"}})
+	fk.seed(t, "/campaigns/1/quests/60/quest_characters", &QuestCharacter{ID: 61, SimpleQuestCharacter: SimpleQuestCharacter{QuestID: 60, CharacterID: 31}})
+	fk.seed(t, "/campaigns/1/quests/60/quest_locations", &QuestLocation{ID: 62, SimpleQuestLocation: SimpleQuestLocation{QuestID: 60, LocationID: 10}})
+	fk.seed(t, "/campaigns/1/quests/60/quest_items", &QuestItem{ID: 63, SimpleQuestItem: SimpleQuestItem{QuestID: 60, ItemID: 40}})
+	fk.seed(t, "/campaigns/1/quests/60/quest_organisations", &QuestOrganization{ID: 64, SimpleQuestOrganization: SimpleQuestOrganization{QuestID: 60, OrganizationID: 50}})
+	fk.seed(t, "/campaigns/1/locations/10/map_points", &MapPoint{SimpleMapPoint: SimpleMapPoint{LocationID: 10, TargetEntityID: 130, Color: "red", Icon: "pin", Shape: "circle", Size: "small"}})
+
+	fk.seed(t, "/campaigns/1/entities/130/attributes", &Attribute{ID: 80, EntityID: 130, SimpleAttribute: SimpleAttribute{Name: "Strength", Value: "12", Type: "number"}})
+	fk.seed(t, "/campaigns/1/entities/130/entity_events", &EntityEvent{ID: 81, SimpleEntityEvent: SimpleEntityEvent{EntityID: 130, Day: 1, Month: 2, Year: 290}})
+	fk.seed(t, "/campaigns/1/entities/130/entity_notes", &EntityNote{ID: 82, SimpleEntityNote: SimpleEntityNote{EntityID: 130, Name: "Secret", IsPrivate: true}})
+	fk.seed(t, "/campaigns/1/entities/130/entity_tags", &EntityTag{ID: 83, SimpleEntityTag: SimpleEntityTag{EntityID: 130, TagID: 70}})
+	fk.seed(t, "/campaigns/1/entities/130/inventory", &EntityInventory{ID: 84, SimpleEntityInventory: SimpleEntityInventory{EntityID: 130, ItemID: 40, Amount: 1}})
+	fk.seed(t, "/campaigns/1/entities/130/relations", &Relation{ID: 85, SimpleRelation: SimpleRelation{Relation: "Sister", OwnerID: 130, TargetID: 131, Attitude: 80}})
+	fk.seed(t, "/campaigns/1/entities/131/relations", &Relation{ID: 86, SimpleRelation: SimpleRelation{Relation: "Brother", OwnerID: 131, TargetID: 130, Attitude: 90}})
+}
+
+func TestClient_Backup(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	img := []byte("not a real image")
+	fk.images["/images/arya.png"] = img
+	seedCampaign(t, fk, ts.URL+"/images/arya.png")
+
+	b, err := c.Backup(1, &BackupOptions{IncludeImages: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.Version != BackupVersion {
+		t.Errorf("got version <%d>, want <%d>", b.Version, BackupVersion)
+	}
+	if b.Campaign.Name != "Westeros" {
+		t.Errorf("got campaign <%s>, want <%s>", b.Campaign.Name, "Westeros")
+	}
+
+	counts := []struct {
+		name string
+		got  int
+		want int
+	}{
+		{"Characters", len(b.Characters), 2},
+		{"Locations", len(b.Locations), 1},
+		{"Families", len(b.Families), 1},
+		{"Organizations", len(b.Organizations), 1},
+		{"OrganizationMembers", len(b.OrganizationMembers), 1},
+		{"Items", len(b.Items), 1},
+		{"Quests", len(b.Quests), 1},
+		{"QuestCharacters", len(b.QuestCharacters), 1},
+		{"QuestLocations", len(b.QuestLocations), 1},
+		{"QuestItems", len(b.QuestItems), 1},
+		{"QuestOrganizations", len(b.QuestOrganizations), 1},
+		{"Tags", len(b.Tags), 1},
+		{"MapPoints", len(b.MapPoints), 1},
+		{"Entities", len(b.Entities), 8},
+		{"Images", len(b.Images), 1},
+	}
+	for _, cnt := range counts {
+		if cnt.got != cnt.want {
+			t.Errorf("got <%d> %s, want <%d>", cnt.got, cnt.name, cnt.want)
+		}
+	}
+
+	var arya *EntityData
+	for _, ent := range b.Entities {
+		if ent.EntityID == 130 {
+			arya = ent
+		}
+	}
+	if arya == nil {
+		t.Fatal("missing EntityData for Entity (ID: 130)")
+	}
+	if arya.Type != TypeCharacter || arya.ID != 30 {
+		t.Errorf("got <%s %d>, want <%s %d>", arya.Type, arya.ID, TypeCharacter, 30)
+	}
+	if len(arya.Attributes) != 1 || len(arya.EntityEvents) != 1 || len(arya.EntityNotes) != 1 ||
+		len(arya.EntityTags) != 1 || len(arya.Inventory) != 1 || len(arya.Relations) != 1 {
+		t.Errorf("missing sub-resources for Entity (ID: 130): %+v", arya)
+	}
+
+	if diff := cmp.Diff(img, b.Images[0].Data); diff != "" {
+		t.Errorf("image mismatch (-want +got):\n%s", diff)
+	}
+	if b.Images[0].Path != "images/130.png" {
+		t.Errorf("got image path <%s>, want <%s>", b.Images[0].Path, "images/130.png")
+	}
+}
+
+func TestClient_Backup_Error(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+	fk.fail["GET /campaigns/1/entities/131/relations"] = http.StatusTooManyRequests
+
+	if _, err := c.Backup(1, nil); err == nil {
+		t.Fatal("got nil error, want error")
+	}
+}
+
+func TestBackup_Archive(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	fk.images["/images/arya.jpeg"] = []byte("not a real image")
+	seedCampaign(t, fk, ts.URL+"/images/arya.jpeg")
+
+	want, err := c.Backup(1, &BackupOptions{IncludeImages: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err = want.WriteArchive(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadArchive(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	if got.Characters[0].ID != 30 || got.Characters[0].EntityID != 130 {
+		t.Errorf("got Character IDs <%d, %d>, want <%d, %d>", got.Characters[0].ID, got.Characters[0].EntityID, 30, 130)
+	}
+}
+
+func TestReadArchive_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest interface{}
+	}{
+		{
+			name:     "Missing manifest",
+			manifest: nil,
+		},
+		{
+			name:     "Wrong format",
+			manifest: Manifest{Format: "something-else", Version: BackupVersion},
+		},
+		{
+			name:     "Newer version",
+			manifest: Manifest{Format: backupFormat, Version: BackupVersion + 1},
+		},
+		{
+			name:     "Missing version",
+			manifest: map[string]string{"format": backupFormat},
+		},
+		{
+			name:     "Zero version",
+			manifest: Manifest{Format: backupFormat, Version: 0},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			zw := zip.NewWriter(&buf)
+			if test.manifest != nil {
+				fw, err := zw.Create(manifestFile)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err = json.NewEncoder(fw).Encode(test.manifest); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := zw.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := ReadArchive(bytes.NewReader(buf.Bytes()), int64(buf.Len())); err == nil {
+				t.Fatal("got nil error, want error")
+			}
+		})
+	}
+}
+
+func TestMarshalRecord(t *testing.T) {
+	ch := &Character{
+		ID:              30,
+		EntityID:        130,
+		SimpleCharacter: SimpleCharacter{Name: "Arya Stark"},
+	}
+
+	b, err := marshalRecord(ch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got Character
+	if err = json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(*ch, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,181 @@
+package kanka
+
+import (
+	"fmt"
+	"sort"
+)
+
+// TaggedEntity is an entity carrying a tag. TagID is the ID of the tag the
+// entity carries, which is a child of the requested tag if child tags were
+// included.
+type TaggedEntity struct {
+	EntityID EntityID
+	Type     EntityType
+	ID       ObjectID
+	Name     string
+	TagID    TagID
+}
+
+// Tagged returns every entity of the Campaign associated with campID
+// carrying the tag associated with tagID, ordered by entity ID. If children
+// is true, entities carrying a descendant of the tag are included.
+func (c *Client) Tagged(campID CampaignID, tagID TagID, children bool) ([]*TaggedEntity, error) {
+	b := &Backup{}
+	if err := c.backupObjects(int(campID), b); err != nil {
+		return nil, fmt.Errorf("cannot get entities tagged with Tag (ID: %d) in Campaign (ID: %d): %w", tagID, campID, err)
+	}
+
+	return b.Tagged(tagID, children), nil
+}
+
+// Tagged returns every entity of the Backup carrying the tag associated
+// with tagID, as Client.Tagged does.
+func (b *Backup) Tagged(tagID TagID, children bool) []*TaggedEntity {
+	tags := b.tagSet(int(tagID), children)
+
+	var ents []*TaggedEntity
+	for _, ref := range b.refs() {
+		for _, id := range ref.Tags {
+			if !tags[id] {
+				continue
+			}
+
+			ents = append(ents, &TaggedEntity{EntityID: EntityID(ref.EntityID), Type: ref.Type, ID: ObjectID(ref.ID), Name: ref.Name, TagID: TagID(id)})
+			break
+		}
+	}
+
+	sort.Slice(ents, func(i, j int) bool { return ents[i].EntityID < ents[j].EntityID })
+	return ents
+}
+
+// TagOutcome is the outcome of a bulk tag operation on a single entity.
+// Changed is false if the entity already matched the operation. Err is set
+// if the operation failed for the entity.
+type TagOutcome struct {
+	EntityID EntityID
+	Changed  bool
+	Err      error
+}
+
+// TagAll adds the tag associated with tagID to every entity associated with
+// entIDs in the Campaign associated with campID. Entities already carrying
+// the tag are left unchanged. The entities are tagged concurrently and a
+// failure for one entity does not stop the others. Requests rejected by the
+// Kanka rate limit are retried with a growing wait. TagAll returns the
+// TagOutcome of every entity in the order of entIDs.
+func (es *EntityTagService) TagAll(campID CampaignID, tagID TagID, entIDs []EntityID) []*TagOutcome {
+	outs := make([]*TagOutcome, len(entIDs))
+	forEach(len(entIDs), func(i int) {
+		out := &TagOutcome{EntityID: entIDs[i]}
+		out.Err = es.client.limiter.retry(func() (err error) {
+			out.Changed, err = es.tag(int(campID), int(entIDs[i]), int(tagID))
+			return err
+		})
+		outs[i] = out
+	})
+
+	return outs
+}
+
+// tag adds the tag associated with tagID to the entity associated with
+// entID unless the entity already carries it. tag returns true if the tag
+// was added.
+func (es *EntityTagService) tag(campID int, entID int, tagID int) (bool, error) {
+	current, err := es.Index(campID, entID, nil)
+	if err != nil {
+		return false, fmt.Errorf("cannot tag Entity (ID: %d) with Tag (ID: %d): %w", entID, tagID, err)
+	}
+
+	for _, et := range current {
+		if int(et.TagID) == tagID {
+			return false, nil
+		}
+	}
+
+	if _, err = es.Create(campID, entID, SimpleEntityTag{EntityID: EntityID(entID), TagID: TagID(tagID)}); err != nil {
+		return false, fmt.Errorf("cannot tag Entity (ID: %d) with Tag (ID: %d): %w", entID, tagID, err)
+	}
+
+	return true, nil
+}
+
+// UntagAll removes the tag associated with tagID from every entity
+// associated with entIDs in the Campaign associated with campID, as TagAll
+// adds it. Entities not carrying the tag are left unchanged.
+func (es *EntityTagService) UntagAll(campID CampaignID, tagID TagID, entIDs []EntityID) []*TagOutcome {
+	outs := make([]*TagOutcome, len(entIDs))
+	forEach(len(entIDs), func(i int) {
+		out := &TagOutcome{EntityID: entIDs[i]}
+		out.Err = es.client.limiter.retry(func() (err error) {
+			out.Changed, err = es.untag(int(campID), int(entIDs[i]), int(tagID))
+			return err
+		})
+		outs[i] = out
+	})
+
+	return outs
+}
+
+// untag removes every EntityTag of the tag associated with tagID from the
+// entity associated with entID. untag returns true if any was removed.
+func (es *EntityTagService) untag(campID int, entID int, tagID int) (bool, error) {
+	current, err := es.Index(campID, entID, nil)
+	if err != nil {
+		return false, fmt.Errorf("cannot untag Entity (ID: %d) from Tag (ID: %d): %w", entID, tagID, err)
+	}
+
+	changed := false
+	for _, et := range current {
+		if int(et.TagID) != tagID {
+			continue
+		}
+
+		if err = es.Delete(campID, entID, et.ID); err != nil {
+			return changed, fmt.Errorf("cannot untag Entity (ID: %d) from Tag (ID: %d): %w", entID, tagID, err)
+		}
+		changed = true
+	}
+
+	return changed, nil
+}
+
+// ReplaceTag replaces the tag associated with oldID by the tag associated
+// with newID on every entity of the Campaign associated with campID
+// carrying the old tag. The new tag is added before the old tag is removed
+// so that a failure never leaves an entity without either. ReplaceTag
+// returns the TagOutcome of every affected entity ordered by entity ID, or
+// an error if the tagged entities cannot be listed.
+func (es *EntityTagService) ReplaceTag(campID CampaignID, oldID TagID, newID TagID) ([]*TagOutcome, error) {
+	if oldID == newID {
+		return nil, fmt.Errorf("cannot replace Tag (ID: %d) with itself", oldID)
+	}
+
+	ents, err := es.client.Tagged(campID, oldID, false)
+	if err != nil {
+		return nil, fmt.Errorf("cannot replace Tag (ID: %d) with Tag (ID: %d): %w", oldID, newID, err)
+	}
+
+	outs := make([]*TagOutcome, len(ents))
+	forEach(len(ents), func(i int) {
+		out := &TagOutcome{EntityID: ents[i].EntityID}
+		outs[i] = out
+
+		var added, removed bool
+		out.Err = es.client.limiter.retry(func() (err error) {
+			added, err = es.tag(int(campID), int(out.EntityID), int(newID))
+			return err
+		})
+		if out.Err != nil {
+			return
+		}
+
+		out.Err = es.client.limiter.retry(func() (err error) {
+			removed, err = es.untag(int(campID), int(out.EntityID), int(oldID))
+			return err
+		})
+		out.Changed = added || removed
+	})
+
+	return outs, nil
+}
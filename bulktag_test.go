@@ -0,0 +1,183 @@
+package kanka
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// seedTagged seeds a tag nested in the tag of seedCampaign and tags the
+// seeded characters with them.
+func seedTagged(t *testing.T, fk *fakeKanka) {
+	seedCampaign(t, fk, "")
+	fk.seed(t, "/campaigns/1/tags", &Tag{ID: 71, EntityID: 171, SimpleTag: SimpleTag{Name: "Wall", TagID: 70}})
+	fk.seed(t, "/campaigns/1/tags", &Tag{ID: 72, EntityID: 172, SimpleTag: SimpleTag{Name: "South"}})
+	fk.seed(t, "/campaigns/1/characters", &Character{ID: 30, EntityID: 130, SimpleCharacter: SimpleCharacter{Name: "Arya Stark", Tags: []TagID{70}}})
+	fk.seed(t, "/campaigns/1/characters", &Character{ID: 31, EntityID: 131, SimpleCharacter: SimpleCharacter{Name: "Jon Snow", Tags: []TagID{72, 71}}})
+	fk.seed(t, "/campaigns/1/entities/131/entity_tags", &EntityTag{ID: 87, SimpleEntityTag: SimpleEntityTag{EntityID: 131, TagID: 71}})
+}
+
+// entityTagIDs returns the sorted tag IDs of the entity tags of the entity
+// associated with entID.
+func entityTagIDs(fk *fakeKanka, entID int) []int {
+	var ids []int
+	for _, et := range fk.list("/campaigns/1/entities/" + strconv.Itoa(entID) + "/entity_tags") {
+		ids = append(ids, intField(et, "tag_id"))
+	}
+	sort.Ints(ids)
+
+	return ids
+}
+
+func TestClient_Tagged(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedTagged(t, fk)
+
+	tests := []struct {
+		name     string
+		tagID    TagID
+		children bool
+		want     []*TaggedEntity
+	}{
+		{"direct", 70, false, []*TaggedEntity{{EntityID: 130, Type: TypeCharacter, ID: 30, Name: "Arya Stark", TagID: 70}}},
+		{"children", 70, true, []*TaggedEntity{
+			{EntityID: 130, Type: TypeCharacter, ID: 30, Name: "Arya Stark", TagID: 70},
+			{EntityID: 131, Type: TypeCharacter, ID: 31, Name: "Jon Snow", TagID: 71},
+		}},
+		{"leaf", 71, true, []*TaggedEntity{{EntityID: 131, Type: TypeCharacter, ID: 31, Name: "Jon Snow", TagID: 71}}},
+		{"unused", 99, true, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := c.Tagged(1, test.tagID, test.children)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEntityTagService_TagAll(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedTagged(t, fk)
+	fk.fail["GET /campaigns/1/entities/140/entity_tags"] = http.StatusInternalServerError
+
+	outs := c.EntityTags.TagAll(1, 71, []EntityID{130, 131, 140})
+
+	want := []*TagOutcome{{EntityID: 130, Changed: true}, {EntityID: 131}, {EntityID: 140}}
+	if diff := cmp.Diff(want, outs, cmpopts.IgnoreFields(TagOutcome{}, "Err")); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if outs[0].Err != nil || outs[1].Err != nil || outs[2].Err == nil {
+		t.Errorf("got errors <%v>, <%v>, <%v>, want only the last", outs[0].Err, outs[1].Err, outs[2].Err)
+	}
+
+	if diff := cmp.Diff([]int{70, 71}, entityTagIDs(fk, 130)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]int{71}, entityTagIDs(fk, 131)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestEntityTagService_TagAll_RateLimited(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedTagged(t, fk)
+	call := "POST /campaigns/1/entities/130/entity_tags"
+	fk.fail[call] = http.StatusTooManyRequests
+
+	var waits []time.Duration
+	clearAt := 2
+	c.limiter.sleep = func(d time.Duration) {
+		fk.mu.Lock()
+		defer fk.mu.Unlock()
+
+		waits = append(waits, d)
+		if len(waits) == clearAt {
+			delete(fk.fail, call)
+		}
+	}
+
+	outs := c.EntityTags.TagAll(1, 71, []EntityID{130})
+	if outs[0].Err != nil || !outs[0].Changed {
+		t.Fatalf("got outcome <%+v>, want changed without error", outs[0])
+	}
+	if diff := cmp.Diff([]time.Duration{bulkBackoff, 2 * bulkBackoff}, waits); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]int{70, 71}, entityTagIDs(fk, 130)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	fk.fail[call] = http.StatusTooManyRequests
+	waits, clearAt = nil, -1
+
+	outs = c.EntityTags.TagAll(1, 72, []EntityID{130})
+	if outs[0].Err == nil {
+		t.Errorf("got nil error, want error after <%d> retries", bulkRetries)
+	}
+	if len(waits) != bulkRetries {
+		t.Errorf("got <%d> waits, want <%d>", len(waits), bulkRetries)
+	}
+}
+
+func TestEntityTagService_UntagAll(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedTagged(t, fk)
+
+	outs := c.EntityTags.UntagAll(1, 70, []EntityID{130, 131})
+
+	want := []*TagOutcome{{EntityID: 130, Changed: true}, {EntityID: 131}}
+	if diff := cmp.Diff(want, outs); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if got := entityTagIDs(fk, 130); len(got) != 0 {
+		t.Errorf("got tags <%v>, want none", got)
+	}
+}
+
+func TestEntityTagService_ReplaceTag(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedTagged(t, fk)
+
+	outs, err := c.EntityTags.ReplaceTag(1, 71, 72)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*TagOutcome{{EntityID: 131, Changed: true}}
+	if diff := cmp.Diff(want, outs); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]int{72}, entityTagIDs(fk, 131)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err = c.EntityTags.ReplaceTag(1, 72, 72); err == nil {
+		t.Error("got nil error for replacing tag with itself, want error")
+	}
+
+	fk.fail["GET /campaigns/1/tags"] = http.StatusInternalServerError
+	if _, err = c.EntityTags.ReplaceTag(1, 70, 72); err == nil {
+		t.Error("got nil error for failed listing, want error")
+	}
+}
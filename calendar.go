@@ -0,0 +1,582 @@
+package kanka
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// monthIntercalary is the type of the months that are outside of the week.
+const monthIntercalary = "intercalary"
+
+// Calendar contains information about a specific calendar.
+// For more information, visit: https://kanka.io/en-US/docs/1.0/calendars
+type Calendar struct {
+	SimpleCalendar
+	ID             CalendarID `json:"id"`
+	ImageFull      string     `json:"image_full"`
+	ImageThumb     string     `json:"image_thumb"`
+	HasCustomImage bool       `json:"has_custom_image"`
+	EntityID       EntityID   `json:"entity_id"`
+	CreatedAt      time.Time  `json:"created_at"`
+	CreatedBy      int        `json:"created_by"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	UpdatedBy      int        `json:"updated_by"`
+}
+
+// SimpleCalendar contains only the simple information about a calendar.
+// Date is the current date of the calendar in Kanka's "year-month-day" form.
+// A leap year adds LeapYearAmount days to the month numbered LeapYearMonth
+// every LeapYearOffset years, starting with the year LeapYearStart.
+// StartOffset is the weekday, counted from 0, of the first day of year 1.
+type SimpleCalendar struct {
+	Name           string          `json:"name"`
+	Entry          string          `json:"entry,omitempty"`
+	Type           string          `json:"type,omitempty"`
+	Date           string          `json:"date,omitempty"`
+	Months         []CalendarMonth `json:"months,omitempty"`
+	Weekdays       []string        `json:"weekdays,omitempty"`
+	Suffix         string          `json:"suffix,omitempty"`
+	HasLeapYear    bool            `json:"has_leap_year,omitempty"`
+	LeapYearAmount int             `json:"leap_year_amount,omitempty"`
+	LeapYearMonth  int             `json:"leap_year_month,omitempty"`
+	LeapYearOffset int             `json:"leap_year_offset,omitempty"`
+	LeapYearStart  int             `json:"leap_year_start,omitempty"`
+	HasYearZero    bool            `json:"has_year_zero,omitempty"`
+	StartOffset    int             `json:"start_offset,omitempty"`
+	Eras           []Era           `json:"eras,omitempty"`
+	Tags           []TagID         `json:"tags,omitempty"`
+	IsPrivate      bool            `json:"is_private,omitempty"`
+	Image          string          `json:"image,omitempty"`
+	ImageURL       string          `json:"image_url,omitempty"`
+}
+
+// EntryMarkdown returns the SimpleCalendar's Entry converted into Markdown.
+func (sc SimpleCalendar) EntryMarkdown() string {
+	return EntryToMarkdown(sc.Entry)
+}
+
+// SetEntryMarkdown sets the SimpleCalendar's Entry to the HTML of the provided
+// Markdown.
+func (sc *SimpleCalendar) SetEntryMarkdown(md string) {
+	sc.Entry = MarkdownToEntry(md)
+}
+
+// CalendarMonth is a month of a calendar. The days of intercalary months
+// are outside of the week and do not advance the weekdays.
+type CalendarMonth struct {
+	Name   string `json:"name"`
+	Length int    `json:"length"`
+	Type   string `json:"type,omitempty"`
+}
+
+// Era is a named period of a calendar beginning with the year Start. Years
+// are counted from 1 within each era and an era lasts until the next one.
+type Era struct {
+	Name         string `json:"name"`
+	Abbreviation string `json:"abbreviation,omitempty"`
+	Start        int    `json:"start"`
+}
+
+// CalendarDate is a day of a calendar. Months and days are counted from 1.
+type CalendarDate struct {
+	Year  int
+	Month int
+	Day   int
+}
+
+// String returns the CalendarDate in Kanka's "year-month-day" form.
+func (d CalendarDate) String() string {
+	return fmt.Sprintf("%d-%d-%d", d.Year, d.Month, d.Day)
+}
+
+// Compare returns -1 if the CalendarDate is before o, 1 if it is after o,
+// and 0 if they are the same day.
+func (d CalendarDate) Compare(o CalendarDate) int {
+	switch {
+	case d.Year != o.Year:
+		return sign(d.Year - o.Year)
+	case d.Month != o.Month:
+		return sign(d.Month - o.Month)
+	default:
+		return sign(d.Day - o.Day)
+	}
+}
+
+// sign returns -1, 0, or 1 depending on the sign of n.
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// yearIndex returns the position of the year counted from year 1, skipping
+// year zero if the calendar has none.
+func (c *SimpleCalendar) yearIndex(year int) int {
+	if c.HasYearZero || year > 0 {
+		return year
+	}
+	return year + 1
+}
+
+// indexYear returns the year at the position counted from year 1.
+func (c *SimpleCalendar) indexYear(idx int) int {
+	if c.HasYearZero || idx > 0 {
+		return idx
+	}
+	return idx - 1
+}
+
+// leapDays returns the number of days added by a leap year, or 0 if the
+// calendar has no valid leap rule.
+func (c *SimpleCalendar) leapDays() int {
+	if !c.HasLeapYear || c.LeapYearOffset <= 0 || c.LeapYearMonth < 1 || c.LeapYearMonth > len(c.Months) {
+		return 0
+	}
+	if c.LeapYearAmount <= 0 {
+		return 1
+	}
+	return c.LeapYearAmount
+}
+
+// IsLeapYear returns true if the year is a leap year.
+func (c *SimpleCalendar) IsLeapYear(year int) bool {
+	if c.leapDays() == 0 || year < c.LeapYearStart || (year == 0 && !c.HasYearZero) {
+		return false
+	}
+	return (year-c.LeapYearStart)%c.LeapYearOffset == 0
+}
+
+// leapsBefore returns the number of leap years before the year.
+func (c *SimpleCalendar) leapsBefore(year int) int {
+	if c.leapDays() == 0 || year <= c.LeapYearStart {
+		return 0
+	}
+
+	n := (year - c.LeapYearStart + c.LeapYearOffset - 1) / c.LeapYearOffset
+	if !c.HasYearZero && c.LeapYearStart <= 0 && year > 0 && -c.LeapYearStart%c.LeapYearOffset == 0 {
+		n--
+	}
+
+	return n
+}
+
+// MonthLength returns the number of days of the month in the year, or 0 if
+// the month does not exist.
+func (c *SimpleCalendar) MonthLength(year int, month int) int {
+	if month < 1 || month > len(c.Months) {
+		return 0
+	}
+
+	n := c.Months[month-1].Length
+	if month == c.LeapYearMonth && c.IsLeapYear(year) {
+		n += c.leapDays()
+	}
+
+	return n
+}
+
+// YearLength returns the number of days of the year.
+func (c *SimpleCalendar) YearLength(year int) int {
+	var n int
+	for m := range c.Months {
+		n += c.MonthLength(year, m+1)
+	}
+
+	return n
+}
+
+// Validate returns an error if the CalendarDate does not exist in the
+// calendar.
+func (c *SimpleCalendar) Validate(d CalendarDate) error {
+	if c.YearLength(1) <= 0 {
+		return fmt.Errorf("calendar '%s' has no days", c.Name)
+	}
+	if d.Year == 0 && !c.HasYearZero {
+		return fmt.Errorf("calendar '%s' has no year zero", c.Name)
+	}
+	if d.Month < 1 || d.Month > len(c.Months) {
+		return fmt.Errorf("calendar '%s' has no month %d", c.Name, d.Month)
+	}
+	if n := c.MonthLength(d.Year, d.Month); d.Day < 1 || d.Day > n {
+		return fmt.Errorf("month %d of year %d of calendar '%s' has no day %d", d.Month, d.Year, c.Name, d.Day)
+	}
+
+	return nil
+}
+
+// daysBefore returns the number of days from the first day of year 1 to
+// the first day of the year, negative for years before year 1.
+func (c *SimpleCalendar) daysBefore(year int) int {
+	var base int
+	for _, m := range c.Months {
+		base += m.Length
+	}
+
+	return (c.yearIndex(year)-1)*base + (c.leapsBefore(year)-c.leapsBefore(1))*c.leapDays()
+}
+
+// ordinal returns the number of days from the first day of year 1 to the
+// CalendarDate.
+func (c *SimpleCalendar) ordinal(d CalendarDate) int {
+	n := c.daysBefore(d.Year)
+	for m := 1; m < d.Month; m++ {
+		n += c.MonthLength(d.Year, m)
+	}
+
+	return n + d.Day - 1
+}
+
+// date returns the CalendarDate that is n days after the first day of
+// year 1.
+func (c *SimpleCalendar) date(n int) CalendarDate {
+	var avg float64
+	for _, m := range c.Months {
+		avg += float64(m.Length)
+	}
+	if leap := c.leapDays(); leap > 0 {
+		avg += float64(leap) / float64(c.LeapYearOffset)
+	}
+
+	idx := int(math.Floor(float64(n)/avg)) + 1
+	for c.daysBefore(c.indexYear(idx)) > n {
+		idx--
+	}
+	for c.daysBefore(c.indexYear(idx+1)) <= n {
+		idx++
+	}
+
+	d := CalendarDate{Year: c.indexYear(idx), Month: 1}
+	n -= c.daysBefore(d.Year)
+	for n >= c.MonthLength(d.Year, d.Month) {
+		n -= c.MonthLength(d.Year, d.Month)
+		d.Month++
+	}
+	d.Day = n + 1
+
+	return d
+}
+
+// AddDays returns the CalendarDate that is n days after d, or before d if n
+// is negative.
+func (c *SimpleCalendar) AddDays(d CalendarDate, n int) (CalendarDate, error) {
+	if err := c.Validate(d); err != nil {
+		return CalendarDate{}, fmt.Errorf("cannot add %d days to date '%s': %w", n, d, err)
+	}
+
+	return c.date(c.ordinal(d) + n), nil
+}
+
+// DaysBetween returns the number of days from one CalendarDate to the
+// other, negative if to is before from.
+func (c *SimpleCalendar) DaysBetween(from CalendarDate, to CalendarDate) (int, error) {
+	for _, d := range []CalendarDate{from, to} {
+		if err := c.Validate(d); err != nil {
+			return 0, fmt.Errorf("cannot count days from date '%s' to date '%s': %w", from, to, err)
+		}
+	}
+
+	return c.ordinal(to) - c.ordinal(from), nil
+}
+
+// Age returns the number of whole years from birth to the provided date,
+// negative if the date is before birth.
+func (c *SimpleCalendar) Age(birth CalendarDate, on CalendarDate) (int, error) {
+	for _, d := range []CalendarDate{birth, on} {
+		if err := c.Validate(d); err != nil {
+			return 0, fmt.Errorf("cannot compute age from date '%s' on date '%s': %w", birth, on, err)
+		}
+	}
+
+	if on.Compare(birth) < 0 {
+		age, err := c.Age(on, birth)
+		return -age, err
+	}
+
+	age := c.yearIndex(on.Year) - c.yearIndex(birth.Year)
+	if (CalendarDate{Month: on.Month, Day: on.Day}).Compare(CalendarDate{Month: birth.Month, Day: birth.Day}) < 0 {
+		age--
+	}
+
+	return age, nil
+}
+
+// Weekday returns the weekday of the CalendarDate counted from 0. Days of
+// intercalary months have no weekday.
+func (c *SimpleCalendar) Weekday(d CalendarDate) (int, error) {
+	if err := c.Validate(d); err != nil {
+		return 0, fmt.Errorf("cannot get weekday of date '%s': %w", d, err)
+	}
+	if len(c.Weekdays) == 0 {
+		return 0, fmt.Errorf("cannot get weekday of date '%s': calendar '%s' has no weekdays", d, c.Name)
+	}
+	if c.Months[d.Month-1].Type == monthIntercalary {
+		return 0, fmt.Errorf("cannot get weekday of date '%s': month '%s' is intercalary", d, c.Months[d.Month-1].Name)
+	}
+
+	var base int
+	for _, m := range c.Months {
+		if m.Type != monthIntercalary {
+			base += m.Length
+		}
+	}
+
+	n := (c.yearIndex(d.Year)-1)*base + d.Day - 1
+	if c.leapDays() > 0 && c.Months[c.LeapYearMonth-1].Type != monthIntercalary {
+		n += (c.leapsBefore(d.Year) - c.leapsBefore(1)) * c.leapDays()
+	}
+	for m := 1; m < d.Month; m++ {
+		if c.Months[m-1].Type != monthIntercalary {
+			n += c.MonthLength(d.Year, m)
+		}
+	}
+
+	w := (n + c.StartOffset) % len(c.Weekdays)
+	if w < 0 {
+		w += len(c.Weekdays)
+	}
+
+	return w, nil
+}
+
+// WeekdayName returns the name of the weekday of the CalendarDate.
+func (c *SimpleCalendar) WeekdayName(d CalendarDate) (string, error) {
+	w, err := c.Weekday(d)
+	if err != nil {
+		return "", err
+	}
+
+	return c.Weekdays[w], nil
+}
+
+// Era returns the era of the year and the year counted within that era. If
+// the year is before every era, Era returns nil and the year unchanged.
+func (c *SimpleCalendar) Era(year int) (*Era, int) {
+	var era *Era
+	for i := range c.Eras {
+		e := &c.Eras[i]
+		if e.Start <= year && (era == nil || e.Start > era.Start) {
+			era = e
+		}
+	}
+	if era == nil {
+		return nil, year
+	}
+
+	return era, c.yearIndex(year) - c.yearIndex(era.Start) + 1
+}
+
+// Current returns the current date of the calendar.
+func (c *SimpleCalendar) Current() (CalendarDate, error) {
+	d, err := c.ParseDate(c.Date)
+	if err != nil {
+		return CalendarDate{}, fmt.Errorf("cannot get current date of calendar '%s': %w", c.Name, err)
+	}
+
+	return d, nil
+}
+
+// Format returns the CalendarDate as its day, month name, and year, such as
+// "12 Thaw 5 CA". The year is formatted as by FormatYear.
+func (c *SimpleCalendar) Format(d CalendarDate) string {
+	month := strconv.Itoa(d.Month)
+	if d.Month >= 1 && d.Month <= len(c.Months) {
+		month = c.Months[d.Month-1].Name
+	}
+
+	return fmt.Sprintf("%d %s %s", d.Day, month, c.FormatYear(d.Year))
+}
+
+// FormatYear returns the year counted within its era and followed by the
+// era's abbreviation or name, such as "5 CA", or followed by the calendar's
+// suffix if the year is before every era.
+func (c *SimpleCalendar) FormatYear(year int) string {
+	if era, n := c.Era(year); era != nil {
+		label := era.Abbreviation
+		if label == "" {
+			label = era.Name
+		}
+		return strconv.Itoa(n) + " " + label
+	}
+
+	if c.Suffix != "" {
+		return strconv.Itoa(year) + " " + c.Suffix
+	}
+
+	return strconv.Itoa(year)
+}
+
+// numericDate matches dates in Kanka's "year-month-day" form.
+var numericDate = regexp.MustCompile(`^(-?\d+)-(\d+)-(\d+)$`)
+
+// ordinalSuffix matches the suffix of ordinal day numbers such as "12th".
+var ordinalSuffix = regexp.MustCompile(`^(\d+)(?:st|nd|rd|th)$`)
+
+// ParseDate parses a date either in Kanka's "year-month-day" form or as a
+// day, month name, and year in any order of day and month, such as
+// "12 Thaw 5 CA" or "Thaw 12th, 5 Crown Age". A year followed by the name or
+// abbreviation of an era is counted within that era. The parsed date must
+// exist in the calendar.
+func (c *SimpleCalendar) ParseDate(s string) (CalendarDate, error) {
+	s = strings.TrimSpace(s)
+
+	var d CalendarDate
+	if m := numericDate.FindStringSubmatch(s); m != nil {
+		d.Year, _ = strconv.Atoi(m[1])
+		d.Month, _ = strconv.Atoi(m[2])
+		d.Day, _ = strconv.Atoi(m[3])
+	} else {
+		var err error
+		if d, err = c.parseWords(s); err != nil {
+			return CalendarDate{}, fmt.Errorf("cannot parse date '%s': %w", s, err)
+		}
+	}
+
+	if err := c.Validate(d); err != nil {
+		return CalendarDate{}, fmt.Errorf("cannot parse date '%s': %w", s, err)
+	}
+
+	return d, nil
+}
+
+// parseWords parses a date written with the name of its month.
+func (c *SimpleCalendar) parseWords(s string) (CalendarDate, error) {
+	words := strings.Fields(strings.ToLower(strings.Replace(s, ",", " ", -1)))
+
+	month, words := c.matchMonth(words)
+	if month == 0 {
+		return CalendarDate{}, fmt.Errorf("no month of calendar '%s' found", c.Name)
+	}
+
+	var nums []int
+	var label []string
+	for _, w := range words {
+		if m := ordinalSuffix.FindStringSubmatch(w); m != nil {
+			w = m[1]
+		}
+		if n, err := strconv.Atoi(w); err == nil {
+			nums = append(nums, n)
+			continue
+		}
+		if w != "of" {
+			label = append(label, w)
+		}
+	}
+	if len(nums) != 2 {
+		return CalendarDate{}, fmt.Errorf("want day and year, found %d numbers", len(nums))
+	}
+
+	d := CalendarDate{Year: nums[1], Month: month, Day: nums[0]}
+	if len(label) == 0 {
+		return d, nil
+	}
+
+	name := strings.Join(label, " ")
+	if strings.EqualFold(name, c.Suffix) {
+		return d, nil
+	}
+	for _, e := range c.Eras {
+		if strings.EqualFold(name, e.Name) || strings.EqualFold(name, e.Abbreviation) {
+			if d.Year < 1 {
+				return CalendarDate{}, fmt.Errorf("invalid year %d of era '%s'", d.Year, e.Name)
+			}
+			d.Year = c.indexYear(c.yearIndex(e.Start) + d.Year - 1)
+			return d, nil
+		}
+	}
+
+	return CalendarDate{}, fmt.Errorf("unknown era '%s'", name)
+}
+
+// matchMonth finds the name of a month among the lowercase words, longest
+// names first, and returns the month and the remaining words. The month is
+// 0 if none is found.
+func (c *SimpleCalendar) matchMonth(words []string) (int, []string) {
+	months := make([]int, len(c.Months))
+	for i := range months {
+		months[i] = i
+	}
+	sort.SliceStable(months, func(i, j int) bool {
+		return len(c.Months[months[i]].Name) > len(c.Months[months[j]].Name)
+	})
+
+	for _, m := range months {
+		name := strings.Fields(strings.ToLower(c.Months[m].Name))
+		if len(name) == 0 {
+			continue
+		}
+		for i := 0; i+len(name) <= len(words); i++ {
+			if strings.Join(words[i:i+len(name)], " ") == strings.Join(name, " ") {
+				rest := append(append([]string{}, words[:i]...), words[i+len(name):]...)
+				return m + 1, rest
+			}
+		}
+	}
+
+	return 0, words
+}
+
+// CalendarService handles communication with the Calendar endpoint.
+type CalendarService service
+
+// Index returns the list of all Calendars in the Campaign associated with
+// campID.
+// If a non-nil time is provided, Index will only return Calendars that have
+// been changed since that time.
+func (cs *CalendarService) Index(campID CampaignID, sync *time.Time) ([]*Calendar, error) {
+	end, err := EndpointCampaign.id(int(campID))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(cs.end)
+
+	if sync != nil {
+		end = end.sync(*sync)
+	}
+
+	var wrap struct {
+		Data []*Calendar `json:"data"`
+	}
+
+	err = cs.client.get(end, &wrap)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get Calendar Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	return wrap.Data, nil
+}
+
+// Get returns the Calendar associated with calID from the Campaign
+// associated with campID.
+func (cs *CalendarService) Get(campID CampaignID, calID CalendarID) (*Calendar, error) {
+	end, err := EndpointCampaign.id(int(campID))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(cs.end)
+
+	end, err = end.id(int(calID))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Calendar ID: %w", err)
+	}
+
+	var wrap struct {
+		Data *Calendar `json:"data"`
+	}
+
+	err = cs.client.get(end, &wrap)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get Calendar (ID: %d) from Campaign (ID: %d): %w", calID, campID, err)
+	}
+
+	return wrap.Data, nil
+}
@@ -0,0 +1,302 @@
+package kanka
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// testCalendar returns a calendar with an intercalary month, a leap day
+// every fourth year from year 4, no year zero, and two eras.
+func testCalendar() *Calendar {
+	return &Calendar{
+		SimpleCalendar: SimpleCalendar{
+			Name: "Reckoning",
+			Date: "4-5-30",
+			Months: []CalendarMonth{
+				{Name: "Frost", Length: 30},
+				{Name: "Thaw", Length: 30},
+				{Name: "Midfest", Length: 1, Type: "intercalary"},
+				{Name: "Deep Bloom", Length: 30},
+				{Name: "Harvest", Length: 29},
+			},
+			Weekdays:       []string{"Moonday", "Tideday", "Fireday", "Starday"},
+			HasLeapYear:    true,
+			LeapYearAmount: 1,
+			LeapYearMonth:  5,
+			LeapYearOffset: 4,
+			LeapYearStart:  4,
+			Eras: []Era{
+				{Name: "First Age", Abbreviation: "FA", Start: -99},
+				{Name: "Crown Age", Abbreviation: "CA", Start: 1},
+			},
+		},
+	}
+}
+
+func TestCalendarDate_Compare(t *testing.T) {
+	tests := []struct {
+		a    CalendarDate
+		b    CalendarDate
+		want int
+	}{
+		{CalendarDate{1, 2, 3}, CalendarDate{1, 2, 3}, 0},
+		{CalendarDate{-1, 5, 29}, CalendarDate{1, 1, 1}, -1},
+		{CalendarDate{2, 1, 1}, CalendarDate{1, 5, 29}, 1},
+		{CalendarDate{2, 3, 1}, CalendarDate{2, 2, 30}, 1},
+		{CalendarDate{2, 2, 1}, CalendarDate{2, 2, 30}, -1},
+	}
+	for _, test := range tests {
+		if got := test.a.Compare(test.b); got != test.want {
+			t.Errorf("got <%d> comparing <%s> to <%s>, want <%d>", got, test.a, test.b, test.want)
+		}
+	}
+}
+
+func TestSimpleCalendar_YearLength(t *testing.T) {
+	c := testCalendar()
+
+	tests := []struct {
+		year int
+		want int
+	}{
+		{1, 120},
+		{4, 121},
+		{8, 121},
+		{6, 120},
+		{-4, 120},
+	}
+	for _, test := range tests {
+		if got := c.YearLength(test.year); got != test.want {
+			t.Errorf("got length <%d> for year <%d>, want <%d>", got, test.year, test.want)
+		}
+	}
+}
+
+func TestSimpleCalendar_AddDays(t *testing.T) {
+	c := testCalendar()
+
+	tests := []struct {
+		name    string
+		date    CalendarDate
+		days    int
+		want    CalendarDate
+		wantErr bool
+	}{
+		{"Same month", CalendarDate{1, 1, 1}, 10, CalendarDate{1, 1, 11}, false},
+		{"Intercalary month", CalendarDate{1, 2, 30}, 1, CalendarDate{1, 3, 1}, false},
+		{"Next year", CalendarDate{1, 5, 29}, 1, CalendarDate{2, 1, 1}, false},
+		{"Leap day", CalendarDate{4, 5, 29}, 1, CalendarDate{4, 5, 30}, false},
+		{"Skips year zero", CalendarDate{1, 1, 1}, -1, CalendarDate{-1, 5, 29}, false},
+		{"Many years", CalendarDate{1, 1, 1}, 120*100 + 25, CalendarDate{101, 1, 1}, false},
+		{"Many years back", CalendarDate{101, 1, 1}, -(120*200 + 25), CalendarDate{-100, 1, 1}, false},
+		{"Invalid date", CalendarDate{1, 3, 2}, 1, CalendarDate{}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := c.AddDays(test.date, test.days)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err <%v>, want err <%t>", err, test.wantErr)
+			}
+
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSimpleCalendar_DaysBetween(t *testing.T) {
+	c := testCalendar()
+
+	tests := []struct {
+		from CalendarDate
+		to   CalendarDate
+		want int
+	}{
+		{CalendarDate{1, 1, 1}, CalendarDate{2, 1, 1}, 120},
+		{CalendarDate{4, 1, 1}, CalendarDate{5, 1, 1}, 121},
+		{CalendarDate{-1, 1, 1}, CalendarDate{1, 1, 1}, 120},
+		{CalendarDate{5, 1, 1}, CalendarDate{1, 1, 1}, -481},
+	}
+	for _, test := range tests {
+		got, err := c.DaysBetween(test.from, test.to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.want {
+			t.Errorf("got <%d> days from <%s> to <%s>, want <%d>", got, test.from, test.to, test.want)
+		}
+	}
+
+	if _, err := c.DaysBetween(CalendarDate{0, 1, 1}, CalendarDate{1, 1, 1}); err == nil {
+		t.Error("got nil error for year zero, want error")
+	}
+}
+
+func TestSimpleCalendar_Age(t *testing.T) {
+	c := testCalendar()
+
+	tests := []struct {
+		birth CalendarDate
+		on    CalendarDate
+		want  int
+	}{
+		{CalendarDate{1, 4, 10}, CalendarDate{20, 4, 9}, 18},
+		{CalendarDate{1, 4, 10}, CalendarDate{20, 4, 10}, 19},
+		{CalendarDate{-5, 1, 1}, CalendarDate{5, 1, 1}, 9},
+		{CalendarDate{20, 4, 10}, CalendarDate{1, 4, 10}, -19},
+	}
+	for _, test := range tests {
+		got, err := c.Age(test.birth, test.on)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.want {
+			t.Errorf("got age <%d> from <%s> on <%s>, want <%d>", got, test.birth, test.on, test.want)
+		}
+	}
+}
+
+func TestSimpleCalendar_WeekdayName(t *testing.T) {
+	c := testCalendar()
+
+	tests := []struct {
+		date    CalendarDate
+		want    string
+		wantErr bool
+	}{
+		{CalendarDate{1, 1, 1}, "Moonday", false},
+		{CalendarDate{1, 1, 6}, "Tideday", false},
+		{CalendarDate{1, 4, 1}, "Moonday", false},
+		{CalendarDate{2, 1, 1}, "Starday", false},
+		{CalendarDate{-1, 5, 29}, "Starday", false},
+		{CalendarDate{5, 1, 1}, "Tideday", false},
+		{CalendarDate{1, 3, 1}, "", true},
+	}
+	for _, test := range tests {
+		got, err := c.WeekdayName(test.date)
+		if (err != nil) != test.wantErr {
+			t.Fatalf("got err <%v> for <%s>, want err <%t>", err, test.date, test.wantErr)
+		}
+		if got != test.want {
+			t.Errorf("got weekday <%s> for <%s>, want <%s>", got, test.date, test.want)
+		}
+	}
+}
+
+func TestSimpleCalendar_Format(t *testing.T) {
+	c := testCalendar()
+
+	tests := []struct {
+		date CalendarDate
+		want string
+	}{
+		{CalendarDate{5, 2, 12}, "12 Thaw 5 CA"},
+		{CalendarDate{-1, 1, 1}, "1 Frost 99 FA"},
+		{CalendarDate{-200, 4, 3}, "3 Deep Bloom -200"},
+	}
+	for _, test := range tests {
+		if got := c.Format(test.date); got != test.want {
+			t.Errorf("got <%s>, want <%s>", got, test.want)
+		}
+	}
+}
+
+func TestSimpleCalendar_ParseDate(t *testing.T) {
+	c := testCalendar()
+
+	tests := []struct {
+		name    string
+		s       string
+		want    CalendarDate
+		wantErr bool
+	}{
+		{"Kanka form", "5-2-12", CalendarDate{5, 2, 12}, false},
+		{"Negative year", "-12-1-3", CalendarDate{-12, 1, 3}, false},
+		{"Formatted", "12 Thaw 5 CA", CalendarDate{5, 2, 12}, false},
+		{"Month first", "Thaw 12th, 5 Crown Age", CalendarDate{5, 2, 12}, false},
+		{"Ordinal", "3rd of Deep Bloom, 7", CalendarDate{7, 4, 3}, false},
+		{"Earlier era", "1 frost 99 FA", CalendarDate{-1, 1, 1}, false},
+		{"Leap day", "30 Harvest 4", CalendarDate{4, 5, 30}, false},
+		{"Missing leap day", "30 Harvest 5", CalendarDate{}, true},
+		{"Year zero", "0-1-1", CalendarDate{}, true},
+		{"Unknown month", "12 Nowhere 5", CalendarDate{}, true},
+		{"Unknown era", "12 Thaw 5 XX", CalendarDate{}, true},
+		{"Missing year", "12 Thaw", CalendarDate{}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := c.ParseDate(test.s)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err <%v>, want err <%t>", err, test.wantErr)
+			}
+
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	for _, d := range []CalendarDate{{5, 2, 12}, {-1, 1, 1}, {-100, 4, 30}} {
+		got, err := c.ParseDate(c.Format(d))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != d {
+			t.Errorf("got <%s> parsing <%s>, want <%s>", got, c.Format(d), d)
+		}
+	}
+}
+
+func TestSimpleCalendar_Current(t *testing.T) {
+	c := testCalendar()
+
+	got, err := c.Current()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (CalendarDate{4, 5, 30}); got != want {
+		t.Errorf("got <%s>, want <%s>", got, want)
+	}
+
+	c.Date = ""
+	if _, err = c.Current(); err == nil {
+		t.Error("got nil error for missing date, want error")
+	}
+}
+
+func TestCalendarService(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	fk.seed(t, "/campaigns/1/calendars", testCalendar())
+
+	cals, err := c.Calendars.Index(1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cals) != 1 {
+		t.Fatalf("got <%d> Calendars, want <%d>", len(cals), 1)
+	}
+
+	cal, err := c.Calendars.Get(1, cals[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(testCalendar().Months, cal.Months); diff != "" {
+		t.Errorf("months mismatch (-want +got):\n%s", diff)
+	}
+	if got, _ := cal.WeekdayName(CalendarDate{2, 1, 1}); got != "Starday" {
+		t.Errorf("got weekday <%s>, want <%s>", got, "Starday")
+	}
+
+	fk.fail["GET /campaigns/1/calendars"] = http.StatusInternalServerError
+	if _, err = c.Calendars.Index(1, nil); err == nil {
+		t.Error("got nil error, want error")
+	}
+	if _, err = c.Calendars.Get(-1, 1); err == nil {
+		t.Error("got nil error for invalid Campaign ID, want error")
+	}
+}
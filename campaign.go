@@ -8,22 +8,22 @@ import (
 // Campaign provides simple data about a campaign.
 // For more information, visit: https://kanka.io/en-US/docs/1.0/campaigns
 type Campaign struct {
-	ID         int       `json:"id"`
-	Name       string    `json:"name"`
-	Entry      string    `json:"entry"`
-	Image      string    `json:"image"`
-	ImageFull  string    `json:"image_full"`
-	ImageThumb string    `json:"image_thumb"`
-	IsPrivate  bool      `json:"is_private"`
-	Visibility string    `json:"visibility"`
-	Locale     string    `json:"locale"`
-	EntityID   int       `json:"entity_id"`
-	Tags       []int     `json:"tags"`
-	CreatedAt  time.Time `json:"created_at"`
-	CreatedBy  int       `json:"created_by"`
-	UpdatedAt  time.Time `json:"updated_at"`
-	UpdatedBy  int       `json:"updated_by"`
-	Members    Members   `json:"members"`
+	ID         CampaignID `json:"id"`
+	Name       string     `json:"name"`
+	Entry      string     `json:"entry"`
+	Image      string     `json:"image"`
+	ImageFull  string     `json:"image_full"`
+	ImageThumb string     `json:"image_thumb"`
+	IsPrivate  bool       `json:"is_private"`
+	Visibility string     `json:"visibility"`
+	Locale     string     `json:"locale"`
+	EntityID   EntityID   `json:"entity_id"`
+	Tags       []TagID    `json:"tags"`
+	CreatedAt  time.Time  `json:"created_at"`
+	CreatedBy  int        `json:"created_by"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	UpdatedBy  int        `json:"updated_by"`
+	Members    Members    `json:"members"`
 }
 
 // Members wraps a list of campaign members.
@@ -87,6 +87,9 @@ func (cs *CampaignService) Index() ([]*Campaign, error) {
 }
 
 // Get returns the Campaign corresponding with the provided ID.
+//
+// Deprecated: Use CampaignHandle.Get, which is returned by Client.Campaign
+// with a typed CampaignID.
 func (cs *CampaignService) Get(campID int) (*Campaign, error) {
 	var wrap struct {
 		Data *Campaign `json:"data"`
@@ -109,6 +112,9 @@ const pathUsers string = "/users"
 
 // Members returns a list of all members of the Campaign corresponding with the
 // provided id.
+//
+// Deprecated: Use CampaignHandle.Members, which is returned by Client.Campaign
+// with a typed CampaignID.
 func (cs *CampaignService) Members(campID int) ([]*Member, error) {
 	var wrap Members
 
@@ -0,0 +1,701 @@
+package kanka
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Additional resources affected by cascading deletes and merges.
+const (
+	ResourceEntityTag         Resource = "entity tag"
+	ResourceEntityFile        Resource = "entity file"
+	ResourceMember            Resource = "organization member"
+	ResourceQuestCharacter    Resource = "quest character"
+	ResourceQuestLocation     Resource = "quest location"
+	ResourceQuestItem         Resource = "quest item"
+	ResourceQuestOrganization Resource = "quest organization"
+	ResourceMapPoint          Resource = "map point"
+	ResourceMention           Resource = "mention"
+)
+
+// DeletePolicy chooses what happens to the children of a deleted object of
+// the same type, such as the child locations of a location.
+type DeletePolicy int
+
+// Available delete policies.
+const (
+	// DeleteReparent moves the children of the deleted object to the
+	// deleted object's parent.
+	DeleteReparent DeletePolicy = iota
+	// DeleteCascade deletes every descendant of the deleted object along
+	// with it.
+	DeleteCascade
+)
+
+// DeleteAction is what a DeletePlan does to a single record.
+type DeleteAction string
+
+// Available delete actions.
+const (
+	// ActionDelete deletes the record.
+	ActionDelete DeleteAction = "delete"
+	// ActionRemove marks a record which Kanka removes along with its
+	// deleted owner, such as the attributes of a deleted entity.
+	ActionRemove DeleteAction = "remove"
+	// ActionRelink points a reference held by a surviving object at the
+	// nearest surviving ancestor of the deleted object, or clears it.
+	ActionRelink DeleteAction = "relink"
+	// ActionKeep marks a reference which is left in place, such as a
+	// mention or a map point targeting the deleted entity.
+	ActionKeep DeleteAction = "keep"
+)
+
+// DeleteOptions configures the behavior of PlanDelete.
+type DeleteOptions struct {
+	Policy DeletePolicy
+
+	// Snapshot stores everything the plan deletes or removes in the
+	// DeletePlan so that it can be restored with RestoreDeleted.
+	Snapshot bool
+}
+
+// DeleteStep is a single step of a DeletePlan. EntityID, Type, ID, and Name
+// identify the entity owning the affected record. RecordID and Label
+// identify the record itself, which is the entity's object for
+// ResourceEntity. Field and Target are the field of a relinked object and
+// its new value, which is 0 if the field is cleared. Err is set if the step
+// failed when the DeletePlan was applied.
+type DeleteStep struct {
+	Action   DeleteAction
+	EntityID EntityID
+	Type     EntityType
+	ID       ObjectID
+	Name     string
+	Resource Resource
+	RecordID int
+	Label    string
+	Field    string
+	Target   ObjectID
+	Err      error
+
+	end  endpoint
+	body map[string]interface{}
+}
+
+// String returns a description of the DeleteStep.
+func (ds *DeleteStep) String() string {
+	desc := fmt.Sprintf("%s %s '%s' (Entity ID: %d)", ds.Action, ds.Type, ds.Name, ds.EntityID)
+
+	switch {
+	case ds.Action == ActionRelink && ds.Target == 0:
+		return desc + fmt.Sprintf(": clear %s", ds.Field)
+	case ds.Action == ActionRelink:
+		return desc + fmt.Sprintf(": set %s to %d", ds.Field, ds.Target)
+	case ds.Resource == ResourceEntity:
+		return desc
+	case ds.RecordID == 0:
+		return desc + fmt.Sprintf(": %s '%s'", ds.Resource, ds.Label)
+	default:
+		return desc + fmt.Sprintf(": %s '%s' (ID: %d)", ds.Resource, ds.Label, ds.RecordID)
+	}
+}
+
+// DeletePlan lists every step needed to safely delete an object and handle
+// everything depending on it. A DeletePlan is a preview; nothing changes
+// until it is applied with ApplyDelete.
+type DeletePlan struct {
+	CampaignID CampaignID
+	Type       EntityType
+	ID         ObjectID
+	Name       string
+	Policy     DeletePolicy
+	Steps      []*DeleteStep
+
+	// Snapshot contains every deleted object along with its sub-resources,
+	// and every deleted record referring to it. Snapshot is nil unless it
+	// was requested.
+	Snapshot *Backup
+
+	// Survivors maps every object and entity outside the Snapshot to
+	// itself, so that restoring the Snapshot into the same Campaign keeps
+	// its references to them.
+	Survivors IDMap
+}
+
+// String returns a description of every step of the DeletePlan, one per
+// line.
+func (p *DeletePlan) String() string {
+	var sb strings.Builder
+	for _, ds := range p.Steps {
+		sb.WriteString(ds.String())
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// PlanDelete returns the DeletePlan deleting the object of the provided type
+// associated with id from the Campaign associated with campID. The plan
+// deletes the relations, inventories, quest elements, organization members,
+// and entity tags of other entities referring to the object, and relinks
+// the objects nested in it, located in it, or belonging to it according to
+// the policy. The sub-resources of the object are listed as removed along
+// with it, while map points targeting it and mentions of it are listed but
+// kept. If opts is nil, children are reparented and no snapshot is taken.
+func (c *Client) PlanDelete(campID CampaignID, typ EntityType, id ObjectID, opts *DeleteOptions) (*DeletePlan, error) {
+	if opts == nil {
+		opts = &DeleteOptions{}
+	}
+
+	b, err := c.Backup(campID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot plan deletion of %s (ID: %d) in Campaign (ID: %d): %w", typ, id, campID, err)
+	}
+
+	p, err := b.planDelete(int(campID), typ, int(id), opts)
+	if err != nil {
+		return nil, fmt.Errorf("cannot plan deletion of %s (ID: %d) in Campaign (ID: %d): %w", typ, id, campID, err)
+	}
+
+	return p, nil
+}
+
+// deletePlanner holds the state of a single call to planDelete.
+type deletePlanner struct {
+	b       *Backup
+	campID  int
+	ix      *ReferenceIndex
+	ents    map[int]*EntityData
+	deleted map[int]bool
+	trees   map[EntityType]*Tree
+}
+
+// planDelete returns the DeletePlan deleting the object of the provided type
+// associated with id from the Backup of the Campaign associated with campID.
+func (b *Backup) planDelete(campID int, typ EntityType, id int, opts *DeleteOptions) (*DeletePlan, error) {
+	dp := &deletePlanner{
+		b:       b,
+		campID:  campID,
+		ix:      b.ReferenceIndex(),
+		ents:    make(map[int]*EntityData),
+		deleted: make(map[int]bool),
+		trees:   make(map[EntityType]*Tree),
+	}
+	for _, ent := range b.Entities {
+		dp.ents[int(ent.EntityID)] = ent
+	}
+
+	root, ok := dp.ix.objects[typ][id]
+	if !ok {
+		return nil, fmt.Errorf("cannot find %s (ID: %d)", typ, id)
+	}
+
+	refs := []entityRef{root}
+	if typ.parentKey() != "" && opts.Policy == DeleteCascade {
+		n, _ := dp.tree(typ).Node(ObjectID(id))
+		desc := n.Descendants()
+		sort.SliceStable(desc, func(i, j int) bool { return desc[i].Depth() > desc[j].Depth() })
+		refs = nil
+		for _, d := range desc {
+			refs = append(refs, dp.ix.objects[typ][int(d.ID)])
+		}
+		refs = append(refs, root)
+	}
+	for _, ref := range refs {
+		dp.deleted[ref.EntityID] = true
+	}
+
+	p := &DeletePlan{CampaignID: CampaignID(campID), Type: typ, ID: ObjectID(id), Name: root.Name, Policy: opts.Policy}
+
+	var removed []*DeleteStep
+	for _, ref := range refs {
+		deps, err := dp.dependents(ref)
+		if err != nil {
+			return nil, err
+		}
+		p.Steps = append(p.Steps, deps...)
+
+		owned, err := dp.owned(ref)
+		if err != nil {
+			return nil, err
+		}
+		removed = append(removed, owned...)
+	}
+	p.Steps = append(p.Steps, removed...)
+
+	if opts.Snapshot {
+		p.Snapshot, p.Survivors = dp.snapshot()
+	}
+
+	return p, nil
+}
+
+// tree returns the Tree of the objects of the provided type.
+func (dp *deletePlanner) tree(typ EntityType) *Tree {
+	t, ok := dp.trees[typ]
+	if !ok {
+		t = dp.b.Tree(typ)
+		dp.trees[typ] = t
+	}
+
+	return t
+}
+
+// survivor returns the ID of the nearest ancestor of the object of the
+// provided type associated with id which is not deleted, or 0 if there is
+// none.
+func (dp *deletePlanner) survivor(typ EntityType, id int) int {
+	if typ.parentKey() == "" {
+		return 0
+	}
+
+	n, ok := dp.tree(typ).Node(ObjectID(id))
+	if !ok {
+		return 0
+	}
+	for p := n.Parent; p != nil; p = p.Parent {
+		if !dp.deleted[dp.ix.objects[typ][int(p.ID)].EntityID] {
+			return int(p.ID)
+		}
+	}
+
+	return 0
+}
+
+// step returns a DeleteStep of the record of the entity described by ref.
+func step(action DeleteAction, ref entityRef, res Resource, id int, label string) *DeleteStep {
+	return &DeleteStep{
+		Action:   action,
+		EntityID: EntityID(ref.EntityID),
+		Type:     ref.Type,
+		ID:       ObjectID(ref.ID),
+		Name:     ref.Name,
+		Resource: res,
+		RecordID: id,
+		Label:    label,
+	}
+}
+
+// dependents returns the steps handling the records of surviving entities
+// which refer to the deleted object described by ref.
+func (dp *deletePlanner) dependents(ref entityRef) ([]*DeleteStep, error) {
+	rels := make(map[int]*Relation)
+	invs := make(map[int]*EntityInventory)
+	for _, ent := range dp.b.Entities {
+		for _, r := range ent.Relations {
+			rels[r.ID] = r
+		}
+		for _, inv := range ent.Inventory {
+			invs[inv.ID] = inv
+		}
+	}
+
+	var steps []*DeleteStep
+	var err error
+	for _, r := range dp.ix.To(EntityID(ref.EntityID)) {
+		if dp.deleted[int(r.FromEntityID)] {
+			continue
+		}
+
+		from := dp.ix.objects[r.FromType][int(r.FromID)]
+		var ds *DeleteStep
+
+		switch r.Kind {
+		case RefRelation:
+			ds = step(ActionDelete, from, ResourceRelation, r.RecordID, rels[r.RecordID].Relation)
+			ds.end, err = nestedEndpoint(dp.campID, endpointEntity, from.EntityID, EndpointRelation, r.RecordID)
+		case RefInventory:
+			ds = step(ActionDelete, from, ResourceInventory, r.RecordID, fmt.Sprintf("item %d", invs[r.RecordID].ItemID))
+			ds.end, err = nestedEndpoint(dp.campID, endpointEntity, from.EntityID, EndpointEntityInventory, r.RecordID)
+		case RefQuestCharacter:
+			ds = step(ActionDelete, from, ResourceQuestCharacter, r.RecordID, ref.Name)
+			ds.end, err = nestedEndpoint(dp.campID, EndpointQuest, from.ID, EndpointQuestCharacters, r.RecordID)
+		case RefQuestLocation:
+			ds = step(ActionDelete, from, ResourceQuestLocation, r.RecordID, ref.Name)
+			ds.end, err = nestedEndpoint(dp.campID, EndpointQuest, from.ID, EndpointQuestLocation, r.RecordID)
+		case RefQuestItem:
+			ds = step(ActionDelete, from, ResourceQuestItem, r.RecordID, ref.Name)
+			ds.end, err = nestedEndpoint(dp.campID, EndpointQuest, from.ID, EndpointQuestItem, r.RecordID)
+		case RefQuestOrganization:
+			ds = step(ActionDelete, from, ResourceQuestOrganization, r.RecordID, ref.Name)
+			ds.end, err = nestedEndpoint(dp.campID, EndpointQuest, from.ID, EndpointQuestOrganization, r.RecordID)
+		case RefMember:
+			ds = step(ActionDelete, from, ResourceMember, r.RecordID, ref.Name)
+			ds.end, err = nestedEndpoint(dp.campID, EndpointOrganization, from.ID, EndpointOrganizationMember, r.RecordID)
+		case RefParent:
+			ds, err = dp.relink(from, from.Type.parentKey(), dp.survivor(ref.Type, ref.ID))
+		case RefLocation:
+			ds, err = dp.relink(from, "location_id", dp.survivor(ref.Type, ref.ID))
+		case RefFamily:
+			ds, err = dp.relink(from, "family_id", dp.survivor(ref.Type, ref.ID))
+		case RefMention:
+			label := "entry"
+			if r.RecordID != 0 {
+				label = "entity note"
+			}
+			ds = step(ActionKeep, from, ResourceMention, r.RecordID, label)
+		default:
+			// Tags are removed through the entity tags below and map points
+			// are listed with their names below.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		steps = append(steps, ds)
+	}
+
+	// Items owned by a deleted character lose their owner.
+	if ref.Type == TypeCharacter {
+		for _, v := range dp.b.Items {
+			if int(v.CharacterID) != ref.ID || dp.deleted[int(v.EntityID)] {
+				continue
+			}
+
+			ds, err := dp.relink(dp.ix.objects[TypeItem][int(v.ID)], "character_id", 0)
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, ds)
+		}
+	}
+
+	if ref.Type == TypeTag {
+		for _, ent := range dp.b.Entities {
+			if dp.deleted[int(ent.EntityID)] {
+				continue
+			}
+
+			from := dp.ix.objects[ent.Type][int(ent.ID)]
+			for _, et := range ent.EntityTags {
+				if int(et.TagID) != ref.ID {
+					continue
+				}
+
+				ds := step(ActionDelete, from, ResourceEntityTag, et.ID, ref.Name)
+				if ds.end, err = nestedEndpoint(dp.campID, endpointEntity, int(ent.EntityID), EndpointEntityTag, et.ID); err != nil {
+					return nil, err
+				}
+				steps = append(steps, ds)
+			}
+		}
+	}
+
+	for _, v := range dp.b.MapPoints {
+		loc := dp.ix.objects[TypeLocation][int(v.LocationID)]
+		if int(v.TargetEntityID) != ref.EntityID || dp.deleted[loc.EntityID] {
+			continue
+		}
+		steps = append(steps, step(ActionKeep, loc, ResourceMapPoint, 0, v.Name))
+	}
+
+	return steps, nil
+}
+
+// relink returns the step setting the field of the object described by ref
+// to target, or clearing it if target is 0.
+func (dp *deletePlanner) relink(ref entityRef, field string, target int) (*DeleteStep, error) {
+	ds := step(ActionRelink, ref, ResourceEntity, ref.ID, ref.Name)
+	ds.Field = field
+	ds.Target = ObjectID(target)
+
+	ds.body = map[string]interface{}{"name": ref.Name, field: nil}
+	if target != 0 {
+		ds.body[field] = target
+	}
+
+	var err error
+	if ds.end, err = nestedEndpoint(dp.campID, ref.Type.endpoint(), ref.ID, "", 0); err != nil {
+		return nil, err
+	}
+
+	return ds, nil
+}
+
+// owned returns the steps listing the sub-resources and child records of
+// the deleted object described by ref, followed by the step deleting the
+// object itself.
+func (dp *deletePlanner) owned(ref entityRef) ([]*DeleteStep, error) {
+	var steps []*DeleteStep
+	add := func(res Resource, id int, label string) {
+		steps = append(steps, step(ActionRemove, ref, res, id, label))
+	}
+
+	if ent, ok := dp.ents[ref.EntityID]; ok {
+		for _, a := range ent.Attributes {
+			add(ResourceAttribute, a.ID, a.Name)
+		}
+		for _, e := range ent.EntityEvents {
+			label := e.CalendarDate().String()
+			if e.Comment != "" {
+				label = e.Comment
+			}
+			add(ResourceEntityEvent, e.ID, label)
+		}
+		for _, n := range ent.EntityNotes {
+			add(ResourceEntityNote, n.ID, n.Name)
+		}
+		for _, et := range ent.EntityTags {
+			add(ResourceEntityTag, et.ID, dp.ix.objects[TypeTag][int(et.TagID)].Name)
+		}
+		for _, inv := range ent.Inventory {
+			add(ResourceInventory, inv.ID, fmt.Sprintf("item %d", inv.ItemID))
+		}
+		for _, r := range ent.Relations {
+			add(ResourceRelation, r.ID, r.Relation)
+		}
+	}
+
+	switch ref.Type {
+	case TypeOrganization:
+		for _, v := range dp.b.OrganizationMembers {
+			if int(v.OrganizationID) == ref.ID {
+				add(ResourceMember, v.ID, dp.ix.objects[TypeCharacter][int(v.CharacterID)].Name)
+			}
+		}
+	case TypeQuest:
+		for _, v := range dp.b.QuestCharacters {
+			if int(v.QuestID) == ref.ID {
+				add(ResourceQuestCharacter, v.ID, dp.ix.objects[TypeCharacter][int(v.CharacterID)].Name)
+			}
+		}
+		for _, v := range dp.b.QuestLocations {
+			if int(v.QuestID) == ref.ID {
+				add(ResourceQuestLocation, v.ID, dp.ix.objects[TypeLocation][int(v.LocationID)].Name)
+			}
+		}
+		for _, v := range dp.b.QuestItems {
+			if int(v.QuestID) == ref.ID {
+				add(ResourceQuestItem, v.ID, dp.ix.objects[TypeItem][int(v.ItemID)].Name)
+			}
+		}
+		for _, v := range dp.b.QuestOrganizations {
+			if int(v.QuestID) == ref.ID {
+				add(ResourceQuestOrganization, v.ID, dp.ix.objects[TypeOrganization][int(v.OrganizationID)].Name)
+			}
+		}
+	case TypeLocation:
+		for _, v := range dp.b.MapPoints {
+			if int(v.LocationID) == ref.ID {
+				add(ResourceMapPoint, 0, v.Name)
+			}
+		}
+	}
+
+	ds := step(ActionDelete, ref, ResourceEntity, ref.ID, ref.Name)
+	var err error
+	if ds.end, err = nestedEndpoint(dp.campID, ref.Type.endpoint(), ref.ID, "", 0); err != nil {
+		return nil, err
+	}
+
+	return append(steps, ds), nil
+}
+
+// snapshot returns a Backup of everything deleted or removed by the plan,
+// and the IDMap mapping every surviving object and entity to itself.
+func (dp *deletePlanner) snapshot() (*Backup, IDMap) {
+	b := dp.b
+	s := b.subset(dp.deleted, true)
+
+	gone := func(typ EntityType, id int) bool {
+		ref, ok := dp.ix.objects[typ][id]
+		return ok && dp.deleted[ref.EntityID]
+	}
+
+	for _, v := range b.OrganizationMembers {
+		if gone(TypeOrganization, int(v.OrganizationID)) || gone(TypeCharacter, int(v.CharacterID)) {
+			s.OrganizationMembers = append(s.OrganizationMembers, v)
+		}
+	}
+	for _, v := range b.QuestCharacters {
+		if gone(TypeQuest, int(v.QuestID)) || gone(TypeCharacter, int(v.CharacterID)) {
+			s.QuestCharacters = append(s.QuestCharacters, v)
+		}
+	}
+	for _, v := range b.QuestLocations {
+		if gone(TypeQuest, int(v.QuestID)) || gone(TypeLocation, int(v.LocationID)) {
+			s.QuestLocations = append(s.QuestLocations, v)
+		}
+	}
+	for _, v := range b.QuestItems {
+		if gone(TypeQuest, int(v.QuestID)) || gone(TypeItem, int(v.ItemID)) {
+			s.QuestItems = append(s.QuestItems, v)
+		}
+	}
+	for _, v := range b.QuestOrganizations {
+		if gone(TypeQuest, int(v.QuestID)) || gone(TypeOrganization, int(v.OrganizationID)) {
+			s.QuestOrganizations = append(s.QuestOrganizations, v)
+		}
+	}
+	for _, v := range b.MapPoints {
+		if gone(TypeLocation, int(v.LocationID)) {
+			s.MapPoints = append(s.MapPoints, v)
+		}
+	}
+
+	// Surviving entities only keep the records referring to deleted ones.
+	for _, ent := range b.Entities {
+		if dp.deleted[int(ent.EntityID)] {
+			s.Entities = append(s.Entities, ent)
+			continue
+		}
+
+		part := &EntityData{EntityID: ent.EntityID, Type: ent.Type, ID: ent.ID}
+		for _, r := range ent.Relations {
+			if dp.deleted[int(r.TargetID)] {
+				part.Relations = append(part.Relations, r)
+			}
+		}
+		for _, inv := range ent.Inventory {
+			if gone(TypeItem, int(inv.ItemID)) {
+				part.Inventory = append(part.Inventory, inv)
+			}
+		}
+		for _, et := range ent.EntityTags {
+			if gone(TypeTag, int(et.TagID)) {
+				part.EntityTags = append(part.EntityTags, et)
+			}
+		}
+		if len(part.Relations) > 0 || len(part.Inventory) > 0 || len(part.EntityTags) > 0 {
+			s.Entities = append(s.Entities, part)
+		}
+	}
+
+	ids := IDMap{}
+	for _, ref := range b.refs() {
+		if dp.deleted[ref.EntityID] {
+			continue
+		}
+		ids.set(string(ref.Type.endpoint()), ref.ID, ref.ID)
+		ids.set(string(endpointEntity), ref.EntityID, ref.EntityID)
+	}
+
+	return s, ids
+}
+
+// nestedEndpoint returns the endpoint of the record associated with id
+// nested in the owner associated with ownerID in the Campaign associated
+// with campID. If sub is empty, the endpoint of the owner itself is
+// returned, and if id is 0, the endpoint of the owner's collection of sub.
+func nestedEndpoint(campID int, owner endpoint, ownerID int, sub endpoint, id int) (endpoint, error) {
+	end, err := EndpointCampaign.id(campID)
+	if err != nil {
+		return "", fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+
+	if end, err = end.concat(owner).id(ownerID); err != nil {
+		return "", fmt.Errorf("invalid %s ID: %w", owner, err)
+	}
+	if sub == "" {
+		return end, nil
+	}
+	end = end.concat(sub)
+	if id == 0 {
+		return end, nil
+	}
+
+	if end, err = end.id(id); err != nil {
+		return "", fmt.Errorf("invalid %s ID: %w", sub, err)
+	}
+
+	return end, nil
+}
+
+// ApplyDelete applies every step of the DeletePlan. Dependent records are
+// deleted and relinked concurrently first. The objects are deleted
+// afterwards, children first, and only if every dependent was handled, so
+// that a failure never leaves references to a deleted object behind. A
+// failed step does not stop the other steps of its phase; its Err is set
+// and ApplyDelete returns an error counting the failures.
+func (c *Client) ApplyDelete(p *DeletePlan) error {
+	var deps, objects []*DeleteStep
+	for _, ds := range p.Steps {
+		ds.Err = nil
+		switch {
+		case ds.Action == ActionDelete && ds.Resource == ResourceEntity:
+			objects = append(objects, ds)
+		case ds.Action == ActionDelete || ds.Action == ActionRelink:
+			deps = append(deps, ds)
+		}
+	}
+
+	forEach(len(deps), func(i int) {
+		deps[i].Err = c.applyDeleteStep(int(p.CampaignID), deps[i])
+	})
+
+	if err := deleteFailures(p, len(deps)); err != nil {
+		return err
+	}
+
+	for _, ds := range objects {
+		if ds.Err = c.applyDeleteStep(int(p.CampaignID), ds); ds.Err != nil {
+			break
+		}
+	}
+
+	return deleteFailures(p, len(deps)+len(objects))
+}
+
+// deleteFailures returns an error counting the failed steps of the
+// DeletePlan out of the n steps attempted, or nil if none failed.
+func deleteFailures(p *DeletePlan, n int) error {
+	var failed []*DeleteStep
+	for _, ds := range p.Steps {
+		if ds.Err != nil {
+			failed = append(failed, ds)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("cannot apply %d of %d delete steps in Campaign (ID: %d): %w", len(failed), n, p.CampaignID, failed[0].Err)
+	}
+
+	return nil
+}
+
+// applyDeleteStep sends the request of the DeleteStep.
+func (c *Client) applyDeleteStep(campID int, ds *DeleteStep) error {
+	if ds.Action == ActionDelete {
+		if err := c.delete(ds.end); err != nil {
+			return fmt.Errorf("cannot delete %s (ID: %d) for Campaign (ID: %d): %w", ds.Resource, ds.RecordID, campID, err)
+		}
+		return nil
+	}
+
+	b, err := json.Marshal(ds.body)
+	if err != nil {
+		return fmt.Errorf("cannot marshal %s of %s (ID: %d): %w", ds.Field, ds.Type, ds.ID, err)
+	}
+
+	var wrap struct {
+		Data json.RawMessage `json:"data"`
+	}
+
+	if err = c.put(ds.end, bytes.NewReader(b), &wrap); err != nil {
+		return fmt.Errorf("cannot update %s of %s (ID: %d) for Campaign (ID: %d): %w", ds.Field, ds.Type, ds.ID, campID, err)
+	}
+
+	return nil
+}
+
+// RestoreDeleted restores the Snapshot of the applied DeletePlan into its
+// Campaign, keeping the references of the restored objects to surviving
+// ones. The restored objects receive new IDs, which are returned in the
+// RestoreReport. Objects relinked by the plan are not moved back.
+func (c *Client) RestoreDeleted(p *DeletePlan) (*RestoreReport, error) {
+	if p.Snapshot == nil {
+		return nil, fmt.Errorf("cannot restore deleted %s (ID: %d): plan has no snapshot", p.Type, p.ID)
+	}
+
+	ids := IDMap{}
+	for kind, m := range p.Survivors {
+		for id, newID := range m {
+			ids.set(kind, id, newID)
+		}
+	}
+
+	return c.Restore(p.CampaignID, p.Snapshot, &RestoreOptions{IDs: ids})
+}
@@ -0,0 +1,198 @@
+package kanka
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_PlanDelete(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+	fk.seed(t, "/campaigns/1/locations", &Location{ID: 11, EntityID: 111, SimpleLocation: SimpleLocation{Name: "Godswood", ParentLocationID: 10}})
+	fk.seed(t, "/campaigns/1/locations", &Location{ID: 12, EntityID: 112, SimpleLocation: SimpleLocation{Name: "Heart Tree", ParentLocationID: 11}})
+
+	tests := []struct {
+		name string
+		typ  EntityType
+		id   ObjectID
+		opts *DeleteOptions
+		want []string
+	}{
+		{
+			name: "character",
+			typ:  TypeCharacter,
+			id:   31,
+			want: []string{
+				"delete organisation 'Night's Watch' (Entity ID: 150): organization member 'Jon Snow' (ID: 51)",
+				"delete quest 'Beyond the Wall' (Entity ID: 160): quest character 'Jon Snow' (ID: 61)",
+				"delete character 'Arya Stark' (Entity ID: 130): relation 'Sister' (ID: 85)",
+				"remove character 'Jon Snow' (Entity ID: 131): relation 'Brother' (ID: 86)",
+				"delete character 'Jon Snow' (Entity ID: 131)",
+				"",
+			},
+		},
+		{
+			name: "reparent location",
+			typ:  TypeLocation,
+			id:   11,
+			want: []string{
+				"relink location 'Heart Tree' (Entity ID: 112): set parent_location_id to 10",
+				"delete location 'Godswood' (Entity ID: 111)",
+				"",
+			},
+		},
+		{
+			name: "cascade location",
+			typ:  TypeLocation,
+			id:   10,
+			opts: &DeleteOptions{Policy: DeleteCascade},
+			want: []string{
+				"relink family 'Stark' (Entity ID: 120): clear location_id",
+				"relink character 'Arya Stark' (Entity ID: 130): clear location_id",
+				"keep character 'Arya Stark' (Entity ID: 130): mention 'entry'",
+				"delete quest 'Beyond the Wall' (Entity ID: 160): quest location 'Winterfell' (ID: 62)",
+				"delete location 'Heart Tree' (Entity ID: 112)",
+				"delete location 'Godswood' (Entity ID: 111)",
+				"remove location 'Winterfell' (Entity ID: 110): map point ''",
+				"delete location 'Winterfell' (Entity ID: 110)",
+				"",
+			},
+		},
+		{
+			name: "tag",
+			typ:  TypeTag,
+			id:   70,
+			want: []string{
+				"delete character 'Arya Stark' (Entity ID: 130): entity tag 'North' (ID: 83)",
+				"delete tag 'North' (Entity ID: 170)",
+				"",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := c.PlanDelete(1, tt.typ, tt.id, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, strings.Split(p.String(), "\n")); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if fk.count("DELETE") != 0 || fk.count("PUT") != 0 {
+		t.Error("got changes after planning, want none")
+	}
+	if _, err := c.PlanDelete(1, TypeCharacter, 99, nil); err == nil {
+		t.Error("got nil error for missing character, want error")
+	}
+}
+
+func TestClient_ApplyDelete(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+
+	p, err := c.PlanDelete(1, TypeCharacter, 31, &DeleteOptions{Snapshot: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.ApplyDelete(p); err != nil {
+		t.Fatal(err)
+	}
+
+	gone := []struct {
+		coll string
+		id   int
+	}{
+		{"/campaigns/1/characters", 31},
+		{"/campaigns/1/entities/130/relations", 85},
+		{"/campaigns/1/organisations/50/organisation_members", 51},
+		{"/campaigns/1/quests/60/quest_characters", 61},
+	}
+	for _, g := range gone {
+		if fk.object(g.coll, g.id) != nil {
+			t.Errorf("got %s/%d after deletion, want deleted", g.coll, g.id)
+		}
+	}
+	if got := fk.count("DELETE /campaigns/1/entities/131"); got != 0 {
+		t.Errorf("got %d deletions of removed records, want 0", got)
+	}
+
+	rep, err := c.RestoreDeleted(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rep.Dropped) != 0 {
+		t.Errorf("got dropped references <%v>, want none", rep.Dropped)
+	}
+
+	charID, _ := rep.IDs.Get(string(EndpointCharacter), 31)
+	entID, _ := rep.IDs.Get(string(endpointEntity), 131)
+	if got := fk.object("/campaigns/1/characters", charID)["name"]; got != "Jon Snow" {
+		t.Errorf("got restored character <%v>, want <Jon Snow>", got)
+	}
+	if got := intField(fk.object("/campaigns/1/characters", charID), "family_id"); got != 20 {
+		t.Errorf("got restored family <%d>, want <20>", got)
+	}
+
+	rels := fk.list("/campaigns/1/entities/130/relations")
+	if len(rels) != 1 || intField(rels[0], "target_id") != entID {
+		t.Errorf("got relations of Arya <%v>, want one targeting Entity (ID: %d)", rels, entID)
+	}
+	mems := fk.list("/campaigns/1/organisations/50/organisation_members")
+	if len(mems) != 1 || intField(mems[0], "character_id") != charID {
+		t.Errorf("got members <%v>, want one of Character (ID: %d)", mems, charID)
+	}
+	if got := len(fk.list("/campaigns/1/quests/60/quest_characters")); got != 1 {
+		t.Errorf("got %d quest characters, want 1", got)
+	}
+}
+
+func TestClient_ApplyDelete_Failure(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+	fk.seed(t, "/campaigns/1/locations", &Location{ID: 11, EntityID: 111, SimpleLocation: SimpleLocation{Name: "Godswood", ParentLocationID: 10}})
+
+	p, err := c.PlanDelete(1, TypeLocation, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fk.fail["DELETE /campaigns/1/quests/60/quest_locations/62"] = http.StatusInternalServerError
+	if err = c.ApplyDelete(p); err == nil {
+		t.Fatal("got nil error, want error")
+	}
+
+	if fk.object("/campaigns/1/locations", 10) == nil {
+		t.Error("got deleted location after failed dependent, want location kept")
+	}
+	if got := fk.object("/campaigns/1/locations", 11)["parent_location_id"]; got != nil {
+		t.Errorf("got parent <%v> of child location, want cleared", got)
+	}
+
+	failed := 0
+	for _, ds := range p.Steps {
+		if ds.Err != nil {
+			failed++
+		}
+	}
+	if failed != 1 {
+		t.Errorf("got %d failed steps, want 1", failed)
+	}
+
+	if _, err = c.RestoreDeleted(p); err == nil {
+		t.Error("got nil error for plan without snapshot, want error")
+	}
+}
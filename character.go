@@ -13,16 +13,16 @@ import (
 // For more information, visit: https://kanka.io/en-US/docs/1.0/characters
 type Character struct {
 	SimpleCharacter
-	ID             int       `json:"id"`
-	ImageFull      string    `json:"image_full"`
-	ImageThumb     string    `json:"image_thumb"`
-	HasCustomImage bool      `json:"has_custom_image"`
-	EntityID       int       `json:"entity_id"`
-	CreatedAt      time.Time `json:"created_at"`
-	CreatedBy      int       `json:"created_by"`
-	UpdatedAt      time.Time `json:"updated_at"`
-	UpdatedBy      int       `json:"updated_by"`
-	Traits         Traits    `json:"traits"`
+	ID             CharacterID `json:"id"`
+	ImageFull      string      `json:"image_full"`
+	ImageThumb     string      `json:"image_thumb"`
+	HasCustomImage bool        `json:"has_custom_image"`
+	EntityID       EntityID    `json:"entity_id"`
+	CreatedAt      time.Time   `json:"created_at"`
+	CreatedBy      int         `json:"created_by"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+	UpdatedBy      int         `json:"updated_by"`
+	Traits         Traits      `json:"traits"`
 
 	Attributes   Attributes   `json:"attributes"`
 	EntityEvents EntityEvents `json:"entity_events"`
@@ -36,24 +36,24 @@ type Character struct {
 // SimpleCharacter is primarily used to create new characters for posting to
 // Kanka.
 type SimpleCharacter struct {
-	Name             string   `json:"name"`
-	Entry            string   `json:"entry,omitempty"`
-	Title            string   `json:"title,omitempty"`
-	Age              string   `json:"age,omitempty"`
-	Sex              string   `json:"sex,omitempty"`
-	Type             string   `json:"type,omitempty"`
-	FamilyID         int      `json:"family_id,omitempty"`
-	LocationID       int      `json:"location_id,omitempty"`
-	RaceID           int      `json:"race_id,omitempty"`
-	Tags             []int    `json:"tags,omitempty"`
-	IsDead           bool     `json:"is_dead,omitempty"`
-	IsPrivate        bool     `json:"is_private,omitempty"`
-	Image            string   `json:"image,omitempty"`
-	ImageURL         string   `json:"image_url,omitempty"`
-	PersonalityName  []string `json:"personality_name,omitempty"`
-	PersonalityEntry []string `json:"personality_entry,omitempty"`
-	AppearanceName   []string `json:"appearance_name,omitempty"`
-	AppearanceEntry  []string `json:"appearance_entry,omitempty"`
+	Name             string     `json:"name"`
+	Entry            string     `json:"entry,omitempty"`
+	Title            string     `json:"title,omitempty"`
+	Age              string     `json:"age,omitempty"`
+	Sex              string     `json:"sex,omitempty"`
+	Type             string     `json:"type,omitempty"`
+	FamilyID         FamilyID   `json:"family_id,omitempty"`
+	LocationID       LocationID `json:"location_id,omitempty"`
+	RaceID           RaceID     `json:"race_id,omitempty"`
+	Tags             []TagID    `json:"tags,omitempty"`
+	IsDead           bool       `json:"is_dead,omitempty"`
+	IsPrivate        bool       `json:"is_private,omitempty"`
+	Image            string     `json:"image,omitempty"`
+	ImageURL         string     `json:"image_url,omitempty"`
+	PersonalityName  []string   `json:"personality_name,omitempty"`
+	PersonalityEntry []string   `json:"personality_entry,omitempty"`
+	AppearanceName   []string   `json:"appearance_name,omitempty"`
+	AppearanceEntry  []string   `json:"appearance_entry,omitempty"`
 }
 
 // MarshalJSON marshals the SimpleCharacter into its JSON-encoded form if it
@@ -67,6 +67,17 @@ func (sc SimpleCharacter) MarshalJSON() ([]byte, error) {
 	return json.Marshal(alias(sc))
 }
 
+// EntryMarkdown returns the SimpleCharacter's Entry converted into Markdown.
+func (sc SimpleCharacter) EntryMarkdown() string {
+	return EntryToMarkdown(sc.Entry)
+}
+
+// SetEntryMarkdown sets the SimpleCharacter's Entry to the HTML of the provided
+// Markdown.
+func (sc *SimpleCharacter) SetEntryMarkdown(md string) {
+	sc.Entry = MarkdownToEntry(md)
+}
+
 // Traits wraps a list of character traits.
 // Traits exists to satisfy the API's JSON structure.
 type Traits struct {
@@ -89,6 +100,8 @@ type CharacterService service
 // Index returns the list of all Characters in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return Characters that have
 // been changed since that time.
+//
+// Deprecated: Use CampaignHandle.Characters, which takes typed IDs.
 func (cs *CharacterService) Index(campID int, sync *time.Time) ([]*Character, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -114,6 +127,8 @@ func (cs *CharacterService) Index(campID int, sync *time.Time) ([]*Character, er
 
 // Get returns the Character associated with charID from the Campaign
 // associated with campID.
+//
+// Deprecated: Use CampaignHandle.Characters, which takes typed IDs.
 func (cs *CharacterService) Get(campID int, charID int) (*Character, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -141,6 +156,8 @@ func (cs *CharacterService) Get(campID int, charID int) (*Character, error) {
 // Create creates a new Character in the Campaign associated with campID using
 // the provided SimpleCharacter data.
 // Create returns the newly created Character.
+//
+// Deprecated: Use CampaignHandle.Characters, which takes typed IDs.
 func (cs *CharacterService) Create(campID int, ch SimpleCharacter) (*Character, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -168,6 +185,8 @@ func (cs *CharacterService) Create(campID int, ch SimpleCharacter) (*Character,
 // Update updates an existing Character associated with charID from the
 // Campaign associated with campID using the provided SimpleCharacter data.
 // Update returns the newly updated Character.
+//
+// Deprecated: Use CampaignHandle.Characters, which takes typed IDs.
 func (cs *CharacterService) Update(campID int, charID int, ch SimpleCharacter) (*Character, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -199,6 +218,8 @@ func (cs *CharacterService) Update(campID int, charID int, ch SimpleCharacter) (
 
 // Delete deletes an existing Character associated with charID from the
 // Campaign associated with campID.
+//
+// Deprecated: Use CampaignHandle.Characters, which takes typed IDs.
 func (cs *CharacterService) Delete(campID int, charID int) error {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
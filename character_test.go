@@ -152,7 +152,7 @@ func TestCharacterService_Get(t *testing.T) {
 			Entry:      "\n<p>She is the key to finding Mechanus</p>\n",
 			Image:      "characters/pdt4F7zJjCyxDUu2flaZXBPqwHtkhCg8fmowXV05.jpeg",
 			IsPrivate:  false,
-			Tags:       []int{34696},
+			Tags:       []TagID{34696},
 			LocationID: 26145,
 			Title:      "Tinkerer",
 			Age:        "24",
@@ -0,0 +1,299 @@
+package kanka
+
+import (
+	"fmt"
+)
+
+// CloneOptions configures the behavior of Clone.
+type CloneOptions struct {
+	// Children also clones every descendant of the object with the same
+	// type, such as the child locations of a location.
+	Children bool
+
+	// Attributes clones the attributes of every cloned entity.
+	Attributes bool
+
+	// EntityNotes clones the entity notes of every cloned entity.
+	EntityNotes bool
+
+	// Relations clones the relations of every cloned entity. Relations
+	// targeting an entity missing from the destination Campaign are dropped.
+	Relations bool
+
+	// Inventory clones the inventory of every cloned entity. Inventory
+	// holding an item missing from the destination Campaign is dropped.
+	Inventory bool
+
+	// Traits clones the personality and appearance traits of characters.
+	Traits bool
+
+	// IDs maps the IDs of objects in the source Campaign to the IDs of
+	// existing objects in the destination Campaign. IDs is keyed like the
+	// IDMap returned by Restore. References found in IDs take precedence over
+	// references matched by name. Passing the IDs of a CloneReport returned
+	// along with an error resumes the interrupted clone.
+	IDs IDMap
+}
+
+// CloneReport describes the result of Clone.
+type CloneReport struct {
+	// IDs maps the IDs of every cloned or matched object in the source
+	// Campaign to its ID in the destination Campaign.
+	IDs IDMap
+
+	// Skipped lists the references which could not be found in the
+	// destination Campaign and were dropped from the clones.
+	Skipped []string
+}
+
+// Clone copies the object of the provided type associated with id from the
+// Campaign associated with srcID to the Campaign associated with dstID.
+// References to other objects are remapped to the objects of the same type
+// and name in the destination Campaign, or dropped and reported if none
+// exist. If opts is nil, only the object itself is cloned.
+//
+// If cloning fails after objects were created in the destination Campaign,
+// Clone returns the CloneReport of the objects created so far along with the
+// error, so that they can be deleted or the clone resumed through
+// CloneOptions.IDs.
+func (c *Client) Clone(srcID CampaignID, typ EntityType, id ObjectID, dstID CampaignID, opts *CloneOptions) (*CloneReport, error) {
+	if opts == nil {
+		opts = &CloneOptions{}
+	}
+
+	src := &Backup{}
+	if err := c.backupObjects(int(srcID), src); err != nil {
+		return nil, fmt.Errorf("cannot clone from Campaign (ID: %d): %w", srcID, err)
+	}
+
+	dst := &Backup{}
+	if err := c.backupObjects(int(dstID), dst); err != nil {
+		return nil, fmt.Errorf("cannot clone to Campaign (ID: %d): %w", dstID, err)
+	}
+
+	refs := src.refs()
+	selected := selectClones(refs, typ, int(id), opts.Children)
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("cannot find %s (ID: %d) in Campaign (ID: %d)", typ, id, srcID)
+	}
+
+	r := &restorer{
+		client: c,
+		campID: int(dstID),
+		ids:    matchClones(refs, dst.refs(), selected, opts.IDs),
+	}
+
+	part := src.subset(selected, opts.Traits)
+	for _, ref := range refs {
+		if !selected[ref.EntityID] {
+			continue
+		}
+
+		ent, err := c.cloneEntity(int(srcID), ref, opts)
+		if err != nil {
+			return nil, fmt.Errorf("cannot clone %s (ID: %d) from Campaign (ID: %d): %w", ref.Type, ref.ID, srcID, err)
+		}
+		part.Entities = append(part.Entities, ent)
+	}
+
+	err := r.run(part)
+	rep := &CloneReport{IDs: r.ids, Skipped: r.dropped}
+	if err != nil {
+		return rep, fmt.Errorf("cannot clone %s (ID: %d) to Campaign (ID: %d): %w", typ, id, dstID, err)
+	}
+
+	return rep, nil
+}
+
+// selectClones returns the entity IDs of the object of the provided type
+// associated with id and, if children is true, of its descendants.
+func selectClones(refs []entityRef, typ EntityType, id int, children bool) map[int]bool {
+	selected := make(map[int]bool)
+	objects := make(map[int]bool)
+
+	for _, ref := range refs {
+		if ref.Type == typ && ref.ID == id {
+			selected[ref.EntityID] = true
+			objects[ref.ID] = true
+		}
+	}
+
+	if !children {
+		return selected
+	}
+
+	for grown := true; grown; {
+		grown = false
+		for _, ref := range refs {
+			if ref.Type != typ || objects[ref.ID] || !objects[ref.Parent] {
+				continue
+			}
+			selected[ref.EntityID] = true
+			objects[ref.ID] = true
+			grown = true
+		}
+	}
+
+	return selected
+}
+
+// matchClones returns an IDMap which maps every unselected source object to
+// a destination object. Objects are matched using the provided IDs first and
+// then by type and name. Selected objects and sub-resources found in the
+// provided IDs were cloned by an earlier call and are mapped as well.
+func matchClones(src []entityRef, dst []entityRef, selected map[int]bool, ids IDMap) IDMap {
+	named := make(map[EntityType]map[string]entityRef)
+	byID := make(map[EntityType]map[int]entityRef)
+	for _, ref := range dst {
+		if named[ref.Type] == nil {
+			named[ref.Type] = make(map[string]entityRef)
+			byID[ref.Type] = make(map[int]entityRef)
+		}
+		if _, ok := named[ref.Type][ref.Name]; !ok {
+			named[ref.Type][ref.Name] = ref
+		}
+		byID[ref.Type][ref.ID] = ref
+	}
+
+	m := IDMap{}
+	objects := map[string]bool{string(endpointEntity): true}
+	for _, ref := range src {
+		objects[string(ref.Type.endpoint())] = true
+	}
+	for kind, byOld := range ids {
+		if objects[kind] {
+			continue
+		}
+		for id, newID := range byOld {
+			m.set(kind, id, newID)
+		}
+	}
+
+	for _, ref := range src {
+		kind := string(ref.Type.endpoint())
+
+		var match entityRef
+		var ok bool
+		id, found := ids.Get(kind, ref.ID)
+		switch {
+		case found:
+			match, ok = byID[ref.Type][id]
+		case !selected[ref.EntityID]:
+			match, ok = named[ref.Type][ref.Name]
+		}
+		if !ok {
+			continue
+		}
+
+		m.set(kind, ref.ID, match.ID)
+		m.set(string(endpointEntity), ref.EntityID, match.EntityID)
+	}
+
+	return m
+}
+
+// cloneEntity returns the sub-resources of the entity described by ref which
+// were requested by the provided CloneOptions.
+func (c *Client) cloneEntity(campID int, ref entityRef, opts *CloneOptions) (*EntityData, error) {
+	var err error
+	ent := &EntityData{
+		EntityID: EntityID(ref.EntityID),
+		Type:     ref.Type,
+		ID:       ObjectID(ref.ID),
+	}
+
+	if opts.Attributes {
+		if ent.Attributes, err = c.Attributes.Index(campID, ref.EntityID, nil); err != nil {
+			return nil, err
+		}
+	}
+	if opts.EntityNotes {
+		if ent.EntityNotes, err = c.EntityNotes.Index(campID, ref.EntityID, nil); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Inventory {
+		if ent.Inventory, err = c.EntityInventories.Index(campID, ref.EntityID, nil); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Relations {
+		if ent.Relations, err = c.Relations.Index(campID, ref.EntityID, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return ent, nil
+}
+
+// subset returns a Backup containing only the core objects whose entity IDs
+// are selected. Character traits are omitted unless traits is true.
+func (b *Backup) subset(selected map[int]bool, traits bool) *Backup {
+	s := &Backup{
+		Version:   b.Version,
+		CreatedAt: b.CreatedAt,
+		Campaign:  b.Campaign,
+	}
+
+	for _, v := range b.Characters {
+		if selected[int(v.EntityID)] {
+			ch := *v
+			if !traits {
+				ch.Traits = Traits{}
+			}
+			s.Characters = append(s.Characters, &ch)
+		}
+	}
+	for _, v := range b.Locations {
+		if selected[int(v.EntityID)] {
+			s.Locations = append(s.Locations, v)
+		}
+	}
+	for _, v := range b.Families {
+		if selected[int(v.EntityID)] {
+			s.Families = append(s.Families, v)
+		}
+	}
+	for _, v := range b.Organizations {
+		if selected[int(v.EntityID)] {
+			s.Organizations = append(s.Organizations, v)
+		}
+	}
+	for _, v := range b.Items {
+		if selected[int(v.EntityID)] {
+			s.Items = append(s.Items, v)
+		}
+	}
+	for _, v := range b.Notes {
+		if selected[int(v.EntityID)] {
+			s.Notes = append(s.Notes, v)
+		}
+	}
+	for _, v := range b.Events {
+		if selected[int(v.EntityID)] {
+			s.Events = append(s.Events, v)
+		}
+	}
+	for _, v := range b.Races {
+		if selected[int(v.EntityID)] {
+			s.Races = append(s.Races, v)
+		}
+	}
+	for _, v := range b.Quests {
+		if selected[int(v.EntityID)] {
+			s.Quests = append(s.Quests, v)
+		}
+	}
+	for _, v := range b.Journals {
+		if selected[int(v.EntityID)] {
+			s.Journals = append(s.Journals, v)
+		}
+	}
+	for _, v := range b.Tags {
+		if selected[int(v.EntityID)] {
+			s.Tags = append(s.Tags, v)
+		}
+	}
+
+	return s
+}
@@ -0,0 +1,146 @@
+package kanka
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_Clone(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+	fk.seed(t, "/campaigns/2/locations", &Location{ID: 500, EntityID: 600, SimpleLocation: SimpleLocation{Name: "Winterfell"}})
+	fk.seed(t, "/campaigns/2/tags", &Tag{ID: 501, EntityID: 601, SimpleTag: SimpleTag{Name: "North"}})
+	fk.seed(t, "/campaigns/1/characters", &Character{
+		ID:       32,
+		EntityID: 132,
+		SimpleCharacter: SimpleCharacter{
+			Name:       "Bran Stark",
+			LocationID: 10,
+			FamilyID:   20,
+			Tags:       []TagID{70},
+		},
+		Traits: Traits{Data: []*Trait{
+			{Name: "Eyes", Entry: "Grey", Section: "appearance"},
+		}},
+	})
+	fk.seed(t, "/campaigns/1/entities/132/attributes", &Attribute{ID: 90, SimpleAttribute: SimpleAttribute{Name: "Wisdom", Value: "18"}})
+	fk.seed(t, "/campaigns/1/entities/132/relations", &Relation{ID: 91, SimpleRelation: SimpleRelation{Relation: "Brother", TargetID: 130}})
+	fk.seed(t, "/campaigns/1/entities/132/inventory", &EntityInventory{ID: 92, SimpleEntityInventory: SimpleEntityInventory{ItemID: 40, Amount: 1}})
+
+	rep, err := c.Clone(1, TypeCharacter, 32, 2, &CloneOptions{
+		Attributes: true,
+		Relations:  true,
+		Inventory:  true,
+		Traits:     true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chars := fk.list("/campaigns/2/characters")
+	if len(chars) != 1 {
+		t.Fatalf("got <%d> Characters, want <%d>", len(chars), 1)
+	}
+	bran := chars[0]
+
+	if got := intField(bran, "location_id"); got != 500 {
+		t.Errorf("got location <%d>, want <%d>", got, 500)
+	}
+	if got := intField(bran, "family_id"); got != 0 {
+		t.Errorf("got family <%d>, want <%d>", got, 0)
+	}
+	if diff := cmp.Diff([]interface{}{float64(501)}, bran["tags"]); diff != "" {
+		t.Errorf("tags mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]interface{}{"Eyes"}, bran["appearance_name"]); diff != "" {
+		t.Errorf("traits mismatch (-want +got):\n%s", diff)
+	}
+
+	entID := intField(bran, "entity_id")
+	if got := len(fk.list(fmt.Sprintf("/campaigns/2/entities/%d/attributes", entID))); got != 1 {
+		t.Errorf("got <%d> Attributes, want <%d>", got, 1)
+	}
+	if got := len(fk.list(fmt.Sprintf("/campaigns/2/entities/%d/relations", entID))); got != 0 {
+		t.Errorf("got <%d> Relations, want <%d>", got, 0)
+	}
+
+	want := []string{"families (ID: 20)", "items (ID: 40)", "entities (ID: 130)"}
+	if diff := cmp.Diff(want, rep.Skipped); diff != "" {
+		t.Errorf("skipped mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestClient_Clone_Children(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+	fk.seed(t, "/campaigns/1/locations", &Location{ID: 11, EntityID: 111, SimpleLocation: SimpleLocation{Name: "Great Hall", ParentLocationID: 12}})
+	fk.seed(t, "/campaigns/1/locations", &Location{ID: 12, EntityID: 112, SimpleLocation: SimpleLocation{Name: "Keep", ParentLocationID: 10}})
+	fk.seed(t, "/campaigns/1/locations", &Location{ID: 13, EntityID: 113, SimpleLocation: SimpleLocation{Name: "King's Landing"}})
+
+	rep, err := c.Clone(1, TypeLocation, 10, 2, &CloneOptions{Children: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := len(fk.list("/campaigns/2/locations")); got != 3 {
+		t.Fatalf("got <%d> Locations, want <%d>", got, 3)
+	}
+
+	keep, _ := rep.IDs.Get("locations", 12)
+	hall, _ := rep.IDs.Get("locations", 11)
+	winterfell, _ := rep.IDs.Get("locations", 10)
+	if got := intField(fk.object("/campaigns/2/locations", keep), "parent_location_id"); got != winterfell {
+		t.Errorf("got parent <%d>, want <%d>", got, winterfell)
+	}
+	if got := intField(fk.object("/campaigns/2/locations", hall), "parent_location_id"); got != keep {
+		t.Errorf("got parent <%d>, want <%d>", got, keep)
+	}
+}
+
+func TestClient_Clone_Missing(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+
+	if _, err := c.Clone(1, TypeCharacter, 999, 2, nil); err == nil {
+		t.Fatal("got nil error, want error")
+	}
+}
+
+func TestClient_Clone_Resume(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+
+	// The clone is assigned the next ID and its entity the one after.
+	attrs := fmt.Sprintf("/campaigns/2/entities/%d/attributes", fk.nextID+2)
+	fk.fail["POST "+attrs] = http.StatusTooManyRequests
+	rep, err := c.Clone(1, TypeCharacter, 30, 2, &CloneOptions{Attributes: true})
+	if err == nil {
+		t.Fatal("got nil error, want error")
+	}
+	arya, ok := rep.IDs.Get("characters", 30)
+	if !ok || fk.object("/campaigns/2/characters", arya) == nil {
+		t.Fatalf("got report <%v>, want the created Character", rep.IDs)
+	}
+	delete(fk.fail, "POST "+attrs)
+
+	if _, err = c.Clone(1, TypeCharacter, 30, 2, &CloneOptions{Attributes: true, IDs: rep.IDs}); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(fk.list("/campaigns/2/characters")); got != 1 {
+		t.Errorf("got <%d> Characters, want <%d>", got, 1)
+	}
+	if got := len(fk.list(attrs)); got != 1 {
+		t.Errorf("got <%d> Attributes, want <%d>", got, 1)
+	}
+}
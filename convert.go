@@ -0,0 +1,161 @@
+package kanka
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// ConvertOptions configures Convert.
+type ConvertOptions struct {
+	// DropFiles converts an original with entity files anyway. Files cannot
+	// be moved to the new entity, so they are lost when the original is
+	// deleted. By default, Convert refuses to convert such an original.
+	DropFiles bool
+}
+
+// ConvertReport describes the result of Convert.
+type ConvertReport struct {
+	// Type, ID, and EntityID identify the object created by Convert.
+	Type     EntityType
+	ID       ObjectID
+	EntityID EntityID
+
+	// Plan is the MergePlan which moved the original into the new object.
+	// Its SurvivorID is the ID of the new object.
+	Plan *MergePlan
+}
+
+// Convert converts the object of the provided type associated with id in the
+// Campaign associated with campID into a new object of type to, such as a
+// note into a character. The new object is created with the name, entry,
+// tags, privacy, and image of the original. The original's attributes,
+// entity notes, entity events, inventory, and relations are then moved to
+// the new entity, mentions of the original are rewritten, and the original is
+// deleted, as ApplyMerge does. Records which can only refer to an object of
+// the original's type, such as quest elements, are skipped, and objects
+// nested in or located in the original lose that reference.
+//
+// Convert returns an error if the original has entity files, unless
+// opts.DropFiles is set. If opts is nil, the defaults are used.
+//
+// If the move cannot be planned, the new object is deleted again; should that
+// fail too, Convert returns the ConvertReport of the new object. If moving
+// fails, both the original and the new object are kept, as records may
+// already refer to the new object, and Convert returns the ConvertReport
+// along with the error, so that the failed steps can be inspected.
+func (c *Client) Convert(campID CampaignID, typ EntityType, id ObjectID, to EntityType, opts *ConvertOptions) (*ConvertReport, error) {
+	if opts == nil {
+		opts = &ConvertOptions{}
+	}
+
+	if to.endpoint() == "" {
+		return nil, fmt.Errorf("cannot convert %s (ID: %d) to unknown type '%s'", typ, id, to)
+	}
+	if to == typ {
+		return nil, fmt.Errorf("cannot convert %s (ID: %d) to its own type", typ, id)
+	}
+
+	b, err := c.Backup(campID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert %s (ID: %d) in Campaign (ID: %d): %w", typ, id, campID, err)
+	}
+
+	mp := newMergePlanner(b, int(campID))
+	orig, ok := mp.ix.objects[typ][int(id)]
+	if !ok {
+		return nil, fmt.Errorf("cannot convert %s (ID: %d) in Campaign (ID: %d): cannot find %s (ID: %d)", typ, id, campID, typ, id)
+	}
+
+	f := b.fields()[orig.EntityID]
+	if n := len(f.files.Data); n > 0 && !opts.DropFiles {
+		return nil, fmt.Errorf("cannot convert %s (ID: %d) in Campaign (ID: %d): cannot move %d entity files", typ, id, campID, n)
+	}
+
+	conv, err := c.createConversion(int(campID), orig, to, mp.tags(orig), *f.entry)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert %s (ID: %d) in Campaign (ID: %d): %w", typ, id, campID, err)
+	}
+
+	rep := &ConvertReport{Type: to, ID: ObjectID(conv.ID), EntityID: EntityID(conv.EntityID)}
+	if rep.Plan, err = mp.plan(conv, orig); err != nil {
+		if derr := c.deleteConversion(int(campID), conv); derr != nil {
+			return rep, fmt.Errorf("cannot convert %s (ID: %d) in Campaign (ID: %d): %w (%v)", typ, id, campID, err, derr)
+		}
+		return nil, fmt.Errorf("cannot convert %s (ID: %d) in Campaign (ID: %d): %w", typ, id, campID, err)
+	}
+
+	if err = c.ApplyMerge(rep.Plan); err != nil {
+		return rep, fmt.Errorf("cannot convert %s (ID: %d) in Campaign (ID: %d): %w", typ, id, campID, err)
+	}
+
+	return rep, nil
+}
+
+// createConversion creates the object of type to converted from the object
+// described by orig, carrying the provided tags and entry, and returns a
+// reference to it.
+func (c *Client) createConversion(campID int, orig entityRef, to EntityType, tags map[int]int, entry string) (entityRef, error) {
+	conv := entityRef{Type: to, Name: orig.Name, IsPrivate: orig.IsPrivate}
+	for id := range tags {
+		conv.Tags = append(conv.Tags, id)
+	}
+	sort.Ints(conv.Tags)
+
+	body := map[string]interface{}{
+		"name":       orig.Name,
+		"entry":      entry,
+		"is_private": orig.IsPrivate,
+	}
+	if len(conv.Tags) > 0 {
+		body["tags"] = conv.Tags
+	}
+	if orig.image != "" {
+		body["image_url"] = orig.image
+	}
+
+	end, err := EndpointCampaign.id(campID)
+	if err != nil {
+		return conv, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(to.endpoint())
+
+	b, err := json.Marshal(body)
+	if err != nil {
+		return conv, fmt.Errorf("cannot marshal %s: %w", to, err)
+	}
+
+	var wrap struct {
+		Data struct {
+			ID       int `json:"id"`
+			EntityID int `json:"entity_id"`
+		} `json:"data"`
+	}
+
+	if err = c.post(end, bytes.NewReader(b), &wrap); err != nil {
+		return conv, fmt.Errorf("cannot create %s for Campaign (ID: %d): %w", to, campID, err)
+	}
+	conv.ID, conv.EntityID = wrap.Data.ID, wrap.Data.EntityID
+
+	return conv, nil
+}
+
+// deleteConversion deletes the object described by conv.
+func (c *Client) deleteConversion(campID int, conv entityRef) error {
+	end, err := EndpointCampaign.id(campID)
+	if err != nil {
+		return fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+
+	end, err = end.concat(conv.Type.endpoint()).id(conv.ID)
+	if err != nil {
+		return fmt.Errorf("invalid %s ID: %w", conv.Type, err)
+	}
+
+	if err = c.delete(end); err != nil {
+		return fmt.Errorf("cannot delete %s (ID: %d) from Campaign (ID: %d): %w", conv.Type, conv.ID, campID, err)
+	}
+
+	return nil
+}
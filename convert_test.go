@@ -0,0 +1,157 @@
+package kanka
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// seedNote seeds the note Bran with sub-resources and references from
+// other entities.
+func seedNote(t *testing.T, fk *fakeKanka) {
+	fk.seed(t, "/campaigns/1/notes", &Note{ID: 45, EntityID: 145, SimpleNote: SimpleNote{Name: "Bran", IsPrivate: true, Entry: "<p>Climbs walls</p>", Tags: []TagID{70}}})
+	fk.seed(t, "/campaigns/1/journals", &Journal{ID: 46, EntityID: 146, SimpleJournal: SimpleJournal{Name: "Diary", Entry: "<p>Saw [note:45] fall</p>"}})
+
+	fk.seed(t, "/campaigns/1/entities/145/attributes", &Attribute{ID: 90, EntityID: 145, SimpleAttribute: SimpleAttribute{Name: "Age", Value: "8"}})
+	fk.seed(t, "/campaigns/1/entities/145/entity_notes", &EntityNote{ID: 91, SimpleEntityNote: SimpleEntityNote{EntityID: 145, Name: "Dreams", Entry: "<p>[note:45] dreams</p>"}})
+	fk.seed(t, "/campaigns/1/entities/145/relations", &Relation{ID: 92, SimpleRelation: SimpleRelation{Relation: "Brother", OwnerID: 145, TargetID: 130, Attitude: 70}})
+	fk.seed(t, "/campaigns/1/entities/130/relations", &Relation{ID: 93, SimpleRelation: SimpleRelation{Relation: "Sister", OwnerID: 130, TargetID: 145, Attitude: 70}})
+}
+
+func TestClient_Convert(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+	seedNote(t, fk)
+
+	rep, err := c.Convert(1, TypeNote, 45, TypeCharacter, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fk.object("/campaigns/1/notes", 45) != nil {
+		t.Error("got original note after conversion, want deleted")
+	}
+
+	char := fk.object("/campaigns/1/characters", int(rep.ID))
+	if char["name"] != "Bran" || char["entry"] != "<p>Climbs walls</p>" || char["is_private"] != true {
+		t.Errorf("got character <%v>, want the shared fields of the note", char)
+	}
+	if diff := cmp.Diff([]interface{}{float64(70)}, char["tags"]); diff != "" {
+		t.Errorf("tags mismatch (-want +got):\n%s", diff)
+	}
+	if got := intField(char, "entity_id"); got != int(rep.EntityID) {
+		t.Errorf("got entity ID <%d>, want <%d>", got, rep.EntityID)
+	}
+
+	ent := "/campaigns/1/entities/" + strconv.Itoa(int(rep.EntityID))
+	if attrs := fk.list(ent + "/attributes"); len(attrs) != 1 || attrs[0]["name"] != "Age" {
+		t.Errorf("got attributes <%v>, want Age", attrs)
+	}
+	notes := fk.list(ent + "/entity_notes")
+	if len(notes) != 1 || notes[0]["entry"] != "<p>[character:"+strconv.Itoa(int(rep.ID))+"] dreams</p>" {
+		t.Errorf("got entity notes <%v>, want Dreams mentioning the character", notes)
+	}
+	if rels := fk.list(ent + "/relations"); len(rels) != 1 || intField(rels[0], "target_id") != 130 {
+		t.Errorf("got relations <%v>, want Brother of Arya", rels)
+	}
+
+	if got := intField(fk.object("/campaigns/1/entities/130/relations", 93), "target_id"); got != int(rep.EntityID) {
+		t.Errorf("got relation target <%d>, want <%d>", got, rep.EntityID)
+	}
+	if got := fk.object("/campaigns/1/journals", 46)["entry"]; got != "<p>Saw [character:"+strconv.Itoa(int(rep.ID))+"] fall</p>" {
+		t.Errorf("got journal entry <%v>, want mention of the character", got)
+	}
+}
+
+func TestClient_Convert_Errors(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+	seedNote(t, fk)
+
+	tests := []struct {
+		name string
+		typ  EntityType
+		id   ObjectID
+		to   EntityType
+	}{
+		{"same type", TypeNote, 45, TypeNote},
+		{"unknown type", TypeNote, 45, EntityType("dragon")},
+		{"missing object", TypeNote, 99, TypeCharacter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := c.Convert(1, tt.typ, tt.id, tt.to, nil); err == nil {
+				t.Error("got nil error, want error")
+			}
+		})
+	}
+
+	fk.fail["PUT /campaigns/1/journals/46"] = http.StatusInternalServerError
+
+	rep, err := c.Convert(1, TypeNote, 45, TypeCharacter, nil)
+	if err == nil {
+		t.Fatal("got nil error for failed move, want error")
+	}
+	if rep == nil || rep.ID == 0 {
+		t.Fatalf("got report <%v>, want report of the created character", rep)
+	}
+	if fk.object("/campaigns/1/notes", 45) == nil {
+		t.Error("got deleted note after failed move, want note kept")
+	}
+}
+
+func TestClient_Convert_Files(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+	seedNote(t, fk)
+	fk.seed(t, "/campaigns/1/notes", &Note{ID: 47, EntityID: 147, SimpleNote: SimpleNote{Name: "Map"}, EntityFiles: EntityFiles{Data: []*EntityFile{{ID: 98, Name: "map.png"}}}})
+	chars := len(fk.list("/campaigns/1/characters"))
+
+	if _, err := c.Convert(1, TypeNote, 47, TypeCharacter, nil); err == nil {
+		t.Fatal("got nil error for original with files, want error")
+	}
+	if got := len(fk.list("/campaigns/1/characters")); got != chars {
+		t.Errorf("got <%d> characters after refused conversion, want <%d>", got, chars)
+	}
+
+	rep, err := c.Convert(1, TypeNote, 47, TypeCharacter, &ConvertOptions{DropFiles: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fk.object("/campaigns/1/characters", int(rep.ID)) == nil || fk.object("/campaigns/1/notes", 47) != nil {
+		t.Error("got original kept with DropFiles, want converted")
+	}
+}
+
+func TestClient_Convert_PlanFailure(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+	seedNote(t, fk)
+	fk.seed(t, "/campaigns/1/entities/131/relations", &Relation{ID: -1, SimpleRelation: SimpleRelation{Relation: "Friend", OwnerID: 131, TargetID: 145}})
+	chars := len(fk.list("/campaigns/1/characters"))
+
+	rep, err := c.Convert(1, TypeNote, 45, TypeCharacter, nil)
+	if err == nil {
+		t.Fatal("got nil error for invalid relation, want error")
+	}
+	if rep != nil {
+		t.Errorf("got report <%v>, want nil after cleanup", rep)
+	}
+	if got := len(fk.list("/campaigns/1/characters")); got != chars || fk.count("DELETE") != 1 {
+		t.Errorf("got <%d> characters after failed plan, want <%d> with the created one deleted", got, chars)
+	}
+	if fk.object("/campaigns/1/notes", 45) == nil {
+		t.Error("got deleted note after failed plan, want note kept")
+	}
+}
@@ -0,0 +1,232 @@
+package kanka
+
+import "fmt"
+
+// EntityType identifies the kind of object an entity represents.
+type EntityType string
+
+// Available Kanka entity types.
+const (
+	TypeCharacter    EntityType = "character"
+	TypeLocation     EntityType = "location"
+	TypeFamily       EntityType = "family"
+	TypeOrganization EntityType = "organisation"
+	TypeItem         EntityType = "item"
+	TypeNote         EntityType = "note"
+	TypeEvent        EntityType = "event"
+	TypeRace         EntityType = "race"
+	TypeQuest        EntityType = "quest"
+	TypeJournal      EntityType = "journal"
+	TypeTag          EntityType = "tag"
+)
+
+// endpoint returns the endpoint of the objects of the EntityType.
+func (t EntityType) endpoint() endpoint {
+	switch t {
+	case TypeCharacter:
+		return EndpointCharacter
+	case TypeLocation:
+		return EndpointLocation
+	case TypeFamily:
+		return EndpointFamily
+	case TypeOrganization:
+		return EndpointOrganization
+	case TypeItem:
+		return EndpointItem
+	case TypeNote:
+		return EndpointNote
+	case TypeEvent:
+		return EndpointEvent
+	case TypeRace:
+		return EndpointRace
+	case TypeQuest:
+		return EndpointQuest
+	case TypeJournal:
+		return EndpointJournal
+	case TypeTag:
+		return EndpointTag
+	default:
+		return ""
+	}
+}
+
+// entityRef identifies a single core object and its entity.
+// Parent is the ID of the object's parent of the same type, if any.
+type entityRef struct {
+	Type      EntityType
+	ID        int
+	EntityID  int
+	Name      string
+	Parent    int
+	Tags      []int
+	IsPrivate bool
+	image     string
+}
+
+// customImage returns the URL of an object's full image if the object has a
+// custom image.
+func customImage(custom bool, full string) string {
+	if !custom {
+		return ""
+	}
+	return full
+}
+
+// getRef returns a reference to the object of the provided type associated
+// with id in the Campaign associated with campID.
+func (c *Client) getRef(campID int, typ EntityType, id int) (entityRef, error) {
+	var err error
+	b := &Backup{}
+
+	switch typ {
+	case TypeCharacter:
+		var v *Character
+		if v, err = c.Characters.Get(campID, id); v != nil {
+			b.Characters = append(b.Characters, v)
+		}
+	case TypeLocation:
+		var v *Location
+		if v, err = c.Locations.Get(campID, id); v != nil {
+			b.Locations = append(b.Locations, v)
+		}
+	case TypeFamily:
+		var v *Family
+		if v, err = c.Families.Get(campID, id); v != nil {
+			b.Families = append(b.Families, v)
+		}
+	case TypeOrganization:
+		var v *Organization
+		if v, err = c.Organizations.Get(campID, id); v != nil {
+			b.Organizations = append(b.Organizations, v)
+		}
+	case TypeItem:
+		var v *Item
+		if v, err = c.Items.Get(campID, id); v != nil {
+			b.Items = append(b.Items, v)
+		}
+	case TypeNote:
+		var v *Note
+		if v, err = c.Notes.Get(campID, id); v != nil {
+			b.Notes = append(b.Notes, v)
+		}
+	case TypeEvent:
+		var v *Event
+		if v, err = c.Events.Get(campID, id); v != nil {
+			b.Events = append(b.Events, v)
+		}
+	case TypeRace:
+		var v *Race
+		if v, err = c.Races.Get(campID, id); v != nil {
+			b.Races = append(b.Races, v)
+		}
+	case TypeQuest:
+		var v *Quest
+		if v, err = c.Quests.Get(campID, id); v != nil {
+			b.Quests = append(b.Quests, v)
+		}
+	case TypeJournal:
+		var v *Journal
+		if v, err = c.Journals.Get(campID, id); v != nil {
+			b.Journals = append(b.Journals, v)
+		}
+	case TypeTag:
+		var v *Tag
+		if v, err = c.Tags.Get(campID, id); v != nil {
+			b.Tags = append(b.Tags, v)
+		}
+	default:
+		return entityRef{}, fmt.Errorf("unknown entity type '%s'", typ)
+	}
+
+	if err != nil {
+		return entityRef{}, err
+	}
+
+	refs := b.refs()
+	if len(refs) == 0 {
+		return entityRef{}, fmt.Errorf("cannot find %s (ID: %d) in Campaign (ID: %d)", typ, id, campID)
+	}
+
+	return refs[0], nil
+}
+
+// getEntityRef returns a reference to the object owning the entity associated
+// with entID in the Campaign associated with campID.
+func (c *Client) getEntityRef(campID int, entID int) (entityRef, error) {
+	var err error
+	end := EndpointCampaign
+
+	if end, err = end.id(campID); err != nil {
+		return entityRef{}, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(endpointEntity)
+
+	if end, err = end.id(entID); err != nil {
+		return entityRef{}, fmt.Errorf("invalid Entity ID: %w", err)
+	}
+
+	var wrap struct {
+		Data *struct {
+			ID        int        `json:"id"`
+			Name      string     `json:"name"`
+			Type      EntityType `json:"type"`
+			ChildID   int        `json:"child_id"`
+			IsPrivate bool       `json:"is_private"`
+		} `json:"data"`
+	}
+
+	if err = c.get(end, &wrap); err != nil {
+		return entityRef{}, fmt.Errorf("cannot get Entity (ID: %d) from Campaign (ID: %d): %w", entID, campID, err)
+	}
+
+	if wrap.Data == nil {
+		return entityRef{}, fmt.Errorf("cannot find Entity (ID: %d) in Campaign (ID: %d)", entID, campID)
+	}
+
+	return entityRef{
+		Type:      wrap.Data.Type,
+		ID:        wrap.Data.ChildID,
+		EntityID:  wrap.Data.ID,
+		Name:      wrap.Data.Name,
+		IsPrivate: wrap.Data.IsPrivate,
+	}, nil
+}
+
+// indexRefs returns references to every object of the provided type in the
+// Campaign associated with campID.
+func (c *Client) indexRefs(campID int, typ EntityType) ([]entityRef, error) {
+	var err error
+	b := &Backup{}
+
+	switch typ {
+	case TypeCharacter:
+		b.Characters, err = c.Characters.Index(campID, nil)
+	case TypeLocation:
+		b.Locations, err = c.Locations.Index(campID, nil)
+	case TypeFamily:
+		b.Families, err = c.Families.Index(campID, nil)
+	case TypeOrganization:
+		b.Organizations, err = c.Organizations.Index(campID, nil)
+	case TypeItem:
+		b.Items, err = c.Items.Index(campID, nil)
+	case TypeNote:
+		b.Notes, err = c.Notes.Index(campID, nil)
+	case TypeEvent:
+		b.Events, err = c.Events.Index(campID, nil)
+	case TypeRace:
+		b.Races, err = c.Races.Index(campID, nil)
+	case TypeQuest:
+		b.Quests, err = c.Quests.Index(campID, nil)
+	case TypeJournal:
+		b.Journals, err = c.Journals.Index(campID, nil)
+	case TypeTag:
+		b.Tags, err = c.Tags.Index(campID, nil)
+	default:
+		return nil, fmt.Errorf("unknown entity type '%s'", typ)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return b.refs(), nil
+}
@@ -13,28 +13,33 @@ import (
 // entity.
 type EntityEvent struct {
 	SimpleEntityEvent
-	ID         int       `json:"id"`
-	CalendarID int       `json:"calendar_id"`
-	CreatedAt  time.Time `json:"created_at"`
-	CreatedBy  int       `json:"created_by"`
-	Date       string    `json:"date"`
-	UpdatedAt  time.Time `json:"updated_at"`
-	UpdatedBy  int       `json:"updated_by"`
+	ID         int        `json:"id"`
+	CalendarID CalendarID `json:"calendar_id"`
+	CreatedAt  time.Time  `json:"created_at"`
+	CreatedBy  int        `json:"created_by"`
+	Date       string     `json:"date"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	UpdatedBy  int        `json:"updated_by"`
 }
 
 // SimpleEntityEvent contains only the simple information about an entity event.
 // SimpleEntityEvent is primarily used to create new entity events for posting to Kanka.
 type SimpleEntityEvent struct {
-	Day            int    `json:"day"`
-	Month          int    `json:"month"`
-	Year           int    `json:"year"`
-	Length         int    `json:"length"`
-	EntityID       int    `json:"entity_id"`
-	Colour         string `json:"colour,omitempty"`
-	Comment        string `json:"comment,omitempty"`
-	IsRecurring    bool   `json:"is_recurring,omitempty"`
-	IsPrivate      bool   `json:"is_private,omitempty"`
-	RecurringUntil int    `json:"recurring_until,omitempty"`
+	Day            int      `json:"day"`
+	Month          int      `json:"month"`
+	Year           int      `json:"year"`
+	Length         int      `json:"length"`
+	EntityID       EntityID `json:"entity_id"`
+	Colour         string   `json:"colour,omitempty"`
+	Comment        string   `json:"comment,omitempty"`
+	IsRecurring    bool     `json:"is_recurring,omitempty"`
+	IsPrivate      bool     `json:"is_private,omitempty"`
+	RecurringUntil int      `json:"recurring_until,omitempty"`
+}
+
+// CalendarDate returns the date of the SimpleEntityEvent in its calendar.
+func (se SimpleEntityEvent) CalendarDate() CalendarDate {
+	return CalendarDate{Year: se.Year, Month: se.Month, Day: se.Day}
 }
 
 // EntityEvents wraps a list of entity events.
@@ -51,6 +56,9 @@ type EntityEventService service
 // entID in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return EntityEvents that have
 // been changed since that time.
+//
+// Deprecated: Use EntityHandle.Events, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (es *EntityEventService) Index(campID int, entID int, sync *time.Time) ([]*EntityEvent, error) {
 	var err error
 	end := EndpointCampaign
@@ -82,6 +90,9 @@ func (es *EntityEventService) Index(campID int, entID int, sync *time.Time) ([]*
 
 // Get returns the EntityEvent associated with evtID for the entity associated
 // with entID from the Campaign associated with campID.
+//
+// Deprecated: Use EntityHandle.Events, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (es *EntityEventService) Get(campID int, entID int, evtID int) (*EntityEvent, error) {
 	var err error
 	end := EndpointCampaign
@@ -114,6 +125,9 @@ func (es *EntityEventService) Get(campID int, entID int, evtID int) (*EntityEven
 // Create creates a new EntityEvent for the entity associated with entID in the
 // Campaign associated with campID using the provided SimpleEntityEvent data.
 // Create returns the newly created EntityEvent.
+//
+// Deprecated: Use EntityHandle.Events, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (es *EntityEventService) Create(campID int, entID int, evt SimpleEntityEvent) (*EntityEvent, error) {
 	var err error
 	end := EndpointCampaign
@@ -148,6 +162,9 @@ func (es *EntityEventService) Create(campID int, entID int, evt SimpleEntityEven
 // associated with entID from the Campaign associated with campID using the
 // provided SimpleEntityEvent data.
 // Update returns the newly updated EntityEvent.
+//
+// Deprecated: Use EntityHandle.Events, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (es *EntityEventService) Update(campID int, entID int, evtID int, evt SimpleEntityEvent) (*EntityEvent, error) {
 	var err error
 	end := EndpointCampaign
@@ -184,6 +201,9 @@ func (es *EntityEventService) Update(campID int, entID int, evtID int, evt Simpl
 
 // Delete deletes an existing EntityEvent associated with evtID from the
 // Campaign associated with campID.
+//
+// Deprecated: Use EntityHandle.Events, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (es *EntityEventService) Delete(campID int, entID int, evtID int) error {
 	var err error
 	end := EndpointCampaign
@@ -14,7 +14,7 @@ type EntityFiles struct {
 type EntityFile struct {
 	CreatedAt  time.Time `json:"created_at"`
 	CreatedBy  int       `json:"created_by"`
-	EntityID   int       `json:"entity_id"`
+	EntityID   EntityID  `json:"entity_id"`
 	ID         int       `json:"id"`
 	IsPrivate  bool      `json:"is_private"`
 	Name       string    `json:"name"`
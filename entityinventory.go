@@ -3,7 +3,10 @@ package kanka
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"sort"
 	"time"
 )
 
@@ -21,12 +24,12 @@ type EntityInventory struct {
 // SimpleEntityInventory contains only the simple information about an entity inventory.
 // SimpleEntityInventory is primarily used to create new entity inventories for posting to Kanka.
 type SimpleEntityInventory struct {
-	EntityID   int    `json:"entity_id"`
-	ItemID     int    `json:"item_id"`
-	Amount     int    `json:"amount"`
-	Position   string `json:"position,omitempty"`
-	Visibility string `json:"visibility,omitempty"`
-	IsPrivate  bool   `json:"is_private,omitempty"`
+	EntityID   EntityID `json:"entity_id"`
+	ItemID     ItemID   `json:"item_id"`
+	Amount     int      `json:"amount"`
+	Position   string   `json:"position,omitempty"`
+	Visibility string   `json:"visibility,omitempty"`
+	IsPrivate  bool     `json:"is_private,omitempty"`
 }
 
 // EntityInventoryService handles communication with the EntityInventory endpoint.
@@ -36,6 +39,9 @@ type EntityInventoryService service
 // entID in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return EntityInventories that have
 // been changed since that time.
+//
+// Deprecated: Use EntityHandle.Inventory, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (es *EntityInventoryService) Index(campID int, entID int, sync *time.Time) ([]*EntityInventory, error) {
 	var err error
 	end := EndpointCampaign
@@ -68,6 +74,9 @@ func (es *EntityInventoryService) Index(campID int, entID int, sync *time.Time)
 // Create creates a new EntityInventory for the entity associated with entID in the
 // Campaign associated with campID using the provided SimpleEntityInventory data.
 // Create returns the newly created EntityInventory.
+//
+// Deprecated: Use EntityHandle.Inventory, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (es *EntityInventoryService) Create(campID int, entID int, inv SimpleEntityInventory) (*EntityInventory, error) {
 	var err error
 	end := EndpointCampaign
@@ -102,6 +111,9 @@ func (es *EntityInventoryService) Create(campID int, entID int, inv SimpleEntity
 // associated with entID from the Campaign associated with campID using the
 // provided SimpleEntityInventory data.
 // Update returns the newly updated EntityInventory.
+//
+// Deprecated: Use EntityHandle.Inventory, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (es *EntityInventoryService) Update(campID int, entID int, invID int, inv SimpleEntityInventory) (*EntityInventory, error) {
 	var err error
 	end := EndpointCampaign
@@ -138,6 +150,9 @@ func (es *EntityInventoryService) Update(campID int, entID int, invID int, inv S
 
 // Delete deletes an existing EntityInventory associated with invID from the
 // Campaign associated with campID.
+//
+// Deprecated: Use EntityHandle.Inventory, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (es *EntityInventoryService) Delete(campID int, entID int, invID int) error {
 	var err error
 	end := EndpointCampaign
@@ -162,3 +177,185 @@ func (es *EntityInventoryService) Delete(campID int, entID int, invID int) error
 
 	return nil
 }
+
+// Transfer moves amount of the item associated with itemID from the
+// inventory of the entity associated with from to the inventory of the
+// entity associated with to in the Campaign associated with campID.
+// The source's stacks of the item are decremented, or deleted once empty,
+// and the target's first stack of the item is incremented, or created with
+// the position and visibility of the source's first stack. If the target
+// cannot be updated, the source's stacks are restored.
+// Transfer returns the target's updated or created EntityInventory.
+func (es *EntityInventoryService) Transfer(campID CampaignID, from EntityID, to EntityID, itemID ItemID, amount int) (*EntityInventory, error) {
+	if amount < 1 {
+		return nil, fmt.Errorf("cannot transfer %d of Item (ID: %d): amount must be positive", amount, itemID)
+	}
+	if from == to {
+		return nil, fmt.Errorf("cannot transfer Item (ID: %d) from Entity (ID: %d) to itself", itemID, from)
+	}
+
+	src, err := es.stacks(campID, from, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot transfer Item (ID: %d) from Entity (ID: %d): %w", itemID, from, err)
+	}
+
+	var held int
+	for _, inv := range src {
+		held += inv.Amount
+	}
+	if held < amount {
+		return nil, fmt.Errorf("cannot transfer %d of Item (ID: %d) from Entity (ID: %d): only %d held", amount, itemID, from, held)
+	}
+
+	dst, err := es.stacks(campID, to, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot transfer Item (ID: %d) to Entity (ID: %d): %w", itemID, to, err)
+	}
+
+	var taken []*EntityInventory
+	left := amount
+	for _, inv := range src {
+		if left == 0 {
+			break
+		}
+
+		n := minInt(inv.Amount, left)
+		if inv.Amount == n {
+			err = es.Delete(int(campID), int(from), inv.ID)
+		} else {
+			upd := inv.SimpleEntityInventory
+			upd.Amount -= n
+			_, err = es.Update(int(campID), int(from), inv.ID, upd)
+		}
+		if err != nil {
+			return nil, es.rollback(campID, from, taken, fmt.Errorf("cannot take Item (ID: %d) from Entity (ID: %d): %w", itemID, from, err))
+		}
+
+		taken = append(taken, inv)
+		left -= n
+	}
+
+	var moved *EntityInventory
+	if len(dst) > 0 {
+		upd := dst[0].SimpleEntityInventory
+		upd.Amount += amount
+		moved, err = es.Update(int(campID), int(to), dst[0].ID, upd)
+	} else {
+		add := src[0].SimpleEntityInventory
+		add.EntityID = to
+		add.Amount = amount
+		moved, err = es.Create(int(campID), int(to), add)
+	}
+	if err != nil {
+		return nil, es.rollback(campID, from, taken, fmt.Errorf("cannot give Item (ID: %d) to Entity (ID: %d): %w", itemID, to, err))
+	}
+
+	return moved, nil
+}
+
+// stacks returns the EntityInventories of the item associated with itemID
+// held by the entity associated with entID, ordered by ID.
+func (es *EntityInventoryService) stacks(campID CampaignID, entID EntityID, itemID ItemID) ([]*EntityInventory, error) {
+	invs, err := es.Index(int(campID), int(entID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var stacks []*EntityInventory
+	for _, inv := range invs {
+		if inv.ItemID == itemID {
+			stacks = append(stacks, inv)
+		}
+	}
+	sort.Slice(stacks, func(i, j int) bool { return stacks[i].ID < stacks[j].ID })
+
+	return stacks, nil
+}
+
+// rollback restores the provided EntityInventories of the entity associated
+// with entID to their original amounts, recreating deleted ones, and
+// returns cause annotated with any failure to do so.
+func (es *EntityInventoryService) rollback(campID CampaignID, entID EntityID, invs []*EntityInventory, cause error) error {
+	for _, inv := range invs {
+		_, err := es.Update(int(campID), int(entID), inv.ID, inv.SimpleEntityInventory)
+		if err == nil {
+			continue
+		}
+
+		var serr *serverError
+		if errors.As(err, &serr) && serr.code == http.StatusNotFound {
+			_, err = es.Create(int(campID), int(entID), inv.SimpleEntityInventory)
+		}
+		if err != nil {
+			return fmt.Errorf("%w; cannot restore EntityInventory (ID: %d): %v", cause, inv.ID, err)
+		}
+	}
+
+	return cause
+}
+
+// MergeStacks merges the EntityInventories of the entity associated with
+// entID in the Campaign associated with campID that hold the same item in
+// the same position into the stack with the lowest ID. If a duplicate stack
+// cannot be deleted, the merged stack is reduced so that no amount is
+// counted twice.
+// MergeStacks returns the resulting inventory of the entity.
+func (es *EntityInventoryService) MergeStacks(campID CampaignID, entID EntityID) ([]*EntityInventory, error) {
+	invs, err := es.Index(int(campID), int(entID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot merge inventory of Entity (ID: %d): %w", entID, err)
+	}
+	sort.Slice(invs, func(i, j int) bool { return invs[i].ID < invs[j].ID })
+
+	type stackKey struct {
+		item     int
+		position string
+	}
+
+	var keys []stackKey
+	groups := make(map[stackKey][]*EntityInventory)
+	for _, inv := range invs {
+		k := stackKey{int(inv.ItemID), inv.Position}
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], inv)
+	}
+
+	var merged []*EntityInventory
+	for _, k := range keys {
+		keep, dups := groups[k][0], groups[k][1:]
+		if len(dups) == 0 {
+			merged = append(merged, keep)
+			continue
+		}
+
+		upd := keep.SimpleEntityInventory
+		for _, d := range dups {
+			upd.Amount += d.Amount
+		}
+
+		if keep, err = es.Update(int(campID), int(entID), keep.ID, upd); err != nil {
+			return nil, fmt.Errorf("cannot merge stacks of Item (ID: %d) of Entity (ID: %d): %w", k.item, entID, err)
+		}
+
+		for i, d := range dups {
+			if err = es.Delete(int(campID), int(entID), d.ID); err == nil {
+				continue
+			}
+
+			cause := fmt.Errorf("cannot merge stacks of Item (ID: %d) of Entity (ID: %d): %w", k.item, entID, err)
+			for _, r := range dups[i:] {
+				upd.Amount -= r.Amount
+			}
+			if _, err = es.Update(int(campID), int(entID), keep.ID, upd); err != nil {
+				return nil, fmt.Errorf("%w; cannot restore EntityInventory (ID: %d): %v", cause, keep.ID, err)
+			}
+			return nil, cause
+		}
+
+		merged = append(merged, keep)
+	}
+
+	return merged, nil
+}
@@ -3,6 +3,7 @@ package kanka
 import (
 	"net/http"
 	"os"
+	"strconv"
 	"testing"
 	"time"
 
@@ -478,3 +479,117 @@ func TestEntityInventoryService_Delete(t *testing.T) {
 		})
 	}
 }
+
+// seedStacks seeds the test campaign with several stacks of Item 41.
+func seedStacks(t *testing.T, fk *fakeKanka) {
+	seedCampaign(t, fk, "")
+	fk.seed(t, "/campaigns/1/entities/130/inventory", &EntityInventory{ID: 90, SimpleEntityInventory: SimpleEntityInventory{EntityID: 130, ItemID: 41, Amount: 5, Position: "Backpack"}})
+	fk.seed(t, "/campaigns/1/entities/130/inventory", &EntityInventory{ID: 91, SimpleEntityInventory: SimpleEntityInventory{EntityID: 130, ItemID: 41, Amount: 2, Position: "Backpack"}})
+	fk.seed(t, "/campaigns/1/entities/130/inventory", &EntityInventory{ID: 93, SimpleEntityInventory: SimpleEntityInventory{EntityID: 130, ItemID: 41, Amount: 1, Position: "Belt"}})
+	fk.seed(t, "/campaigns/1/entities/131/inventory", &EntityInventory{ID: 92, SimpleEntityInventory: SimpleEntityInventory{EntityID: 131, ItemID: 41, Amount: 1}})
+}
+
+// stackAmounts returns the amounts of Item 41 held by the entity associated
+// with entID keyed by EntityInventory ID.
+func stackAmounts(fk *fakeKanka, entID int) map[int]int {
+	amounts := make(map[int]int)
+	for _, obj := range fk.list("/campaigns/1/entities/" + strconv.Itoa(entID) + "/inventory") {
+		if intField(obj, "item_id") == 41 {
+			amounts[intField(obj, "id")] = intField(obj, "amount")
+		}
+	}
+	return amounts
+}
+
+func TestEntityInventoryService_Transfer(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedStacks(t, fk)
+
+	inv, err := c.EntityInventories.Transfer(1, 130, 131, 41, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inv.ID != 92 || inv.Amount != 7 {
+		t.Errorf("got EntityInventory <%d> with amount <%d>, want <%d> with <%d>", inv.ID, inv.Amount, 92, 7)
+	}
+	if diff := cmp.Diff(map[int]int{91: 1, 93: 1}, stackAmounts(fk, 130)); diff != "" {
+		t.Errorf("source mismatch (-want +got):\n%s", diff)
+	}
+
+	inv, err = c.EntityInventories.Transfer(1, 130, 150, 40, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inv.EntityID != 150 || inv.Amount != 1 || fk.object("/campaigns/1/entities/130/inventory", 84) != nil {
+		t.Errorf("got EntityInventory <%+v>, want a new stack of 1 and the empty stack deleted", *inv)
+	}
+
+	if _, err = c.EntityInventories.Transfer(1, 130, 131, 41, 9); err == nil {
+		t.Error("got nil error for transferring more than held, want error")
+	}
+	if _, err = c.EntityInventories.Transfer(1, 130, 130, 41, 1); err == nil {
+		t.Error("got nil error for transferring to the same entity, want error")
+	}
+	if _, err = c.EntityInventories.Transfer(1, 130, 131, 41, 0); err == nil {
+		t.Error("got nil error for transferring nothing, want error")
+	}
+}
+
+func TestEntityInventoryService_Transfer_Rollback(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedStacks(t, fk)
+	fk.fail["PUT /campaigns/1/entities/131/inventory/92"] = http.StatusInternalServerError
+
+	if _, err := c.EntityInventories.Transfer(1, 130, 131, 41, 6); err == nil {
+		t.Fatal("got nil error, want error")
+	}
+
+	var total int
+	amounts := stackAmounts(fk, 130)
+	for _, n := range amounts {
+		total += n
+	}
+	if len(amounts) != 3 || total != 8 || amounts[91] != 2 {
+		t.Errorf("got stacks <%v>, want the original three stacks restored", amounts)
+	}
+	if diff := cmp.Diff(map[int]int{92: 1}, stackAmounts(fk, 131)); diff != "" {
+		t.Errorf("target mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestEntityInventoryService_MergeStacks(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedStacks(t, fk)
+
+	invs, err := c.EntityInventories.MergeStacks(1, 130)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(invs) != 3 {
+		t.Errorf("got <%d> stacks, want <%d>", len(invs), 3)
+	}
+	if diff := cmp.Diff(map[int]int{90: 7, 93: 1}, stackAmounts(fk, 130)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestEntityInventoryService_MergeStacks_Failure(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedStacks(t, fk)
+	fk.fail["DELETE /campaigns/1/entities/130/inventory/91"] = http.StatusInternalServerError
+
+	if _, err := c.EntityInventories.MergeStacks(1, 130); err == nil {
+		t.Fatal("got nil error, want error")
+	}
+	if diff := cmp.Diff(map[int]int{90: 5, 91: 2, 93: 1}, stackAmounts(fk, 130)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
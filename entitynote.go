@@ -24,11 +24,11 @@ type EntityNote struct {
 // SimpleEntityNote contains only the simple information about an entity note.
 // SimpleEntityNote is primarily used to create new entity notes for posting to Kanka.
 type SimpleEntityNote struct {
-	Name       string `json:"name"`
-	EntityID   int    `json:"entity_id"`
-	Entry      string `json:"entry,omitempty"`
-	IsPrivate  bool   `json:"is_private,omitempty"`
-	Visibility string `json:"visibility,omitempty"`
+	Name       string   `json:"name"`
+	EntityID   EntityID `json:"entity_id"`
+	Entry      string   `json:"entry,omitempty"`
+	IsPrivate  bool     `json:"is_private,omitempty"`
+	Visibility string   `json:"visibility,omitempty"`
 }
 
 // MarshalJSON marshals the SimpleEntityNote into its JSON-encoded form if it
@@ -42,6 +42,17 @@ func (se SimpleEntityNote) MarshalJSON() ([]byte, error) {
 	return json.Marshal(alias(se))
 }
 
+// EntryMarkdown returns the SimpleEntityNote's Entry converted into Markdown.
+func (se SimpleEntityNote) EntryMarkdown() string {
+	return EntryToMarkdown(se.Entry)
+}
+
+// SetEntryMarkdown sets the SimpleEntityNote's Entry to the HTML of the provided
+// Markdown.
+func (se *SimpleEntityNote) SetEntryMarkdown(md string) {
+	se.Entry = MarkdownToEntry(md)
+}
+
 // EntityNotes wraps a list of entity notes.
 // EntityNotes exists to satisfy the API's JSON structure.
 type EntityNotes struct {
@@ -56,6 +67,9 @@ type EntityNoteService service
 // entID in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return EntityNotes that have
 // been changed since that time.
+//
+// Deprecated: Use EntityHandle.Notes, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (es *EntityNoteService) Index(campID int, entID int, sync *time.Time) ([]*EntityNote, error) {
 	var err error
 	end := EndpointCampaign
@@ -87,6 +101,9 @@ func (es *EntityNoteService) Index(campID int, entID int, sync *time.Time) ([]*E
 
 // Get returns the EntityNote associated with evtID for the entity associated
 // with entID from the Campaign associated with campID.
+//
+// Deprecated: Use EntityHandle.Notes, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (es *EntityNoteService) Get(campID int, entID int, evtID int) (*EntityNote, error) {
 	var err error
 	end := EndpointCampaign
@@ -119,6 +136,9 @@ func (es *EntityNoteService) Get(campID int, entID int, evtID int) (*EntityNote,
 // Create creates a new EntityNote for the entity associated with entID in the
 // Campaign associated with campID using the provided SimpleEntityNote data.
 // Create returns the newly created EntityNote.
+//
+// Deprecated: Use EntityHandle.Notes, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (es *EntityNoteService) Create(campID int, entID int, note SimpleEntityNote) (*EntityNote, error) {
 	var err error
 	end := EndpointCampaign
@@ -153,6 +173,9 @@ func (es *EntityNoteService) Create(campID int, entID int, note SimpleEntityNote
 // associated with entID from the Campaign associated with campID using the
 // provided SimpleEntityNote data.
 // Update returns the newly updated EntityNote.
+//
+// Deprecated: Use EntityHandle.Notes, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (es *EntityNoteService) Update(campID int, entID int, noteID int, note SimpleEntityNote) (*EntityNote, error) {
 	var err error
 	end := EndpointCampaign
@@ -189,6 +212,9 @@ func (es *EntityNoteService) Update(campID int, entID int, noteID int, note Simp
 
 // Delete deletes an existing EntityNote associated with noteID from the
 // Campaign associated with campID.
+//
+// Deprecated: Use EntityHandle.Notes, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (es *EntityNoteService) Delete(campID int, entID int, noteID int) error {
 	var err error
 	end := EndpointCampaign
@@ -18,8 +18,8 @@ type EntityTag struct {
 // SimpleEntityTag contains only the simple information about an entity tag.
 // SimpleEntityTag is primarily used to create new entity tags for posting to Kanka.
 type SimpleEntityTag struct {
-	EntityID int `json:"entity_id"`
-	TagID    int `json:"tag_id"`
+	EntityID EntityID `json:"entity_id"`
+	TagID    TagID    `json:"tag_id"`
 }
 
 // EntityTagService handles communication with the EntityTag endpoint.
@@ -29,6 +29,9 @@ type EntityTagService service
 // entID in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return EntityTags that have
 // been changed since that time.
+//
+// Deprecated: Use EntityHandle.Tags, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (es *EntityTagService) Index(campID int, entID int, sync *time.Time) ([]*EntityTag, error) {
 	var err error
 	end := EndpointCampaign
@@ -60,6 +63,9 @@ func (es *EntityTagService) Index(campID int, entID int, sync *time.Time) ([]*En
 
 // Get returns the EntityTag associated with tagID for the entity associated
 // with entID from the Campaign associated with campID.
+//
+// Deprecated: Use EntityHandle.Tags, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (es *EntityTagService) Get(campID int, entID int, tagID int) (*EntityTag, error) {
 	var err error
 	end := EndpointCampaign
@@ -92,6 +98,9 @@ func (es *EntityTagService) Get(campID int, entID int, tagID int) (*EntityTag, e
 // Create creates a new EntityTag for the entity associated with entID in the
 // Campaign associated with campID using the provided SimpleEntityTag data.
 // Create returns the newly created EntityTag.
+//
+// Deprecated: Use EntityHandle.Tags, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (es *EntityTagService) Create(campID int, entID int, tag SimpleEntityTag) (*EntityTag, error) {
 	var err error
 	end := EndpointCampaign
@@ -126,6 +135,9 @@ func (es *EntityTagService) Create(campID int, entID int, tag SimpleEntityTag) (
 // associated with entID from the Campaign associated with campID using the
 // provided SimpleEntityTag data.
 // Update returns the newly updated EntityTag.
+//
+// Deprecated: Use EntityHandle.Tags, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (es *EntityTagService) Update(campID int, entID int, tagID int, tag SimpleEntityTag) (*EntityTag, error) {
 	var err error
 	end := EndpointCampaign
@@ -162,6 +174,9 @@ func (es *EntityTagService) Update(campID int, entID int, tagID int, tag SimpleE
 
 // Delete deletes an existing EntityTag associated with tagID from the
 // Campaign associated with campID.
+//
+// Deprecated: Use EntityHandle.Tags, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (es *EntityTagService) Delete(campID int, entID int, tagID int) error {
 	var err error
 	end := EndpointCampaign
@@ -0,0 +1,343 @@
+package kanka
+
+import (
+	"html"
+	"strings"
+)
+
+// htmlTokenType identifies the kind of an htmlToken.
+type htmlTokenType int
+
+const (
+	htmlText htmlTokenType = iota
+	htmlStart
+	htmlEnd
+	htmlSelfClosing
+)
+
+// htmlToken is a single token of an entry's HTML.
+// Text holds the unescaped text of text tokens. Tag holds the lowercase
+// name of tag tokens.
+type htmlToken struct {
+	Type  htmlTokenType
+	Text  string
+	Tag   string
+	Attrs map[string]string
+}
+
+// voidTags lists the HTML elements which never have an end tag.
+var voidTags = map[string]bool{
+	"br":    true,
+	"hr":    true,
+	"img":   true,
+	"input": true,
+	"meta":  true,
+	"link":  true,
+	"col":   true,
+	"wbr":   true,
+}
+
+// tokenizeHTML splits the provided HTML into tokens. Comments and doctypes
+// are dropped. tokenizeHTML is lenient and treats malformed tags as text.
+func tokenizeHTML(s string) []htmlToken {
+	var toks []htmlToken
+	var text strings.Builder
+
+	flush := func() {
+		if text.Len() > 0 {
+			toks = append(toks, htmlToken{Type: htmlText, Text: html.UnescapeString(text.String())})
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); {
+		if s[i] != '<' {
+			text.WriteByte(s[i])
+			i++
+			continue
+		}
+
+		if strings.HasPrefix(s[i:], "<!--") {
+			end := strings.Index(s[i+4:], "-->")
+			if end < 0 {
+				break
+			}
+			flush()
+			i += 4 + end + 3
+			continue
+		}
+
+		end := tagEnd(s, i)
+		if end < 0 || i+1 >= len(s) || !isTagStart(s[i+1]) {
+			text.WriteByte(s[i])
+			i++
+			continue
+		}
+
+		flush()
+		if tok, ok := parseTag(s[i+1 : end]); ok {
+			toks = append(toks, tok)
+		}
+		i = end + 1
+	}
+	flush()
+
+	return toks
+}
+
+// isTagStart returns true if c can follow the '<' of a tag.
+func isTagStart(c byte) bool {
+	return c == '/' || c == '!' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// tagEnd returns the index of the '>' closing the tag starting at index i,
+// skipping over quoted attribute values, or -1 if there is none.
+func tagEnd(s string, i int) int {
+	var quote byte
+	for j := i + 1; j < len(s); j++ {
+		switch c := s[j]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return j
+		}
+	}
+	return -1
+}
+
+// parseTag parses the contents of a tag without its angle brackets.
+func parseTag(s string) (htmlToken, bool) {
+	if strings.HasPrefix(s, "!") {
+		return htmlToken{}, false
+	}
+
+	tok := htmlToken{Type: htmlStart}
+	if strings.HasPrefix(s, "/") {
+		tok.Type = htmlEnd
+		s = s[1:]
+	}
+	if strings.HasSuffix(s, "/") {
+		tok.Type = htmlSelfClosing
+		s = s[:len(s)-1]
+	}
+
+	s = strings.TrimSpace(s)
+	n := strings.IndexAny(s, " \t\r\n")
+	if n < 0 {
+		n = len(s)
+	}
+	tok.Tag = strings.ToLower(s[:n])
+	tok.Attrs = parseAttrs(s[n:])
+
+	if tok.Type == htmlStart && voidTags[tok.Tag] {
+		tok.Type = htmlSelfClosing
+	}
+
+	return tok, tok.Tag != ""
+}
+
+// parseAttrs parses the attributes of a tag.
+func parseAttrs(s string) map[string]string {
+	attrs := make(map[string]string)
+
+	for {
+		s = strings.TrimLeft(s, " \t\r\n")
+		if s == "" {
+			return attrs
+		}
+
+		n := strings.IndexAny(s, "= \t\r\n")
+		if n < 0 {
+			attrs[strings.ToLower(s)] = ""
+			return attrs
+		}
+
+		name := strings.ToLower(s[:n])
+		s = strings.TrimLeft(s[n:], " \t\r\n")
+		if !strings.HasPrefix(s, "=") {
+			attrs[name] = ""
+			continue
+		}
+		s = strings.TrimLeft(s[1:], " \t\r\n")
+
+		var val string
+		if s != "" && (s[0] == '"' || s[0] == '\'') {
+			end := strings.IndexByte(s[1:], s[0])
+			if end < 0 {
+				end = len(s) - 1
+			}
+			val, s = s[1:end+1], s[minInt(end+2, len(s)):]
+		} else {
+			end := strings.IndexAny(s, " \t\r\n")
+			if end < 0 {
+				end = len(s)
+			}
+			val, s = s[:end], s[end:]
+		}
+		attrs[name] = html.UnescapeString(val)
+	}
+}
+
+// minInt returns the smaller of a and b.
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// blockTags lists the HTML elements rendered on lines of their own.
+var blockTags = map[string]bool{
+	"p":          true,
+	"div":        true,
+	"h1":         true,
+	"h2":         true,
+	"h3":         true,
+	"h4":         true,
+	"h5":         true,
+	"h6":         true,
+	"ul":         true,
+	"ol":         true,
+	"li":         true,
+	"table":      true,
+	"tr":         true,
+	"blockquote": true,
+	"pre":        true,
+	"hr":         true,
+}
+
+// htmlToText returns the plain text of the provided HTML. Block elements
+// are separated by newlines and list items are prefixed with dashes.
+func htmlToText(s string) string {
+	var b strings.Builder
+
+	for _, tok := range tokenizeHTML(s) {
+		switch {
+		case tok.Type == htmlText:
+			b.WriteString(collapseSpace(tok.Text))
+		case tok.Tag == "br":
+			b.WriteString("\n")
+		case tok.Tag == "li" && tok.Type == htmlStart:
+			b.WriteString("\n- ")
+		case tok.Tag == "td" || tok.Tag == "th":
+			if tok.Type == htmlEnd {
+				b.WriteString("\t")
+			}
+		case blockTags[tok.Tag]:
+			b.WriteString("\n")
+		}
+	}
+
+	return tidyLines(b.String())
+}
+
+// collapseSpace replaces every run of whitespace in s with a single space.
+func collapseSpace(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range s {
+		if r == ' ' || r == '\t' || r == '\n' || r == '\r' {
+			space = true
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+	if space {
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
+
+// tidyLines trims the trailing whitespace of every line in s, removes
+// leading spaces introduced by collapsed whitespace, and limits runs of
+// blank lines to one.
+func tidyLines(s string) string {
+	lines := strings.Split(s, "\n")
+	out := make([]string, 0, len(lines))
+	blank := true
+
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if strings.HasPrefix(line, " ") && !strings.HasPrefix(strings.TrimLeft(line, " "), "- ") {
+			line = strings.TrimLeft(line, " ")
+		}
+		if line == "" {
+			if !blank {
+				out = append(out, "")
+			}
+			blank = true
+			continue
+		}
+		out = append(out, line)
+		blank = false
+	}
+
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
+
+// htmlNode is a single node of an entry's HTML tree. Text nodes have an
+// empty Tag.
+type htmlNode struct {
+	Tag      string
+	Text     string
+	Attrs    map[string]string
+	Children []*htmlNode
+}
+
+// parseHTML parses the provided HTML into a tree under a root node.
+// Unmatched end tags are ignored and unclosed elements are closed at the
+// end of their parent.
+func parseHTML(s string) *htmlNode {
+	root := &htmlNode{}
+	stack := []*htmlNode{root}
+
+	for _, tok := range tokenizeHTML(s) {
+		top := stack[len(stack)-1]
+
+		switch tok.Type {
+		case htmlText:
+			top.Children = append(top.Children, &htmlNode{Text: tok.Text})
+		case htmlSelfClosing:
+			top.Children = append(top.Children, &htmlNode{Tag: tok.Tag, Attrs: tok.Attrs})
+		case htmlStart:
+			if (tok.Tag == "li" || tok.Tag == "p") && top.Tag == tok.Tag {
+				stack = stack[:len(stack)-1]
+				top = stack[len(stack)-1]
+			}
+			n := &htmlNode{Tag: tok.Tag, Attrs: tok.Attrs}
+			top.Children = append(top.Children, n)
+			stack = append(stack, n)
+		case htmlEnd:
+			for i := len(stack) - 1; i > 0; i-- {
+				if stack[i].Tag == tok.Tag {
+					stack = stack[:i]
+					break
+				}
+			}
+		}
+	}
+
+	return root
+}
+
+// text returns the concatenated text of the node and its descendants.
+func (n *htmlNode) text() string {
+	if n.Tag == "" {
+		return n.Text
+	}
+
+	var b strings.Builder
+	for _, c := range n.Children {
+		b.WriteString(c.text())
+	}
+
+	return b.String()
+}
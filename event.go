@@ -13,11 +13,11 @@ import (
 // For more information, visit: https://kanka.io/en-US/docs/1.0/events
 type Event struct {
 	SimpleEvent
-	ID             int       `json:"id"`
+	ID             EventID   `json:"id"`
 	ImageFull      string    `json:"image_full"`
 	ImageThumb     string    `json:"image_thumb"`
 	HasCustomImage bool      `json:"has_custom_image"`
-	EntityID       int       `json:"entity_id"`
+	EntityID       EntityID  `json:"entity_id"`
 	CreatedAt      time.Time `json:"created_at"`
 	CreatedBy      int       `json:"created_by"`
 	UpdatedAt      time.Time `json:"updated_at"`
@@ -34,15 +34,15 @@ type Event struct {
 // SimpleEvent contains only the simple information about an event.
 // SimpleEvent is primarily used to create new events for posting to Kanka.
 type SimpleEvent struct {
-	Name       string `json:"name"`
-	Entry      string `json:"entry,omitempty"`
-	Type       string `json:"type,omitempty"`
-	Date       string `json:"date,omitempty"`
-	LocationID int    `json:"location_id,omitempty"`
-	Tags       []int  `json:"tags,omitempty"`
-	IsPrivate  bool   `json:"is_private,omitempty"`
-	Image      string `json:"image,omitempty"`
-	ImageURL   string `json:"image_url,omitempty"`
+	Name       string     `json:"name"`
+	Entry      string     `json:"entry,omitempty"`
+	Type       string     `json:"type,omitempty"`
+	Date       string     `json:"date,omitempty"`
+	LocationID LocationID `json:"location_id,omitempty"`
+	Tags       []TagID    `json:"tags,omitempty"`
+	IsPrivate  bool       `json:"is_private,omitempty"`
+	Image      string     `json:"image,omitempty"`
+	ImageURL   string     `json:"image_url,omitempty"`
 }
 
 // MarshalJSON marshals the SimpleEvent into its JSON-encoded form if it
@@ -56,12 +56,25 @@ func (se SimpleEvent) MarshalJSON() ([]byte, error) {
 	return json.Marshal(alias(se))
 }
 
+// EntryMarkdown returns the SimpleEvent's Entry converted into Markdown.
+func (se SimpleEvent) EntryMarkdown() string {
+	return EntryToMarkdown(se.Entry)
+}
+
+// SetEntryMarkdown sets the SimpleEvent's Entry to the HTML of the provided
+// Markdown.
+func (se *SimpleEvent) SetEntryMarkdown(md string) {
+	se.Entry = MarkdownToEntry(md)
+}
+
 // EventService handles communication with the Event endpoint.
 type EventService service
 
 // Index returns the list of all Events in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return Events that have
 // been changed since that time.
+//
+// Deprecated: Use CampaignHandle.Events, which takes typed IDs.
 func (es *EventService) Index(campID int, sync *time.Time) ([]*Event, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -87,6 +100,8 @@ func (es *EventService) Index(campID int, sync *time.Time) ([]*Event, error) {
 
 // Get returns the Event associated with evtID from the Campaign
 // associated with campID.
+//
+// Deprecated: Use CampaignHandle.Events, which takes typed IDs.
 func (es *EventService) Get(campID int, evtID int) (*Event, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -114,6 +129,8 @@ func (es *EventService) Get(campID int, evtID int) (*Event, error) {
 // Create creates a new Event in the Campaign associated with campID using
 // the provided SimpleEvent data.
 // Create returns the newly created Event.
+//
+// Deprecated: Use CampaignHandle.Events, which takes typed IDs.
 func (es *EventService) Create(campID int, evt SimpleEvent) (*Event, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -141,6 +158,8 @@ func (es *EventService) Create(campID int, evt SimpleEvent) (*Event, error) {
 // Update updates an existing Event associated with evtID from the
 // Campaign associated with campID using the provided SimpleEvent data.
 // Update returns the newly updated Event.
+//
+// Deprecated: Use CampaignHandle.Events, which takes typed IDs.
 func (es *EventService) Update(campID int, evtID int, evt SimpleEvent) (*Event, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -172,6 +191,8 @@ func (es *EventService) Update(campID int, evtID int, evt SimpleEvent) (*Event,
 
 // Delete deletes an existing Event associated with evtID from the
 // Campaign associated with campID.
+//
+// Deprecated: Use CampaignHandle.Events, which takes typed IDs.
 func (es *EventService) Delete(campID int, evtID int) error {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -138,7 +138,7 @@ func TestEventService_Get(t *testing.T) {
 			Date:       "First day of Frostbloom",
 			Image:      "events/pKtVTVLA776DKxNq3PMquVmNGmg3rJVboEJcje8j.jpeg",
 			IsPrivate:  false,
-			Tags:       []int{35131},
+			Tags:       []TagID{35131},
 			LocationID: 115366,
 			Type:       "Festival",
 		},
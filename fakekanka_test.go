@@ -0,0 +1,255 @@
+package kanka
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeKanka is an in-memory imitation of the Kanka API used to test
+// operations spanning several services. Every path is treated as a REST
+// collection of JSON objects keyed by their ID.
+type fakeKanka struct {
+	mu     sync.Mutex
+	nextID int
+	colls  map[string]map[int]map[string]interface{}
+	fail   map[string]int
+	calls  []string
+	images map[string][]byte
+}
+
+// coreObjects lists the collections whose objects own an entity.
+var coreObjects = map[string]bool{
+	"characters":    true,
+	"locations":     true,
+	"families":      true,
+	"organisations": true,
+	"items":         true,
+	"notes":         true,
+	"events":        true,
+	"races":         true,
+	"quests":        true,
+	"journals":      true,
+	"tags":          true,
+}
+
+// parentKeys maps a parent collection to the field its children use to
+// refer to it.
+var parentKeys = map[string]string{
+	"entities":      "entity_id",
+	"quests":        "quest_id",
+	"locations":     "location_id",
+	"organisations": "organisation_id",
+}
+
+// newFakeKanka returns a fakeKanka and a Client configured to communicate
+// with it.
+func newFakeKanka(t *testing.T) (*Client, *fakeKanka, *httptest.Server) {
+	fk := &fakeKanka{
+		nextID: 1000,
+		colls:  make(map[string]map[int]map[string]interface{}),
+		fail:   make(map[string]int),
+		images: make(map[string][]byte),
+	}
+
+	ts := httptest.NewServer(fk)
+
+	c := NewClient(testToken, ts.Client())
+	c.rootURL = ts.URL + "/"
+
+	return c, fk, ts
+}
+
+// seed stores the provided object in the collection at the provided path
+// and returns its ID. Objects without an ID are assigned one, as are core
+// objects without an entity ID.
+func (fk *fakeKanka) seed(t *testing.T, coll string, v interface{}) int {
+	b, err := marshalRecord(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var obj map[string]interface{}
+	if err = json.Unmarshal(b, &obj); err != nil {
+		t.Fatal(err)
+	}
+
+	fk.mu.Lock()
+	defer fk.mu.Unlock()
+
+	return fk.store(coll, obj)
+}
+
+// store stores obj in the collection at the provided path, assigning IDs
+// where necessary.
+func (fk *fakeKanka) store(coll string, obj map[string]interface{}) int {
+	id := intField(obj, "id")
+	if id == 0 {
+		fk.nextID++
+		id = fk.nextID
+		obj["id"] = id
+	}
+	if id > fk.nextID {
+		fk.nextID = id
+	}
+
+	segs := strings.Split(strings.Trim(coll, "/"), "/")
+	if coreObjects[segs[len(segs)-1]] && intField(obj, "entity_id") == 0 {
+		fk.nextID++
+		obj["entity_id"] = fk.nextID
+	}
+
+	if len(segs) >= 3 {
+		parent, _ := strconv.Atoi(segs[len(segs)-2])
+		if key, ok := parentKeys[segs[len(segs)-3]]; ok && intField(obj, key) == 0 {
+			obj[key] = parent
+		}
+		if segs[len(segs)-1] == "relations" && intField(obj, "owner_id") == 0 {
+			obj["owner_id"] = parent
+		}
+	}
+
+	if fk.colls[coll] == nil {
+		fk.colls[coll] = make(map[int]map[string]interface{})
+	}
+	fk.colls[coll][id] = obj
+
+	return id
+}
+
+// list returns the objects in the collection at the provided path ordered
+// by ID.
+func (fk *fakeKanka) list(coll string) []map[string]interface{} {
+	fk.mu.Lock()
+	defer fk.mu.Unlock()
+
+	return fk.sorted(coll)
+}
+
+func (fk *fakeKanka) sorted(coll string) []map[string]interface{} {
+	var ids []int
+	for id := range fk.colls[coll] {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	objs := []map[string]interface{}{}
+	for _, id := range ids {
+		objs = append(objs, fk.colls[coll][id])
+	}
+
+	return objs
+}
+
+// object returns the object associated with id in the collection at the
+// provided path.
+func (fk *fakeKanka) object(coll string, id int) map[string]interface{} {
+	fk.mu.Lock()
+	defer fk.mu.Unlock()
+
+	return fk.colls[coll][id]
+}
+
+// count returns the number of recorded calls starting with the provided
+// prefix, such as "POST" or "DELETE /campaigns/1/characters".
+func (fk *fakeKanka) count(prefix string) int {
+	fk.mu.Lock()
+	defer fk.mu.Unlock()
+
+	n := 0
+	for _, c := range fk.calls {
+		if strings.HasPrefix(c, prefix) {
+			n++
+		}
+	}
+
+	return n
+}
+
+func intField(obj map[string]interface{}, key string) int {
+	switch v := obj[key].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	}
+	return 0
+}
+
+func (fk *fakeKanka) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fk.mu.Lock()
+	defer fk.mu.Unlock()
+
+	p := strings.TrimSuffix(r.URL.Path, "/")
+	call := r.Method + " " + p
+	fk.calls = append(fk.calls, call)
+
+	if status, ok := fk.fail[call]; ok {
+		w.WriteHeader(status)
+		return
+	}
+
+	if img, ok := fk.images[p]; ok {
+		w.Write(img)
+		return
+	}
+
+	coll, id := p, 0
+	if i := strings.LastIndex(p, "/"); i >= 0 {
+		if n, err := strconv.Atoi(p[i+1:]); err == nil && r.Method != "POST" {
+			coll, id = p[:i], n
+		}
+	}
+
+	var body map[string]interface{}
+	if r.Method == "POST" || r.Method == "PUT" {
+		b, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(b, &body); err != nil {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+	}
+
+	var data interface{}
+	switch {
+	case r.Method == "GET" && id == 0:
+		data = fk.sorted(coll)
+	case r.Method == "GET":
+		obj, ok := fk.colls[coll][id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		data = obj
+	case r.Method == "POST":
+		delete(body, "id")
+		fk.store(coll, body)
+		data = body
+	case r.Method == "PUT":
+		obj, ok := fk.colls[coll][id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		for k, v := range body {
+			obj[k] = v
+		}
+		data = obj
+	case r.Method == "DELETE":
+		if _, ok := fk.colls[coll][id]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(fk.colls[coll], id)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+}
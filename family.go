@@ -13,16 +13,16 @@ import (
 // For more information, visit: https://kanka.io/en-US/dofs/1.0/families
 type Family struct {
 	SimpleFamily
-	ID             int       `json:"id"`
-	ImageFull      string    `json:"image_full"`
-	ImageThumb     string    `json:"image_thumb"`
-	HasCustomImage bool      `json:"has_custom_image"`
-	EntityID       int       `json:"entity_id"`
-	CreatedAt      time.Time `json:"created_at"`
-	CreatedBy      int       `json:"created_by"`
-	UpdatedAt      time.Time `json:"updated_at"`
-	UpdatedBy      int       `json:"updated_by"`
-	Members        []int     `json:"members"`
+	ID             FamilyID      `json:"id"`
+	ImageFull      string        `json:"image_full"`
+	ImageThumb     string        `json:"image_thumb"`
+	HasCustomImage bool          `json:"has_custom_image"`
+	EntityID       EntityID      `json:"entity_id"`
+	CreatedAt      time.Time     `json:"created_at"`
+	CreatedBy      int           `json:"created_by"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+	UpdatedBy      int           `json:"updated_by"`
+	Members        []CharacterID `json:"members"`
 
 	Attributes   Attributes   `json:"attributes"`
 	EntityEvents EntityEvents `json:"entity_events"`
@@ -36,15 +36,15 @@ type Family struct {
 // SimpleFamily is primarily used to create new families for posting to
 // Kanka.
 type SimpleFamily struct {
-	Name       string `json:"name"`
-	Entry      string `json:"entry,omitempty"`
-	Type       string `json:"type,omitempty"`
-	LocationID int    `json:"location_id,omitempty"`
-	FamilyID   int    `json:"family_id,omitempty"`
-	Tags       []int  `json:"tags,omitempty"`
-	IsPrivate  bool   `json:"is_private,omitempty"`
-	Image      string `json:"image,omitempty"`
-	ImageURL   string `json:"image_url,omitempty"`
+	Name       string     `json:"name"`
+	Entry      string     `json:"entry,omitempty"`
+	Type       string     `json:"type,omitempty"`
+	LocationID LocationID `json:"location_id,omitempty"`
+	FamilyID   FamilyID   `json:"family_id,omitempty"`
+	Tags       []TagID    `json:"tags,omitempty"`
+	IsPrivate  bool       `json:"is_private,omitempty"`
+	Image      string     `json:"image,omitempty"`
+	ImageURL   string     `json:"image_url,omitempty"`
 }
 
 // MarshalJSON marshals the SimpleFamily into its JSON-encoded form if it
@@ -58,12 +58,25 @@ func (sf SimpleFamily) MarshalJSON() ([]byte, error) {
 	return json.Marshal(alias(sf))
 }
 
+// EntryMarkdown returns the SimpleFamily's Entry converted into Markdown.
+func (sf SimpleFamily) EntryMarkdown() string {
+	return EntryToMarkdown(sf.Entry)
+}
+
+// SetEntryMarkdown sets the SimpleFamily's Entry to the HTML of the provided
+// Markdown.
+func (sf *SimpleFamily) SetEntryMarkdown(md string) {
+	sf.Entry = MarkdownToEntry(md)
+}
+
 // FamilyService handles communication with the Family endpoint.
 type FamilyService service
 
 // Index returns the list of all Families in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return Families that have
 // been changed since that time.
+//
+// Deprecated: Use CampaignHandle.Families, which takes typed IDs.
 func (fs *FamilyService) Index(campID int, sync *time.Time) ([]*Family, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -89,6 +102,8 @@ func (fs *FamilyService) Index(campID int, sync *time.Time) ([]*Family, error) {
 
 // Get returns the Family associated with famID from the Campaign
 // associated with campID.
+//
+// Deprecated: Use CampaignHandle.Families, which takes typed IDs.
 func (fs *FamilyService) Get(campID int, famID int) (*Family, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -116,6 +131,8 @@ func (fs *FamilyService) Get(campID int, famID int) (*Family, error) {
 // Create creates a new Family in the Campaign associated with campID using
 // the provided SimpleFamily data.
 // Create returns the newly created Family.
+//
+// Deprecated: Use CampaignHandle.Families, which takes typed IDs.
 func (fs *FamilyService) Create(campID int, fam SimpleFamily) (*Family, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -143,6 +160,8 @@ func (fs *FamilyService) Create(campID int, fam SimpleFamily) (*Family, error) {
 // Update updates an existing Family associated with famID from the
 // Campaign associated with campID using the provided SimpleFamily data.
 // Update returns the newly updated Family.
+//
+// Deprecated: Use CampaignHandle.Families, which takes typed IDs.
 func (fs *FamilyService) Update(campID int, famID int, fam SimpleFamily) (*Family, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -174,6 +193,8 @@ func (fs *FamilyService) Update(campID int, famID int, fam SimpleFamily) (*Famil
 
 // Delete deletes an existing Family associated with famID from the
 // Campaign associated with campID.
+//
+// Deprecated: Use CampaignHandle.Families, which takes typed IDs.
 func (fs *FamilyService) Delete(campID int, famID int) error {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -137,7 +137,7 @@ func TestFamilyService_Get(t *testing.T) {
 			Entry:      "\n<p>House Stark</p>\n",
 			Image:      "families/EIwz3WTvqcbVVcUYBue9O4DQ9dETmlI6JYbkOGx1.png",
 			IsPrivate:  false,
-			Tags:       []int{35131},
+			Tags:       []TagID{35131},
 			Type:       "Royal",
 			LocationID: 115368,
 		},
@@ -148,7 +148,7 @@ func TestFamilyService_Get(t *testing.T) {
 		EntityID:       436884,
 		CreatedBy:      5600,
 		UpdatedBy:      5600,
-		Members:        []int{118427},
+		Members:        []CharacterID{118427},
 	}
 
 	type args struct {
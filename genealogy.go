@@ -0,0 +1,714 @@
+package kanka
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// KinshipNames configures the relations which describe kinship between
+// characters. A relation names the role of its target from the point of
+// view of its owner. For example, a relation from Arya to Ned named "Father"
+// makes Ned a parent of Arya. Names are matched regardless of case.
+// The first name of each list is used when creating relations.
+type KinshipNames struct {
+	Parent []string
+	Child  []string
+	Spouse []string
+}
+
+// DefaultKinshipNames returns the KinshipNames used when none are provided.
+func DefaultKinshipNames() *KinshipNames {
+	return &KinshipNames{
+		Parent: []string{"Parent", "Father", "Mother"},
+		Child:  []string{"Child", "Son", "Daughter"},
+		Spouse: []string{"Spouse", "Husband", "Wife"},
+	}
+}
+
+// kinship returns the kind of kinship the relation name describes, if any.
+func (k *KinshipNames) kinship(name string) string {
+	kinds := []struct {
+		kind  string
+		names []string
+	}{
+		{"parent", k.Parent},
+		{"child", k.Child},
+		{"spouse", k.Spouse},
+	}
+
+	for _, kind := range kinds {
+		for _, n := range kind.names {
+			if strings.EqualFold(strings.TrimSpace(name), n) {
+				return kind.kind
+			}
+		}
+	}
+
+	return ""
+}
+
+// Person is a character in a Genealogy.
+// XRef is the person's GEDCOM cross-reference identifier without its at
+// signs. CharacterID and EntityID are zero for people not in Kanka.
+type Person struct {
+	XRef        string
+	CharacterID CharacterID
+	EntityID    EntityID
+	Name        string
+	Surname     string
+	Sex         string
+	IsDead      bool
+	FamilyID    FamilyID
+
+	Parents  []*Person
+	Children []*Person
+	Spouses  []*Person
+}
+
+// Ancestors returns every ancestor of the Person, nearest first.
+func (p *Person) Ancestors() []*Person {
+	return p.walk(func(q *Person) []*Person { return q.Parents })
+}
+
+// Descendants returns every descendant of the Person, nearest first.
+func (p *Person) Descendants() []*Person {
+	return p.walk(func(q *Person) []*Person { return q.Children })
+}
+
+// walk returns the people reachable from the Person using next in
+// breadth-first order.
+func (p *Person) walk(next func(*Person) []*Person) []*Person {
+	seen := map[*Person]bool{p: true}
+	var found []*Person
+
+	for queue := append([]*Person(nil), next(p)...); len(queue) > 0; queue = queue[1:] {
+		q := queue[0]
+		if seen[q] {
+			continue
+		}
+		seen[q] = true
+		found = append(found, q)
+		queue = append(queue, next(q)...)
+	}
+
+	return found
+}
+
+// Genealogy is a set of people linked by kinship.
+type Genealogy struct {
+	People   []*Person
+	Families []*Family
+}
+
+// Genealogy returns the Genealogy of every character in the Campaign
+// associated with campID. Kinship is read from the relations between
+// characters using the provided names, or DefaultKinshipNames if names is
+// nil.
+func (c *Client) Genealogy(campID CampaignID, names *KinshipNames) (*Genealogy, error) {
+	var err error
+	b := &Backup{}
+
+	if b.Characters, err = c.Characters.Index(int(campID), nil); err != nil {
+		return nil, fmt.Errorf("cannot get genealogy of Campaign (ID: %d): %w", campID, err)
+	}
+	if b.Families, err = c.Families.Index(int(campID), nil); err != nil {
+		return nil, fmt.Errorf("cannot get genealogy of Campaign (ID: %d): %w", campID, err)
+	}
+
+	for _, ch := range b.Characters {
+		rels, err := c.Relations.Index(int(campID), int(ch.EntityID), nil)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get genealogy of Campaign (ID: %d): %w", campID, err)
+		}
+		b.Entities = append(b.Entities, &EntityData{EntityID: ch.EntityID, Type: TypeCharacter, ID: ObjectID(ch.ID), Relations: rels})
+	}
+
+	return b.Genealogy(names), nil
+}
+
+// Genealogy returns the Genealogy of every character in the Backup.
+// Kinship is read from the relations between characters using the provided
+// names, or DefaultKinshipNames if names is nil.
+func (b *Backup) Genealogy(names *KinshipNames) *Genealogy {
+	if names == nil {
+		names = DefaultKinshipNames()
+	}
+
+	surnames := make(map[FamilyID]string)
+	members := make(map[CharacterID]FamilyID)
+	for _, f := range b.Families {
+		surnames[f.ID] = f.Name
+		for _, m := range f.Members {
+			members[m] = f.ID
+		}
+	}
+
+	g := &Genealogy{Families: b.Families}
+	byEntity := make(map[int]*Person)
+	for _, ch := range b.Characters {
+		p := &Person{
+			XRef:        "I" + strconv.Itoa(int(ch.ID)),
+			CharacterID: ch.ID,
+			EntityID:    ch.EntityID,
+			Name:        ch.Name,
+			Sex:         ch.Sex,
+			IsDead:      ch.IsDead,
+			FamilyID:    ch.FamilyID,
+		}
+		if p.FamilyID == 0 {
+			p.FamilyID = members[ch.ID]
+		}
+		p.Surname = surnames[p.FamilyID]
+
+		g.People = append(g.People, p)
+		byEntity[int(ch.EntityID)] = p
+	}
+
+	for _, ent := range b.Entities {
+		owner := byEntity[int(ent.EntityID)]
+		for _, rel := range ent.Relations {
+			target := byEntity[int(rel.TargetID)]
+			if owner == nil || target == nil || owner == target {
+				continue
+			}
+
+			switch names.kinship(rel.Relation) {
+			case "parent":
+				link(&owner.Parents, target)
+				link(&target.Children, owner)
+			case "child":
+				link(&owner.Children, target)
+				link(&target.Parents, owner)
+			case "spouse":
+				link(&owner.Spouses, target)
+				link(&target.Spouses, owner)
+			}
+		}
+	}
+
+	return g
+}
+
+// link appends p to people unless it is already present.
+func link(people *[]*Person, p *Person) {
+	for _, q := range *people {
+		if q == p {
+			return
+		}
+	}
+	*people = append(*people, p)
+}
+
+// Person returns the Person of the character associated with charID, if
+// any.
+func (g *Genealogy) Person(charID CharacterID) *Person {
+	for _, p := range g.People {
+		if p.CharacterID == charID {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// Family returns a Genealogy of the members of the family associated with
+// famID and of its descendant families. Kinship with people outside of the
+// families is dropped.
+func (g *Genealogy) Family(famID FamilyID) *Genealogy {
+	fams := map[FamilyID]bool{famID: true}
+	for grown := true; grown; {
+		grown = false
+		for _, f := range g.Families {
+			if !fams[f.ID] && fams[f.FamilyID] {
+				fams[f.ID] = true
+				grown = true
+			}
+		}
+	}
+
+	sub := &Genealogy{}
+	for _, f := range g.Families {
+		if fams[f.ID] {
+			sub.Families = append(sub.Families, f)
+		}
+	}
+
+	copies := make(map[*Person]*Person)
+	for _, p := range g.People {
+		if fams[p.FamilyID] {
+			cp := *p
+			cp.Parents, cp.Children, cp.Spouses = nil, nil, nil
+			copies[p] = &cp
+			sub.People = append(sub.People, &cp)
+		}
+	}
+
+	relink := func(people []*Person) []*Person {
+		var kept []*Person
+		for _, q := range people {
+			if cp, ok := copies[q]; ok {
+				kept = append(kept, cp)
+			}
+		}
+		return kept
+	}
+	for p, cp := range copies {
+		cp.Parents = relink(p.Parents)
+		cp.Children = relink(p.Children)
+		cp.Spouses = relink(p.Spouses)
+	}
+
+	return sub
+}
+
+// union is a couple and their children, corresponding to a GEDCOM FAM
+// record.
+type union struct {
+	XRef     string
+	Partners []*Person
+	Children []*Person
+}
+
+// xrefs returns the GEDCOM identifier of every person in the Genealogy,
+// generating identifiers for people without one.
+func (g *Genealogy) xrefs() map[*Person]string {
+	ids := make(map[*Person]string)
+	used := make(map[string]bool)
+	for _, p := range g.People {
+		if p.XRef != "" && !used[p.XRef] {
+			ids[p] = p.XRef
+			used[p.XRef] = true
+		}
+	}
+
+	n := 0
+	for _, p := range g.People {
+		for ids[p] == "" {
+			n++
+			if id := "I" + strconv.Itoa(n); !used[id] {
+				ids[p] = id
+				used[id] = true
+			}
+		}
+	}
+
+	return ids
+}
+
+// unions returns the couples of the Genealogy and the children of each.
+// Children with more than two parents are linked to their first two.
+func (g *Genealogy) unions(ids map[*Person]string) []*union {
+	var unions []*union
+	byKey := make(map[string]*union)
+
+	get := func(partners []*Person) *union {
+		partners = append([]*Person(nil), partners...)
+		sort.Slice(partners, func(i, j int) bool { return ids[partners[i]] < ids[partners[j]] })
+		if len(partners) > 2 {
+			partners = partners[:2]
+		}
+
+		var key []string
+		for _, p := range partners {
+			key = append(key, ids[p])
+		}
+		k := strings.Join(key, ",")
+
+		u, ok := byKey[k]
+		if !ok {
+			u = &union{XRef: "F" + strconv.Itoa(len(unions)+1), Partners: partners}
+			byKey[k] = u
+			unions = append(unions, u)
+		}
+		return u
+	}
+
+	for _, p := range g.People {
+		for _, s := range p.Spouses {
+			if _, ok := ids[s]; ok {
+				get([]*Person{p, s})
+			}
+		}
+	}
+	for _, p := range g.People {
+		var parents []*Person
+		for _, q := range p.Parents {
+			if _, ok := ids[q]; ok {
+				parents = append(parents, q)
+			}
+		}
+		if len(parents) > 0 {
+			u := get(parents)
+			u.Children = append(u.Children, p)
+		}
+	}
+
+	return unions
+}
+
+// gedcomSex returns the GEDCOM sex of the provided Kanka sex.
+func gedcomSex(sex string) string {
+	switch strings.ToLower(strings.TrimSpace(sex)) {
+	case "m", "male", "man", "boy":
+		return "M"
+	case "f", "female", "woman", "girl":
+		return "F"
+	default:
+		return "U"
+	}
+}
+
+// roles returns the partners of the union as husband and wife, according
+// to their sex where known.
+func (u *union) roles() (husb *Person, wife *Person) {
+	switch len(u.Partners) {
+	case 1:
+		if gedcomSex(u.Partners[0].Sex) == "F" {
+			return nil, u.Partners[0]
+		}
+		return u.Partners[0], nil
+	case 2:
+		a, b := u.Partners[0], u.Partners[1]
+		if gedcomSex(a.Sex) == "F" || gedcomSex(b.Sex) == "M" {
+			a, b = b, a
+		}
+		return a, b
+	}
+
+	return nil, nil
+}
+
+// gedcomName returns the GEDCOM NAME value of the Person, with the surname
+// between slashes when the name ends with it.
+func (p *Person) gedcomName() string {
+	if p.Surname != "" && strings.HasSuffix(p.Name, " "+p.Surname) {
+		return strings.TrimSuffix(p.Name, p.Surname) + "/" + p.Surname + "/"
+	}
+
+	return p.Name
+}
+
+// WriteGEDCOM writes the Genealogy to w as a GEDCOM 5.5.1 file. The file is
+// submitted by a SUBM record named kanka, as the standard requires.
+func (g *Genealogy) WriteGEDCOM(w io.Writer) error {
+	ids := g.xrefs()
+	unions := g.unions(ids)
+
+	famc := make(map[*Person][]string)
+	fams := make(map[*Person][]string)
+	for _, u := range unions {
+		for _, p := range u.Partners {
+			fams[p] = append(fams[p], u.XRef)
+		}
+		for _, p := range u.Children {
+			famc[p] = append(famc[p], u.XRef)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("0 HEAD\n1 SOUR kanka\n1 SUBM @SUBM@\n1 GEDC\n2 VERS 5.5.1\n2 FORM LINEAGE-LINKED\n1 CHAR UTF-8\n")
+	b.WriteString("0 @SUBM@ SUBM\n1 NAME kanka\n")
+
+	for _, p := range g.People {
+		fmt.Fprintf(&b, "0 @%s@ INDI\n", ids[p])
+		fmt.Fprintf(&b, "1 NAME %s\n", gedcomLine(p.gedcomName()))
+		if p.Surname != "" {
+			fmt.Fprintf(&b, "2 SURN %s\n", gedcomLine(p.Surname))
+		}
+		fmt.Fprintf(&b, "1 SEX %s\n", gedcomSex(p.Sex))
+		if p.IsDead {
+			b.WriteString("1 DEAT Y\n")
+		}
+		for _, f := range famc[p] {
+			fmt.Fprintf(&b, "1 FAMC @%s@\n", f)
+		}
+		for _, f := range fams[p] {
+			fmt.Fprintf(&b, "1 FAMS @%s@\n", f)
+		}
+	}
+
+	for _, u := range unions {
+		fmt.Fprintf(&b, "0 @%s@ FAM\n", u.XRef)
+		husb, wife := u.roles()
+		if husb != nil {
+			fmt.Fprintf(&b, "1 HUSB @%s@\n", ids[husb])
+		}
+		if wife != nil {
+			fmt.Fprintf(&b, "1 WIFE @%s@\n", ids[wife])
+		}
+		for _, c := range u.Children {
+			fmt.Fprintf(&b, "1 CHIL @%s@\n", ids[c])
+		}
+	}
+
+	b.WriteString("0 TRLR\n")
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("cannot write GEDCOM file: %w", err)
+	}
+
+	return nil
+}
+
+// gedcomLine replaces the line breaks and at signs of s which would break a
+// GEDCOM line.
+func gedcomLine(s string) string {
+	s = strings.NewReplacer("\r", " ", "\n", " ", "@", "@@").Replace(s)
+	return strings.TrimSpace(s)
+}
+
+// WriteDOT writes the Genealogy to w in the Graphviz DOT language.
+// Couples are joined by a point from which edges lead to their children.
+func (g *Genealogy) WriteDOT(w io.Writer) error {
+	ids := g.xrefs()
+
+	var b strings.Builder
+	b.WriteString("digraph genealogy {\n")
+	b.WriteString("\trankdir=TB;\n")
+	b.WriteString("\tnode [shape=box];\n")
+
+	for _, p := range g.People {
+		fmt.Fprintf(&b, "\t%s [label=\"%s\"", ids[p], dotEscaper.Replace(p.Name))
+		if p.IsDead {
+			b.WriteString(", style=dashed")
+		}
+		b.WriteString("];\n")
+	}
+
+	for _, u := range g.unions(ids) {
+		fmt.Fprintf(&b, "\t%s [shape=point];\n", u.XRef)
+		for _, p := range u.Partners {
+			fmt.Fprintf(&b, "\t%s -> %s [dir=none];\n", ids[p], u.XRef)
+		}
+		for _, c := range u.Children {
+			fmt.Fprintf(&b, "\t%s -> %s;\n", u.XRef, ids[c])
+		}
+	}
+
+	b.WriteString("}\n")
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("cannot write DOT graph: %w", err)
+	}
+
+	return nil
+}
+
+// gedcomRecord is a top-level GEDCOM record and its lines.
+type gedcomRecord struct {
+	XRef  string
+	Tag   string
+	Lines []gedcomRecordLine
+}
+
+type gedcomRecordLine struct {
+	Level int
+	Tag   string
+	Value string
+}
+
+// ReadGEDCOM reads a Genealogy from the provided GEDCOM file.
+// Individuals, names, sexes, deaths, and the couples and children of family
+// records are read. Every other record is ignored.
+func ReadGEDCOM(r io.Reader) (*Genealogy, error) {
+	var recs []*gedcomRecord
+
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(strings.TrimPrefix(sc.Text(), "\ufeff"))
+		if line == "" {
+			continue
+		}
+
+		fields := strings.SplitN(line, " ", 3)
+		level, err := strconv.Atoi(fields[0])
+		if err != nil || len(fields) < 2 {
+			return nil, fmt.Errorf("cannot read GEDCOM line %d: invalid line '%s'", n, line)
+		}
+
+		if level == 0 {
+			rec := &gedcomRecord{Tag: fields[1]}
+			if strings.HasPrefix(fields[1], "@") && len(fields) == 3 {
+				rec.XRef = strings.Trim(fields[1], "@")
+				rec.Tag = strings.Fields(fields[2])[0]
+			}
+			recs = append(recs, rec)
+			continue
+		}
+
+		if len(recs) == 0 {
+			return nil, fmt.Errorf("cannot read GEDCOM line %d: missing record", n)
+		}
+
+		rl := gedcomRecordLine{Level: level, Tag: fields[1]}
+		if len(fields) == 3 {
+			rl.Value = strings.ReplaceAll(fields[2], "@@", "@")
+		}
+		rec := recs[len(recs)-1]
+		rec.Lines = append(rec.Lines, rl)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read GEDCOM file: %w", err)
+	}
+
+	g := &Genealogy{}
+	people := make(map[string]*Person)
+	for _, rec := range recs {
+		if rec.Tag != "INDI" {
+			continue
+		}
+
+		p := &Person{XRef: rec.XRef}
+		for i, l := range rec.Lines {
+			switch {
+			case l.Level == 1 && l.Tag == "NAME" && p.Name == "":
+				p.Name, p.Surname = parseGEDCOMName(l.Value)
+				for _, sub := range rec.Lines[i+1:] {
+					if sub.Level <= 1 {
+						break
+					}
+					if sub.Level == 2 && sub.Tag == "SURN" {
+						p.Surname = sub.Value
+					}
+				}
+			case l.Level == 1 && l.Tag == "SEX":
+				switch l.Value {
+				case "M":
+					p.Sex = "Male"
+				case "F":
+					p.Sex = "Female"
+				}
+			case l.Level == 1 && l.Tag == "DEAT":
+				p.IsDead = true
+			}
+		}
+
+		g.People = append(g.People, p)
+		people[p.XRef] = p
+	}
+
+	for _, rec := range recs {
+		if rec.Tag != "FAM" {
+			continue
+		}
+
+		var partners, children []*Person
+		for _, l := range rec.Lines {
+			p, ok := people[strings.Trim(l.Value, "@")]
+			if l.Level != 1 || !ok {
+				continue
+			}
+			switch l.Tag {
+			case "HUSB", "WIFE":
+				partners = append(partners, p)
+			case "CHIL":
+				children = append(children, p)
+			}
+		}
+
+		for _, a := range partners {
+			for _, b := range partners {
+				if a != b {
+					link(&a.Spouses, b)
+				}
+			}
+			for _, c := range children {
+				link(&a.Children, c)
+				link(&c.Parents, a)
+			}
+		}
+	}
+
+	return g, nil
+}
+
+// parseGEDCOMName returns the full name and surname of a GEDCOM NAME value
+// such as "Arya /Stark/".
+func parseGEDCOMName(v string) (name string, surname string) {
+	if i := strings.Index(v, "/"); i >= 0 {
+		if j := strings.Index(v[i+1:], "/"); j >= 0 {
+			surname = strings.TrimSpace(v[i+1 : i+1+j])
+		}
+	}
+
+	return strings.Join(strings.Fields(strings.ReplaceAll(v, "/", " ")), " "), surname
+}
+
+// ImportGenealogy creates the people of the provided Genealogy as
+// characters in the Campaign associated with campID. People are grouped
+// into families by surname, reusing existing families of the same name.
+// Kinship is created as relations using the first of the provided names,
+// or of DefaultKinshipNames if names is nil. The CharacterID, EntityID, and
+// FamilyID of every imported Person are updated.
+func (c *Client) ImportGenealogy(campID CampaignID, g *Genealogy, names *KinshipNames) error {
+	if names == nil {
+		names = DefaultKinshipNames()
+	}
+	if len(names.Parent) == 0 || len(names.Child) == 0 || len(names.Spouse) == 0 {
+		return fmt.Errorf("cannot import genealogy to Campaign (ID: %d): missing kinship names", campID)
+	}
+
+	fams, err := c.Families.Index(int(campID), nil)
+	if err != nil {
+		return fmt.Errorf("cannot import genealogy to Campaign (ID: %d): %w", campID, err)
+	}
+
+	famIDs := make(map[string]FamilyID)
+	for _, f := range fams {
+		if _, ok := famIDs[f.Name]; !ok {
+			famIDs[f.Name] = f.ID
+		}
+	}
+
+	for _, p := range g.People {
+		if p.Surname != "" && famIDs[p.Surname] == 0 {
+			f, err := c.Families.Create(int(campID), SimpleFamily{Name: p.Surname})
+			if err != nil {
+				return fmt.Errorf("cannot import genealogy to Campaign (ID: %d): %w", campID, err)
+			}
+			famIDs[p.Surname] = f.ID
+		}
+		p.FamilyID = famIDs[p.Surname]
+
+		ch, err := c.Characters.Create(int(campID), SimpleCharacter{
+			Name:     p.Name,
+			Sex:      p.Sex,
+			IsDead:   p.IsDead,
+			FamilyID: p.FamilyID,
+		})
+		if err != nil {
+			return fmt.Errorf("cannot import genealogy to Campaign (ID: %d): %w", campID, err)
+		}
+		p.CharacterID = ch.ID
+		p.EntityID = ch.EntityID
+	}
+
+	for _, p := range g.People {
+		kin := []struct {
+			name   string
+			people []*Person
+		}{
+			{names.Parent[0], p.Parents},
+			{names.Child[0], p.Children},
+			{names.Spouse[0], p.Spouses},
+		}
+
+		for _, k := range kin {
+			for _, q := range k.people {
+				if q.EntityID == 0 {
+					continue
+				}
+				_, err := c.Relations.Create(int(campID), int(p.EntityID), SimpleRelation{Relation: k.name, OwnerID: p.EntityID, TargetID: q.EntityID})
+				if err != nil {
+					return fmt.Errorf("cannot import genealogy to Campaign (ID: %d): %w", campID, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
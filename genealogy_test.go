@@ -0,0 +1,252 @@
+package kanka
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// personNames returns the names of the provided people.
+func personNames(people []*Person) []string {
+	var ns []string
+	for _, p := range people {
+		ns = append(ns, p.Name)
+	}
+	return ns
+}
+
+func TestBackup_Genealogy(t *testing.T) {
+	g := testBackup(t).Genealogy(nil)
+
+	arya := g.Person(30)
+	if diff := cmp.Diff([]string{"Eddard Stark", "Catelyn Stark"}, personNames(arya.Parents)); diff != "" {
+		t.Errorf("parents mismatch (-want +got):\n%s", diff)
+	}
+
+	ned := g.Person(34)
+	if diff := cmp.Diff([]string{"Arya Stark", "Robb Stark"}, personNames(ned.Descendants())); diff != "" {
+		t.Errorf("descendants mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"Catelyn Stark"}, personNames(ned.Spouses)); diff != "" {
+		t.Errorf("spouses mismatch (-want +got):\n%s", diff)
+	}
+
+	if got := g.Person(35).Surname; got != "Tully" {
+		t.Errorf("got surname <%s> from family members, want <%s>", got, "Tully")
+	}
+	if got := g.Person(37); len(got.Parents)+len(got.Children)+len(got.Spouses) != 0 {
+		t.Errorf("got kinship for <%s>, want none", got.Name)
+	}
+}
+
+func TestGenealogy_Family(t *testing.T) {
+	g := testBackup(t).Genealogy(nil).Family(20)
+
+	if diff := cmp.Diff([]string{"Arya Stark", "Bran Stark", "Eddard Stark", "Robb Stark"}, personNames(g.People)); diff != "" {
+		t.Errorf("people mismatch (-want +got):\n%s", diff)
+	}
+	if got := g.Person(30).Parents; len(got) != 1 || got[0] != g.Person(34) {
+		t.Errorf("got parents <%v>, want only Eddard Stark", personNames(got))
+	}
+}
+
+func TestGenealogy_WriteGEDCOM(t *testing.T) {
+	var b bytes.Buffer
+	if err := testBackup(t).Genealogy(nil).WriteGEDCOM(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.Join([]string{
+		"0 HEAD",
+		"1 SOUR kanka",
+		"1 SUBM @SUBM@",
+		"1 GEDC",
+		"2 VERS 5.5.1",
+		"2 FORM LINEAGE-LINKED",
+		"1 CHAR UTF-8",
+		"0 @SUBM@ SUBM",
+		"1 NAME kanka",
+		"0 @I30@ INDI",
+		"1 NAME Arya Stark",
+		"2 SURN Stark of Winterfell",
+		"1 SEX F",
+		"1 FAMC @F1@",
+		"0 @I31@ INDI",
+		"1 NAME Jon Snow",
+		"1 SEX M",
+		"0 @I32@ INDI",
+		"1 NAME Cersei Lannister",
+		"1 SEX F",
+		"0 @I33@ INDI",
+		"1 NAME Bran Stark",
+		"2 SURN Stark of Winterfell",
+		"1 SEX M",
+		"0 @I34@ INDI",
+		"1 NAME Eddard /Stark/",
+		"2 SURN Stark",
+		"1 SEX M",
+		"1 DEAT Y",
+		"1 FAMS @F1@",
+		"1 FAMS @F2@",
+		"0 @I35@ INDI",
+		"1 NAME Catelyn Stark",
+		"2 SURN Tully",
+		"1 SEX F",
+		"1 FAMS @F1@",
+		"0 @I36@ INDI",
+		"1 NAME Robb Stark",
+		"2 SURN Stark of Winterfell",
+		"1 SEX M",
+		"1 FAMC @F2@",
+		"0 @I37@ INDI",
+		"1 NAME Hodor",
+		"1 SEX U",
+		"0 @I38@ INDI",
+		"1 NAME Jaime Lannister",
+		"1 SEX M",
+		"0 @F1@ FAM",
+		"1 HUSB @I34@",
+		"1 WIFE @I35@",
+		"1 CHIL @I30@",
+		"0 @F2@ FAM",
+		"1 HUSB @I34@",
+		"1 CHIL @I36@",
+		"0 TRLR",
+		"",
+	}, "\n")
+
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadGEDCOM(t *testing.T) {
+	var b bytes.Buffer
+	if err := testBackup(t).Genealogy(nil).WriteGEDCOM(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := ReadGEDCOM(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff([]string{"Arya Stark", "Jon Snow", "Cersei Lannister", "Bran Stark", "Eddard Stark", "Catelyn Stark", "Robb Stark", "Hodor", "Jaime Lannister"}, personNames(g.People)); diff != "" {
+		t.Fatalf("people mismatch (-want +got):\n%s", diff)
+	}
+
+	arya, ned, cat := g.People[0], g.People[4], g.People[5]
+	if ned.Surname != "Stark" || ned.Sex != "Male" || !ned.IsDead || ned.XRef != "I34" {
+		t.Errorf("got <%+v>, want dead male Stark I34", *ned)
+	}
+	if cat.Surname != "Tully" || cat.Sex != "Female" {
+		t.Errorf("got surname <%s> and sex <%s>, want <%s> and <%s>", cat.Surname, cat.Sex, "Tully", "Female")
+	}
+	if diff := cmp.Diff([]string{"Eddard Stark", "Catelyn Stark"}, personNames(arya.Parents)); diff != "" {
+		t.Errorf("parents mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"Catelyn Stark"}, personNames(ned.Spouses)); diff != "" {
+		t.Errorf("spouses mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := ReadGEDCOM(strings.NewReader("0 HEAD\nnot a line\n")); err == nil {
+		t.Error("got nil error for invalid line, want error")
+	}
+}
+
+func TestGenealogy_WriteDOT(t *testing.T) {
+	var b bytes.Buffer
+	if err := testBackup(t).Genealogy(nil).WriteDOT(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"\tI34 [label=\"Eddard Stark\", style=dashed];\n",
+		"\tF1 [shape=point];\n",
+		"\tI35 -> F1 [dir=none];\n",
+		"\tF1 -> I30;\n",
+		"\tF2 -> I36;\n",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("got DOT:\n%s\nwant it to contain <%s>", b.String(), want)
+		}
+	}
+}
+
+func TestClient_Genealogy(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+	fk.seed(t, "/campaigns/1/entities/131/relations", &Relation{ID: 87, SimpleRelation: SimpleRelation{Relation: "Father", OwnerID: 131, TargetID: 130}})
+
+	g, err := c.Genealogy(1, &KinshipNames{Parent: []string{"father"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jon := g.Person(31)
+	if diff := cmp.Diff([]string{"Arya Stark"}, personNames(jon.Parents)); diff != "" {
+		t.Errorf("parents mismatch (-want +got):\n%s", diff)
+	}
+	if jon.Surname != "Stark" {
+		t.Errorf("got surname <%s>, want <%s>", jon.Surname, "Stark")
+	}
+}
+
+func TestClient_ImportGenealogy(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+
+	gedcom := strings.Join([]string{
+		"0 HEAD",
+		"0 @P1@ INDI",
+		"1 NAME Hoster /Tully/",
+		"1 SEX M",
+		"0 @P2@ INDI",
+		"1 NAME Edmure /Tully/",
+		"0 @P3@ INDI",
+		"1 NAME Benjen /Stark/",
+		"0 @FAM1@ FAM",
+		"1 HUSB @P1@",
+		"1 CHIL @P2@",
+		"0 TRLR",
+	}, "\n")
+
+	g, err := ReadGEDCOM(strings.NewReader(gedcom))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = c.ImportGenealogy(1, g, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := len(fk.list("/campaigns/1/families")); got != 2 {
+		t.Errorf("got <%d> Families, want <%d> with Stark reused", got, 2)
+	}
+	if got := g.People[2].FamilyID; got != 20 {
+		t.Errorf("got family <%d>, want existing Stark family <%d>", got, 20)
+	}
+
+	hoster, edmure := g.People[0], g.People[1]
+	obj := fk.object("/campaigns/1/characters", int(edmure.CharacterID))
+	if obj["name"] != "Edmure Tully" || FamilyID(intField(obj, "family_id")) != hoster.FamilyID {
+		t.Errorf("got character <%v>, want Edmure Tully in family <%d>", obj, hoster.FamilyID)
+	}
+
+	rels := fk.list(fmt.Sprintf("/campaigns/1/entities/%d/relations", edmure.EntityID))
+	if len(rels) != 1 || rels[0]["relation"] != "Parent" || EntityID(intField(rels[0], "target_id")) != hoster.EntityID {
+		t.Errorf("got relations <%v>, want one Parent relation to <%d>", rels, hoster.EntityID)
+	}
+
+	rels = fk.list(fmt.Sprintf("/campaigns/1/entities/%d/relations", hoster.EntityID))
+	if len(rels) != 1 || rels[0]["relation"] != "Child" {
+		t.Errorf("got relations <%v>, want one Child relation", rels)
+	}
+}
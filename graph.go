@@ -0,0 +1,511 @@
+package kanka
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GraphNode is an entity in a RelationGraph.
+type GraphNode struct {
+	EntityID  EntityID
+	Type      EntityType
+	ID        ObjectID
+	Name      string
+	IsPrivate bool
+}
+
+// GraphEdge is a relation from one entity to another in a RelationGraph.
+type GraphEdge struct {
+	RelationID int
+	From       EntityID
+	To         EntityID
+	Relation   string
+	Attitude   int
+	TwoWay     bool
+	IsPrivate  bool
+}
+
+// RelationGraph is a directed graph of the relations between the entities
+// of a campaign, keyed by entity ID.
+type RelationGraph struct {
+	nodes map[EntityID]*GraphNode
+	out   map[EntityID][]*GraphEdge
+	in    map[EntityID][]*GraphEdge
+}
+
+// NewRelationGraph returns an empty RelationGraph.
+func NewRelationGraph() *RelationGraph {
+	return &RelationGraph{
+		nodes: make(map[EntityID]*GraphNode),
+		out:   make(map[EntityID][]*GraphEdge),
+		in:    make(map[EntityID][]*GraphEdge),
+	}
+}
+
+// RelationGraph returns the RelationGraph of every relation between the
+// entities of the Campaign associated with campID.
+func (c *Client) RelationGraph(campID CampaignID) (*RelationGraph, error) {
+	b := &Backup{}
+	if err := c.backupObjects(int(campID), b); err != nil {
+		return nil, fmt.Errorf("cannot get relation graph of Campaign (ID: %d): %w", campID, err)
+	}
+
+	g := NewRelationGraph()
+	for _, ref := range b.refs() {
+		g.AddNode(ref.node())
+	}
+
+	for _, ref := range b.refs() {
+		rels, err := c.Relations.Index(int(campID), ref.EntityID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get relation graph of Campaign (ID: %d): %w", campID, err)
+		}
+
+		for _, rel := range rels {
+			g.AddEdge(rel.edge(EntityID(ref.EntityID)))
+		}
+	}
+
+	return g, nil
+}
+
+// RelationGraph returns the RelationGraph of every relation in the Backup.
+func (b *Backup) RelationGraph() *RelationGraph {
+	g := NewRelationGraph()
+	for _, ref := range b.refs() {
+		g.AddNode(ref.node())
+	}
+
+	for _, ent := range b.Entities {
+		for _, rel := range ent.Relations {
+			g.AddEdge(rel.edge(ent.EntityID))
+		}
+	}
+
+	return g
+}
+
+// node returns the GraphNode of the referenced entity.
+func (ref entityRef) node() *GraphNode {
+	return &GraphNode{
+		EntityID:  EntityID(ref.EntityID),
+		Type:      ref.Type,
+		ID:        ObjectID(ref.ID),
+		Name:      ref.Name,
+		IsPrivate: ref.IsPrivate,
+	}
+}
+
+// edge returns the GraphEdge of the Relation owned by the entity associated
+// with owner.
+func (r *Relation) edge(owner EntityID) *GraphEdge {
+	if r.OwnerID != 0 {
+		owner = r.OwnerID
+	}
+
+	return &GraphEdge{
+		RelationID: r.ID,
+		From:       owner,
+		To:         r.TargetID,
+		Relation:   r.Relation,
+		Attitude:   r.Attitude,
+		TwoWay:     r.TwoWay,
+		IsPrivate:  r.IsPrivate,
+	}
+}
+
+// AddNode adds the provided node to the RelationGraph, replacing any node
+// with the same entity ID.
+func (g *RelationGraph) AddNode(n *GraphNode) {
+	g.nodes[n.EntityID] = n
+}
+
+// AddEdge adds the provided edge to the RelationGraph. Entities without a
+// node are added with only their entity ID.
+func (g *RelationGraph) AddEdge(e *GraphEdge) {
+	for _, id := range []EntityID{e.From, e.To} {
+		if _, ok := g.nodes[id]; !ok {
+			g.nodes[id] = &GraphNode{EntityID: id}
+		}
+	}
+
+	g.out[e.From] = append(g.out[e.From], e)
+	g.in[e.To] = append(g.in[e.To], e)
+}
+
+// Node returns the node of the entity associated with entID, if any.
+func (g *RelationGraph) Node(entID EntityID) (*GraphNode, bool) {
+	n, ok := g.nodes[entID]
+	return n, ok
+}
+
+// Nodes returns every node of the RelationGraph ordered by entity ID.
+func (g *RelationGraph) Nodes() []*GraphNode {
+	nodes := make([]*GraphNode, 0, len(g.nodes))
+	for _, n := range g.nodes {
+		nodes = append(nodes, n)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].EntityID < nodes[j].EntityID })
+
+	return nodes
+}
+
+// Edges returns every edge of the RelationGraph ordered by source and
+// target.
+func (g *RelationGraph) Edges() []*GraphEdge {
+	var edges []*GraphEdge
+	for _, n := range g.Nodes() {
+		edges = append(edges, g.EdgesFrom(n.EntityID)...)
+	}
+
+	return edges
+}
+
+// EdgesFrom returns the edges from the entity associated with entID ordered
+// by target.
+func (g *RelationGraph) EdgesFrom(entID EntityID) []*GraphEdge {
+	edges := append([]*GraphEdge(nil), g.out[entID]...)
+	sort.SliceStable(edges, func(i, j int) bool { return edges[i].To < edges[j].To })
+
+	return edges
+}
+
+// EdgesTo returns the edges to the entity associated with entID ordered by
+// source.
+func (g *RelationGraph) EdgesTo(entID EntityID) []*GraphEdge {
+	edges := append([]*GraphEdge(nil), g.in[entID]...)
+	sort.SliceStable(edges, func(i, j int) bool { return edges[i].From < edges[j].From })
+
+	return edges
+}
+
+// Neighbors returns the nodes related to the entity associated with entID
+// in either direction, ordered by entity ID.
+func (g *RelationGraph) Neighbors(entID EntityID) []*GraphNode {
+	seen := make(map[EntityID]bool)
+	var nodes []*GraphNode
+
+	add := func(id EntityID) {
+		if id == entID || seen[id] {
+			return
+		}
+		seen[id] = true
+		nodes = append(nodes, g.nodes[id])
+	}
+	for _, e := range g.out[entID] {
+		add(e.To)
+	}
+	for _, e := range g.in[entID] {
+		add(e.From)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].EntityID < nodes[j].EntityID })
+
+	return nodes
+}
+
+// ShortestPath returns the entity IDs along the shortest path from the
+// entity associated with from to the entity associated with to, including
+// both ends. If directed is true, relations are only followed from owner to
+// target unless they are two-way. ShortestPath returns nil if there is no
+// path.
+func (g *RelationGraph) ShortestPath(from EntityID, to EntityID, directed bool) []EntityID {
+	if _, ok := g.nodes[from]; !ok {
+		return nil
+	}
+	if from == to {
+		return []EntityID{from}
+	}
+
+	prev := map[EntityID]EntityID{from: from}
+	queue := []EntityID{from}
+
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		for _, next := range g.adjacent(cur, directed) {
+			if _, ok := prev[next]; ok {
+				continue
+			}
+			prev[next] = cur
+
+			if next == to {
+				path := []EntityID{to}
+				for id := to; id != from; {
+					id = prev[id]
+					path = append([]EntityID{id}, path...)
+				}
+				return path
+			}
+			queue = append(queue, next)
+		}
+	}
+
+	return nil
+}
+
+// adjacent returns the entity IDs reachable from the entity associated with
+// entID by a single relation, ordered by entity ID.
+func (g *RelationGraph) adjacent(entID EntityID, directed bool) []EntityID {
+	var ids []EntityID
+	for _, e := range g.out[entID] {
+		ids = append(ids, e.To)
+	}
+	for _, e := range g.in[entID] {
+		if !directed || e.TwoWay {
+			ids = append(ids, e.From)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
+
+// Clusters returns the groups of entities connected by relations with an
+// attitude of at least minAttitude, regardless of direction. Each cluster is
+// ordered by entity ID and clusters are ordered by their first entity ID.
+// Entities without such relations are omitted.
+func (g *RelationGraph) Clusters(minAttitude int) [][]*GraphNode {
+	parent := make(map[EntityID]EntityID)
+
+	var find func(id EntityID) EntityID
+	find = func(id EntityID) EntityID {
+		if parent[id] == id {
+			return id
+		}
+		parent[id] = find(parent[id])
+		return parent[id]
+	}
+
+	for _, e := range g.Edges() {
+		if e.Attitude < minAttitude || e.From == e.To {
+			continue
+		}
+		for _, id := range []EntityID{e.From, e.To} {
+			if _, ok := parent[id]; !ok {
+				parent[id] = id
+			}
+		}
+
+		a, b := find(e.From), find(e.To)
+		if a > b {
+			a, b = b, a
+		}
+		parent[b] = a
+	}
+
+	groups := make(map[EntityID][]*GraphNode)
+	var roots []EntityID
+	for _, n := range g.Nodes() {
+		if _, ok := parent[n.EntityID]; !ok {
+			continue
+		}
+		root := find(n.EntityID)
+		if groups[root] == nil {
+			roots = append(roots, root)
+		}
+		groups[root] = append(groups[root], n)
+	}
+
+	clusters := make([][]*GraphNode, 0, len(roots))
+	for _, root := range roots {
+		clusters = append(clusters, groups[root])
+	}
+
+	return clusters
+}
+
+// AttitudeColor returns the hexadecimal RGB color of the provided attitude,
+// ranging from red for hostile relations through grey for neutral relations
+// to green for friendly relations.
+func AttitudeColor(attitude int) string {
+	if attitude < attitudeMin {
+		attitude = attitudeMin
+	}
+	if attitude > attitudeMax {
+		attitude = attitudeMax
+	}
+
+	neutral := [3]int{0x80, 0x80, 0x80}
+	end := [3]int{0x2c, 0xa0, 0x2c}
+	t := float64(attitude) / float64(attitudeMax)
+	if attitude < 0 {
+		end = [3]int{0xd6, 0x27, 0x28}
+		t = float64(attitude) / float64(attitudeMin)
+	}
+
+	var rgb [3]int
+	for i := range rgb {
+		rgb[i] = neutral[i] + int(float64(end[i]-neutral[i])*t)
+	}
+
+	return fmt.Sprintf("#%02x%02x%02x", rgb[0], rgb[1], rgb[2])
+}
+
+// exportEdges returns the edges of the RelationGraph ordered by source and
+// target, with each mirrored pair of two-way edges collapsed into the first
+// edge of the pair. Kanka stores a two-way relation as one relation on each
+// entity, so exporting both would draw the relation twice.
+func (g *RelationGraph) exportEdges() []*GraphEdge {
+	type pair struct{ from, to EntityID }
+	mirrors := make(map[pair]int)
+
+	var edges []*GraphEdge
+	for _, e := range g.Edges() {
+		if e.TwoWay {
+			if mirrors[pair{e.To, e.From}] > 0 {
+				mirrors[pair{e.To, e.From}]--
+				continue
+			}
+			mirrors[pair{e.From, e.To}]++
+		}
+		edges = append(edges, e)
+	}
+
+	return edges
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// WriteDOT writes the RelationGraph to w in the Graphviz DOT language.
+// Edges are labeled with their relations and colored by attitude. A two-way
+// relation is drawn as a single edge.
+func (g *RelationGraph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+
+	b.WriteString("digraph kanka {\n")
+	b.WriteString("\tnode [shape=box];\n")
+
+	for _, n := range g.Nodes() {
+		label := n.Name
+		if label == "" {
+			label = strconv.Itoa(int(n.EntityID))
+		}
+		fmt.Fprintf(&b, "\t%d [label=\"%s\"", n.EntityID, dotEscaper.Replace(label))
+		if n.Type != "" {
+			fmt.Fprintf(&b, ", tooltip=\"%s\"", dotEscaper.Replace(string(n.Type)))
+		}
+		b.WriteString("];\n")
+	}
+
+	for _, e := range g.exportEdges() {
+		fmt.Fprintf(&b, "\t%d -> %d [label=\"%s\", color=\"%s\"", e.From, e.To, dotEscaper.Replace(e.Relation), AttitudeColor(e.Attitude))
+		if e.TwoWay {
+			b.WriteString(", dir=both")
+		}
+		if e.IsPrivate {
+			b.WriteString(", style=dashed")
+		}
+		b.WriteString("];\n")
+	}
+
+	b.WriteString("}\n")
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("cannot write DOT graph: %w", err)
+	}
+
+	return nil
+}
+
+// graphML is the root element of a GraphML document.
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	ID     string        `xml:"id,attr,omitempty"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WriteGraphML writes the RelationGraph to w as a GraphML document.
+// Nodes carry their names and types. Edges carry their relations,
+// attitudes, and attitude colors. A two-way relation is written as a single
+// edge.
+func (g *RelationGraph) WriteGraphML(w io.Writer) error {
+	doc := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "name", For: "node", Name: "name", Type: "string"},
+			{ID: "type", For: "node", Name: "type", Type: "string"},
+			{ID: "relation", For: "edge", Name: "relation", Type: "string"},
+			{ID: "attitude", For: "edge", Name: "attitude", Type: "int"},
+			{ID: "two_way", For: "edge", Name: "two_way", Type: "boolean"},
+			{ID: "color", For: "edge", Name: "color", Type: "string"},
+		},
+		Graph: graphMLGraph{ID: "kanka", EdgeDefault: "directed"},
+	}
+
+	for _, n := range g.Nodes() {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			ID: "n" + strconv.Itoa(int(n.EntityID)),
+			Data: []graphMLData{
+				{Key: "name", Value: n.Name},
+				{Key: "type", Value: string(n.Type)},
+			},
+		})
+	}
+
+	for _, e := range g.exportEdges() {
+		edge := graphMLEdge{
+			Source: "n" + strconv.Itoa(int(e.From)),
+			Target: "n" + strconv.Itoa(int(e.To)),
+			Data: []graphMLData{
+				{Key: "relation", Value: e.Relation},
+				{Key: "attitude", Value: strconv.Itoa(e.Attitude)},
+				{Key: "two_way", Value: strconv.FormatBool(e.TwoWay)},
+				{Key: "color", Value: AttitudeColor(e.Attitude)},
+			},
+		}
+		if e.RelationID != 0 {
+			edge.ID = "e" + strconv.Itoa(e.RelationID)
+		}
+		doc.Graph.Edges = append(doc.Graph.Edges, edge)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("cannot write GraphML graph: %w", err)
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("cannot write GraphML graph: %w", err)
+	}
+
+	return nil
+}
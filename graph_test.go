@@ -0,0 +1,196 @@
+package kanka
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// testGraph returns the RelationGraph of the test Backup.
+func testGraph(t *testing.T) *RelationGraph {
+	return testBackup(t).RelationGraph()
+}
+
+func TestRelationGraph_Neighbors(t *testing.T) {
+	g := testGraph(t)
+
+	var got []EntityID
+	for _, n := range g.Neighbors(134) {
+		got = append(got, n.EntityID)
+	}
+
+	if diff := cmp.Diff([]EntityID{130, 132, 135, 136}, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	if got := g.Neighbors(104); len(got) != 0 {
+		t.Errorf("got <%d> neighbors, want none", len(got))
+	}
+}
+
+func TestRelationGraph_ShortestPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     EntityID
+		to       EntityID
+		directed bool
+		want     []EntityID
+	}{
+		{"Same entity", 134, 134, true, []EntityID{134}},
+		{"Directed", 132, 136, true, []EntityID{132, 134, 136}},
+		{"Directed against relation", 138, 136, true, nil},
+		{"Undirected", 138, 136, false, []EntityID{138, 132, 134, 136}},
+		{"Two-way", 135, 134, true, []EntityID{135, 134}},
+		{"Unrelated", 134, 104, false, nil},
+		{"Missing entity", 999, 134, false, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := testGraph(t).ShortestPath(test.from, test.to, test.directed)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRelationGraph_Clusters(t *testing.T) {
+	tests := []struct {
+		name        string
+		minAttitude int
+		want        [][]EntityID
+	}{
+		{"Friendly", 50, [][]EntityID{{130, 134, 135, 136, 137, 199}, {132, 138}}},
+		{"Everything", -100, [][]EntityID{{130, 132, 134, 135, 136, 137, 138, 199}}},
+		{"Nothing", 101, [][]EntityID{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := [][]EntityID{}
+			for _, c := range testGraph(t).Clusters(test.minAttitude) {
+				var ids []EntityID
+				for _, n := range c {
+					ids = append(ids, n.EntityID)
+				}
+				got = append(got, ids)
+			}
+
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAttitudeColor(t *testing.T) {
+	tests := []struct {
+		attitude int
+		want     string
+	}{
+		{-100, "#d62728"},
+		{-200, "#d62728"},
+		{0, "#808080"},
+		{100, "#2ca02c"},
+		{50, "#569056"},
+	}
+	for _, test := range tests {
+		if got := AttitudeColor(test.attitude); got != test.want {
+			t.Errorf("got color <%s> for attitude <%d>, want <%s>", got, test.attitude, test.want)
+		}
+	}
+}
+
+func TestRelationGraph_WriteDOT(t *testing.T) {
+	var b bytes.Buffer
+	if err := testGraph(t).WriteDOT(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"digraph kanka {\n",
+		"\t104 [label=\"The \\\"Wall\\\"\", tooltip=\"location\"];\n",
+		"\t134 -> 135 [label=\"Wife\", color=\"#359c35\", dir=both];\n",
+		"\t132 -> 134 [label=\"Rival\", color=\"#c4393a\"];\n",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("got DOT:\n%s\nwant it to contain <%s>", b.String(), want)
+		}
+	}
+	if strings.Contains(b.String(), "Husband") {
+		t.Errorf("got DOT:\n%s\nwant mirrored two-way relation collapsed", b.String())
+	}
+}
+
+func TestRelationGraph_WriteGraphML(t *testing.T) {
+	var b bytes.Buffer
+	if err := testGraph(t).WriteGraphML(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	var doc graphML
+	if err := xml.Unmarshal(b.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(doc.Graph.Nodes) != 30 || len(doc.Graph.Edges) != 8 {
+		t.Fatalf("got <%d> nodes and <%d> edges, want <%d> and <%d>", len(doc.Graph.Nodes), len(doc.Graph.Edges), 30, 8)
+	}
+
+	want := graphMLEdge{
+		ID:     "e7",
+		Source: "n132",
+		Target: "n134",
+		Data: []graphMLData{
+			{Key: "relation", Value: "Rival"},
+			{Key: "attitude", Value: "-80"},
+			{Key: "two_way", Value: "false"},
+			{Key: "color", Value: "#c4393a"},
+		},
+	}
+	if diff := cmp.Diff(want, doc.Graph.Edges[4]); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestClient_RelationGraph(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+
+	g, err := c.RelationGraph(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*GraphEdge{
+		{RelationID: 85, From: 130, To: 131, Relation: "Sister", Attitude: 80},
+		{RelationID: 86, From: 131, To: 130, Relation: "Brother", Attitude: 90},
+	}
+	if diff := cmp.Diff(want, g.Edges()); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	if n, ok := g.Node(131); !ok || n.Name != "Jon Snow" || !n.IsPrivate {
+		t.Errorf("got node <%+v>, want private Jon Snow", n)
+	}
+
+	fk.fail["GET /campaigns/1/entities/130/relations"] = http.StatusInternalServerError
+	if _, err = c.RelationGraph(1); err == nil {
+		t.Error("got nil error, want error")
+	}
+}
+
+func TestBackup_RelationGraph(t *testing.T) {
+	g := testBackup(t).RelationGraph()
+	if got := g.ShortestPath(130, 199, true); !cmp.Equal(got, []EntityID{130, 199}) {
+		t.Errorf("got path <%v>, want <%v>", got, []EntityID{130, 199})
+	}
+	if n, _ := g.Node(199); n.Name != "" {
+		t.Errorf("got name <%s> for unknown entity, want none", n.Name)
+	}
+}
@@ -0,0 +1,1112 @@
+package kanka
+
+import (
+	"fmt"
+	"time"
+)
+
+// CampaignHandle provides the services of a Client bound to a single
+// Campaign, so that the Campaign's ID does not need to be repeated in every
+// call. CampaignHandle is returned by Client.Campaign.
+type CampaignHandle struct {
+	client *Client
+	id     CampaignID
+
+	// Services
+	Characters          *BoundCharacterService
+	Locations           *BoundLocationService
+	MapPoints           *BoundMapPointService
+	Families            *BoundFamilyService
+	Organizations       *BoundOrganizationService
+	OrganizationMembers *BoundOrganizationMemberService
+	Items               *BoundItemService
+	Notes               *BoundNoteService
+	Events              *BoundEventService
+	Races               *BoundRaceService
+	Quests              *BoundQuestService
+	QuestCharacters     *BoundQuestCharacterService
+	QuestLocations      *BoundQuestLocationService
+	QuestItems          *BoundQuestItemService
+	QuestOrganizations  *BoundQuestOrganizationService
+	Journals            *BoundJournalService
+	Tags                *BoundTagService
+	Calendars           *BoundCalendarService
+}
+
+// Campaign returns a CampaignHandle whose services are bound to the Campaign
+// associated with campID.
+func (c *Client) Campaign(campID CampaignID) *CampaignHandle {
+	h := &CampaignHandle{client: c, id: campID}
+
+	h.Characters = &BoundCharacterService{s: c.Characters, campID: int(h.id)}
+	h.Locations = &BoundLocationService{s: c.Locations, campID: int(h.id)}
+	h.MapPoints = &BoundMapPointService{s: c.MapPoints, campID: int(h.id)}
+	h.Families = &BoundFamilyService{s: c.Families, campID: int(h.id)}
+	h.Organizations = &BoundOrganizationService{s: c.Organizations, campID: int(h.id)}
+	h.OrganizationMembers = &BoundOrganizationMemberService{s: c.OrganizationMembers, campID: int(h.id)}
+	h.Items = &BoundItemService{s: c.Items, campID: int(h.id)}
+	h.Notes = &BoundNoteService{s: c.Notes, campID: int(h.id)}
+	h.Events = &BoundEventService{s: c.Events, campID: int(h.id)}
+	h.Races = &BoundRaceService{s: c.Races, campID: int(h.id)}
+	h.Quests = &BoundQuestService{s: c.Quests, campID: int(h.id)}
+	h.QuestCharacters = &BoundQuestCharacterService{s: c.QuestCharacters, campID: int(h.id)}
+	h.QuestLocations = &BoundQuestLocationService{s: c.QuestLocations, campID: int(h.id)}
+	h.QuestItems = &BoundQuestItemService{s: c.QuestItems, campID: int(h.id)}
+	h.QuestOrganizations = &BoundQuestOrganizationService{s: c.QuestOrganizations, campID: int(h.id)}
+	h.Journals = &BoundJournalService{s: c.Journals, campID: int(h.id)}
+	h.Tags = &BoundTagService{s: c.Tags, campID: int(h.id)}
+	h.Calendars = &BoundCalendarService{s: c.Calendars, campID: int(h.id)}
+
+	return h
+}
+
+// ID returns the ID of the Campaign bound to the CampaignHandle.
+func (h *CampaignHandle) ID() CampaignID {
+	return h.id
+}
+
+// Get returns the Campaign bound to the CampaignHandle.
+func (h *CampaignHandle) Get() (*Campaign, error) {
+	return h.client.Campaigns.Get(int(h.id))
+}
+
+// Members returns the list of all Members of the Campaign bound to the
+// CampaignHandle.
+func (h *CampaignHandle) Members() ([]*Member, error) {
+	return h.client.Campaigns.Members(int(h.id))
+}
+
+// Backup returns a Backup of the Campaign bound to the CampaignHandle.
+// See Client.Backup.
+func (h *CampaignHandle) Backup(opts *BackupOptions) (*Backup, error) {
+	return h.client.Backup(h.id, opts)
+}
+
+// Restore recreates the contents of the Backup in the Campaign bound to the
+// CampaignHandle. See Client.Restore.
+func (h *CampaignHandle) Restore(b *Backup, opts *RestoreOptions) (*RestoreReport, error) {
+	return h.client.Restore(h.id, b, opts)
+}
+
+// Search returns the entities of the Campaign bound to the CampaignHandle
+// matching qry. See Client.Search.
+func (h *CampaignHandle) Search(qry string, sync *time.Time) ([]*Result, error) {
+	return h.client.Search(int(h.id), qry, sync)
+}
+
+// MentionResolver returns a MentionResolver looking up mentions in the
+// Campaign bound to the CampaignHandle. See Client.MentionResolver.
+func (h *CampaignHandle) MentionResolver() MentionResolver {
+	return h.client.MentionResolver(h.id)
+}
+
+// ReferenceIndex returns the ReferenceIndex of the Campaign bound to the
+// CampaignHandle. See Client.ReferenceIndex.
+func (h *CampaignHandle) ReferenceIndex() (*ReferenceIndex, error) {
+	return h.client.ReferenceIndex(h.id)
+}
+
+// RelationGraph returns the RelationGraph of the Campaign bound to the
+// CampaignHandle. See Client.RelationGraph.
+func (h *CampaignHandle) RelationGraph() (*RelationGraph, error) {
+	return h.client.RelationGraph(h.id)
+}
+
+// Genealogy returns the Genealogy of the Campaign bound to the
+// CampaignHandle. See Client.Genealogy.
+func (h *CampaignHandle) Genealogy(names *KinshipNames) (*Genealogy, error) {
+	return h.client.Genealogy(h.id, names)
+}
+
+// ImportGenealogy creates the people and families of the Genealogy in the
+// Campaign bound to the CampaignHandle. See Client.ImportGenealogy.
+func (h *CampaignHandle) ImportGenealogy(g *Genealogy, names *KinshipNames) error {
+	return h.client.ImportGenealogy(h.id, g, names)
+}
+
+// Ledger returns the Ledger of the Campaign bound to the CampaignHandle.
+// See Client.Ledger.
+func (h *CampaignHandle) Ledger() (*Ledger, error) {
+	return h.client.Ledger(h.id)
+}
+
+// Tree returns the Tree of the objects of the provided type in the Campaign
+// bound to the CampaignHandle. See Client.Tree.
+func (h *CampaignHandle) Tree(typ EntityType) (*Tree, error) {
+	return h.client.Tree(h.id, typ)
+}
+
+// MoveSubtree moves the node of the Tree associated with id under the node
+// associated with parent in the Campaign bound to the CampaignHandle.
+// See Client.MoveSubtree.
+func (h *CampaignHandle) MoveSubtree(t *Tree, id ObjectID, parent ObjectID) error {
+	return h.client.MoveSubtree(h.id, t, id, parent)
+}
+
+// Schedule returns the Schedule of the Calendar associated with calID in the
+// Campaign bound to the CampaignHandle. See Client.Schedule.
+func (h *CampaignHandle) Schedule(calID CalendarID) (*Schedule, error) {
+	return h.client.Schedule(h.id, calID)
+}
+
+// Timeline returns the Timeline of the Calendar associated with calID in the
+// Campaign bound to the CampaignHandle. See Client.Timeline.
+func (h *CampaignHandle) Timeline(calID CalendarID) (*Timeline, error) {
+	return h.client.Timeline(h.id, calID)
+}
+
+// Tagged returns every entity of the Campaign bound to the CampaignHandle
+// carrying the tag associated with tagID. See Client.Tagged.
+func (h *CampaignHandle) Tagged(tagID TagID, children bool) ([]*TaggedEntity, error) {
+	return h.client.Tagged(h.id, tagID, children)
+}
+
+// TagAll adds the tag associated with tagID to every entity associated with
+// entIDs in the Campaign bound to the CampaignHandle.
+// See EntityTagService.TagAll.
+func (h *CampaignHandle) TagAll(tagID TagID, entIDs []EntityID) []*TagOutcome {
+	return h.client.EntityTags.TagAll(h.id, tagID, entIDs)
+}
+
+// UntagAll removes the tag associated with tagID from every entity
+// associated with entIDs in the Campaign bound to the CampaignHandle.
+// See EntityTagService.UntagAll.
+func (h *CampaignHandle) UntagAll(tagID TagID, entIDs []EntityID) []*TagOutcome {
+	return h.client.EntityTags.UntagAll(h.id, tagID, entIDs)
+}
+
+// ReplaceTag replaces the tag associated with oldID by the tag associated
+// with newID on every entity of the Campaign bound to the CampaignHandle.
+// See EntityTagService.ReplaceTag.
+func (h *CampaignHandle) ReplaceTag(oldID TagID, newID TagID) ([]*TagOutcome, error) {
+	return h.client.EntityTags.ReplaceTag(h.id, oldID, newID)
+}
+
+// ApplyTemplateAll applies the AttributeTemplate to every entity associated
+// with entIDs in the Campaign bound to the CampaignHandle.
+// See AttributeService.ApplyTemplateAll.
+func (h *CampaignHandle) ApplyTemplateAll(entIDs []EntityID, tmpl *AttributeTemplate, opts *TemplateOptions) []*TemplateResult {
+	return h.client.Attributes.ApplyTemplateAll(h.id, entIDs, tmpl, opts)
+}
+
+// PlanPrivacy returns the PrivacyPlan making the entities of the Campaign
+// bound to the CampaignHandle chosen by sel private, or public if private is
+// false. See Client.PlanPrivacy.
+func (h *CampaignHandle) PlanPrivacy(sel EntitySelector, private bool) (*PrivacyPlan, error) {
+	return h.client.PlanPrivacy(h.id, sel, private)
+}
+
+// Entity returns an EntityHandle whose services are bound to the entity
+// associated with entID in the Campaign bound to the CampaignHandle.
+// Note that entID is an entity ID, such as Character.EntityID,
+// and not the ID of the object itself.
+func (h *CampaignHandle) Entity(entID EntityID) *EntityHandle {
+	return &EntityHandle{client: h.client, campID: h.id, id: entID}
+}
+
+// EntityHandle provides the entity services of a Client bound to a single
+// entity of a single Campaign. EntityHandle is returned by
+// CampaignHandle.Entity.
+type EntityHandle struct {
+	client *Client
+	campID CampaignID
+	id     EntityID
+}
+
+// ID returns the entity ID bound to the EntityHandle.
+func (h *EntityHandle) ID() EntityID {
+	return h.id
+}
+
+// CampaignID returns the ID of the Campaign bound to the EntityHandle.
+func (h *EntityHandle) CampaignID() CampaignID {
+	return h.campID
+}
+
+// Attributes returns an AttributeService bound to the entity.
+func (h *EntityHandle) Attributes() *BoundAttributeService {
+	return &BoundAttributeService{s: h.client.Attributes, campID: int(h.campID), entID: int(h.id)}
+}
+
+// Events returns an EntityEventService bound to the entity.
+func (h *EntityHandle) Events() *BoundEntityEventService {
+	return &BoundEntityEventService{s: h.client.EntityEvents, campID: int(h.campID), entID: int(h.id)}
+}
+
+// Notes returns an EntityNoteService bound to the entity.
+func (h *EntityHandle) Notes() *BoundEntityNoteService {
+	return &BoundEntityNoteService{s: h.client.EntityNotes, campID: int(h.campID), entID: int(h.id)}
+}
+
+// Tags returns an EntityTagService bound to the entity.
+func (h *EntityHandle) Tags() *BoundEntityTagService {
+	return &BoundEntityTagService{s: h.client.EntityTags, campID: int(h.campID), entID: int(h.id)}
+}
+
+// Relations returns a RelationService bound to the entity.
+func (h *EntityHandle) Relations() *BoundRelationService {
+	return &BoundRelationService{s: h.client.Relations, campID: int(h.campID), entID: int(h.id)}
+}
+
+// Inventory returns an EntityInventoryService bound to the entity.
+func (h *EntityHandle) Inventory() *BoundEntityInventoryService {
+	return &BoundEntityInventoryService{s: h.client.EntityInventories, campID: int(h.campID), entID: int(h.id)}
+}
+
+// ref returns a reference to the object owning the entity bound to the
+// EntityHandle.
+func (h *EntityHandle) ref() (entityRef, error) {
+	return h.client.getEntityRef(int(h.campID), int(h.id))
+}
+
+// PlanDelete returns the DeletePlan deleting the object owning the entity
+// bound to the EntityHandle. See Client.PlanDelete.
+func (h *EntityHandle) PlanDelete(opts *DeleteOptions) (*DeletePlan, error) {
+	ref, err := h.ref()
+	if err != nil {
+		return nil, err
+	}
+
+	return h.client.PlanDelete(h.campID, ref.Type, ObjectID(ref.ID), opts)
+}
+
+// PlanMerge returns the MergePlan merging the object owning the entity
+// associated with dupID into the object owning the entity bound to the
+// EntityHandle. Both objects must be of the same type. See Client.PlanMerge.
+func (h *EntityHandle) PlanMerge(dupID EntityID) (*MergePlan, error) {
+	surv, err := h.ref()
+	if err != nil {
+		return nil, err
+	}
+
+	dup, err := h.client.getEntityRef(int(h.campID), int(dupID))
+	if err != nil {
+		return nil, err
+	}
+
+	if dup.Type != surv.Type {
+		return nil, fmt.Errorf("cannot merge %s (ID: %d) into %s (ID: %d)", dup.Type, dup.ID, surv.Type, surv.ID)
+	}
+
+	return h.client.PlanMerge(h.campID, surv.Type, ObjectID(surv.ID), ObjectID(dup.ID))
+}
+
+// Convert converts the object owning the entity bound to the EntityHandle
+// into a new object of the provided type. See Client.Convert.
+func (h *EntityHandle) Convert(to EntityType, opts *ConvertOptions) (*ConvertReport, error) {
+	ref, err := h.ref()
+	if err != nil {
+		return nil, err
+	}
+
+	return h.client.Convert(h.campID, ref.Type, ObjectID(ref.ID), to, opts)
+}
+
+// Clone copies the object owning the entity bound to the EntityHandle to the
+// Campaign associated with dstID. See Client.Clone.
+func (h *EntityHandle) Clone(dstID CampaignID, opts *CloneOptions) (*CloneReport, error) {
+	ref, err := h.ref()
+	if err != nil {
+		return nil, err
+	}
+
+	return h.client.Clone(h.campID, ref.Type, ObjectID(ref.ID), dstID, opts)
+}
+
+// BoundCharacterService is a CharacterService bound to a single Campaign.
+type BoundCharacterService struct {
+	s      *CharacterService
+	campID int
+}
+
+// Index returns the list of all Characters in the bound Campaign.
+// See CharacterService.Index.
+func (bs *BoundCharacterService) Index(sync *time.Time) ([]*Character, error) {
+	return bs.s.Index(bs.campID, sync)
+}
+
+// Get returns the Character associated with charID in the bound Campaign.
+func (bs *BoundCharacterService) Get(charID CharacterID) (*Character, error) {
+	return bs.s.Get(bs.campID, int(charID))
+}
+
+// Create creates a new Character in the bound Campaign.
+func (bs *BoundCharacterService) Create(ch SimpleCharacter) (*Character, error) {
+	return bs.s.Create(bs.campID, ch)
+}
+
+// Update updates the Character associated with charID in the bound Campaign.
+func (bs *BoundCharacterService) Update(charID CharacterID, ch SimpleCharacter) (*Character, error) {
+	return bs.s.Update(bs.campID, int(charID), ch)
+}
+
+// Delete deletes the Character associated with charID in the bound Campaign.
+func (bs *BoundCharacterService) Delete(charID CharacterID) error {
+	return bs.s.Delete(bs.campID, int(charID))
+}
+
+// BoundLocationService is a LocationService bound to a single Campaign.
+type BoundLocationService struct {
+	s      *LocationService
+	campID int
+}
+
+// Index returns the list of all Locations in the bound Campaign.
+// See LocationService.Index.
+func (bs *BoundLocationService) Index(sync *time.Time) ([]*Location, error) {
+	return bs.s.Index(bs.campID, sync)
+}
+
+// Get returns the Location associated with locID in the bound Campaign.
+func (bs *BoundLocationService) Get(locID LocationID) (*Location, error) {
+	return bs.s.Get(bs.campID, int(locID))
+}
+
+// Create creates a new Location in the bound Campaign.
+func (bs *BoundLocationService) Create(loc SimpleLocation) (*Location, error) {
+	return bs.s.Create(bs.campID, loc)
+}
+
+// Update updates the Location associated with locID in the bound Campaign.
+func (bs *BoundLocationService) Update(locID LocationID, loc SimpleLocation) (*Location, error) {
+	return bs.s.Update(bs.campID, int(locID), loc)
+}
+
+// Delete deletes the Location associated with locID in the bound Campaign.
+func (bs *BoundLocationService) Delete(locID LocationID) error {
+	return bs.s.Delete(bs.campID, int(locID))
+}
+
+// BoundFamilyService is a FamilyService bound to a single Campaign.
+type BoundFamilyService struct {
+	s      *FamilyService
+	campID int
+}
+
+// Index returns the list of all Families in the bound Campaign.
+// See FamilyService.Index.
+func (bs *BoundFamilyService) Index(sync *time.Time) ([]*Family, error) {
+	return bs.s.Index(bs.campID, sync)
+}
+
+// Get returns the Family associated with famID in the bound Campaign.
+func (bs *BoundFamilyService) Get(famID FamilyID) (*Family, error) {
+	return bs.s.Get(bs.campID, int(famID))
+}
+
+// Create creates a new Family in the bound Campaign.
+func (bs *BoundFamilyService) Create(fam SimpleFamily) (*Family, error) {
+	return bs.s.Create(bs.campID, fam)
+}
+
+// Update updates the Family associated with famID in the bound Campaign.
+func (bs *BoundFamilyService) Update(famID FamilyID, fam SimpleFamily) (*Family, error) {
+	return bs.s.Update(bs.campID, int(famID), fam)
+}
+
+// Delete deletes the Family associated with famID in the bound Campaign.
+func (bs *BoundFamilyService) Delete(famID FamilyID) error {
+	return bs.s.Delete(bs.campID, int(famID))
+}
+
+// BoundOrganizationService is a OrganizationService bound to a single Campaign.
+type BoundOrganizationService struct {
+	s      *OrganizationService
+	campID int
+}
+
+// Index returns the list of all Organizations in the bound Campaign.
+// See OrganizationService.Index.
+func (bs *BoundOrganizationService) Index(sync *time.Time) ([]*Organization, error) {
+	return bs.s.Index(bs.campID, sync)
+}
+
+// Get returns the Organization associated with orgID in the bound Campaign.
+func (bs *BoundOrganizationService) Get(orgID OrganizationID) (*Organization, error) {
+	return bs.s.Get(bs.campID, int(orgID))
+}
+
+// Create creates a new Organization in the bound Campaign.
+func (bs *BoundOrganizationService) Create(org SimpleOrganization) (*Organization, error) {
+	return bs.s.Create(bs.campID, org)
+}
+
+// Update updates the Organization associated with orgID in the bound Campaign.
+func (bs *BoundOrganizationService) Update(orgID OrganizationID, org SimpleOrganization) (*Organization, error) {
+	return bs.s.Update(bs.campID, int(orgID), org)
+}
+
+// Delete deletes the Organization associated with orgID in the bound Campaign.
+func (bs *BoundOrganizationService) Delete(orgID OrganizationID) error {
+	return bs.s.Delete(bs.campID, int(orgID))
+}
+
+// BoundItemService is a ItemService bound to a single Campaign.
+type BoundItemService struct {
+	s      *ItemService
+	campID int
+}
+
+// Index returns the list of all Items in the bound Campaign.
+// See ItemService.Index.
+func (bs *BoundItemService) Index(sync *time.Time) ([]*Item, error) {
+	return bs.s.Index(bs.campID, sync)
+}
+
+// Get returns the Item associated with itemID in the bound Campaign.
+func (bs *BoundItemService) Get(itemID ItemID) (*Item, error) {
+	return bs.s.Get(bs.campID, int(itemID))
+}
+
+// Create creates a new Item in the bound Campaign.
+func (bs *BoundItemService) Create(item SimpleItem) (*Item, error) {
+	return bs.s.Create(bs.campID, item)
+}
+
+// Update updates the Item associated with itemID in the bound Campaign.
+func (bs *BoundItemService) Update(itemID ItemID, item SimpleItem) (*Item, error) {
+	return bs.s.Update(bs.campID, int(itemID), item)
+}
+
+// Delete deletes the Item associated with itemID in the bound Campaign.
+func (bs *BoundItemService) Delete(itemID ItemID) error {
+	return bs.s.Delete(bs.campID, int(itemID))
+}
+
+// BoundNoteService is a NoteService bound to a single Campaign.
+type BoundNoteService struct {
+	s      *NoteService
+	campID int
+}
+
+// Index returns the list of all Notes in the bound Campaign.
+// See NoteService.Index.
+func (bs *BoundNoteService) Index(sync *time.Time) ([]*Note, error) {
+	return bs.s.Index(bs.campID, sync)
+}
+
+// Get returns the Note associated with noteID in the bound Campaign.
+func (bs *BoundNoteService) Get(noteID NoteID) (*Note, error) {
+	return bs.s.Get(bs.campID, int(noteID))
+}
+
+// Create creates a new Note in the bound Campaign.
+func (bs *BoundNoteService) Create(note SimpleNote) (*Note, error) {
+	return bs.s.Create(bs.campID, note)
+}
+
+// Update updates the Note associated with noteID in the bound Campaign.
+func (bs *BoundNoteService) Update(noteID NoteID, note SimpleNote) (*Note, error) {
+	return bs.s.Update(bs.campID, int(noteID), note)
+}
+
+// Delete deletes the Note associated with noteID in the bound Campaign.
+func (bs *BoundNoteService) Delete(noteID NoteID) error {
+	return bs.s.Delete(bs.campID, int(noteID))
+}
+
+// BoundEventService is a EventService bound to a single Campaign.
+type BoundEventService struct {
+	s      *EventService
+	campID int
+}
+
+// Index returns the list of all Events in the bound Campaign.
+// See EventService.Index.
+func (bs *BoundEventService) Index(sync *time.Time) ([]*Event, error) {
+	return bs.s.Index(bs.campID, sync)
+}
+
+// Get returns the Event associated with evtID in the bound Campaign.
+func (bs *BoundEventService) Get(evtID EventID) (*Event, error) {
+	return bs.s.Get(bs.campID, int(evtID))
+}
+
+// Create creates a new Event in the bound Campaign.
+func (bs *BoundEventService) Create(evt SimpleEvent) (*Event, error) {
+	return bs.s.Create(bs.campID, evt)
+}
+
+// Update updates the Event associated with evtID in the bound Campaign.
+func (bs *BoundEventService) Update(evtID EventID, evt SimpleEvent) (*Event, error) {
+	return bs.s.Update(bs.campID, int(evtID), evt)
+}
+
+// Delete deletes the Event associated with evtID in the bound Campaign.
+func (bs *BoundEventService) Delete(evtID EventID) error {
+	return bs.s.Delete(bs.campID, int(evtID))
+}
+
+// BoundRaceService is a RaceService bound to a single Campaign.
+type BoundRaceService struct {
+	s      *RaceService
+	campID int
+}
+
+// Index returns the list of all Races in the bound Campaign.
+// See RaceService.Index.
+func (bs *BoundRaceService) Index(sync *time.Time) ([]*Race, error) {
+	return bs.s.Index(bs.campID, sync)
+}
+
+// Get returns the Race associated with raceID in the bound Campaign.
+func (bs *BoundRaceService) Get(raceID RaceID) (*Race, error) {
+	return bs.s.Get(bs.campID, int(raceID))
+}
+
+// Create creates a new Race in the bound Campaign.
+func (bs *BoundRaceService) Create(race SimpleRace) (*Race, error) {
+	return bs.s.Create(bs.campID, race)
+}
+
+// Update updates the Race associated with raceID in the bound Campaign.
+func (bs *BoundRaceService) Update(raceID RaceID, race SimpleRace) (*Race, error) {
+	return bs.s.Update(bs.campID, int(raceID), race)
+}
+
+// Delete deletes the Race associated with raceID in the bound Campaign.
+func (bs *BoundRaceService) Delete(raceID RaceID) error {
+	return bs.s.Delete(bs.campID, int(raceID))
+}
+
+// BoundQuestService is a QuestService bound to a single Campaign.
+type BoundQuestService struct {
+	s      *QuestService
+	campID int
+}
+
+// Index returns the list of all Quests in the bound Campaign.
+// See QuestService.Index.
+func (bs *BoundQuestService) Index(sync *time.Time) ([]*Quest, error) {
+	return bs.s.Index(bs.campID, sync)
+}
+
+// Get returns the Quest associated with qstID in the bound Campaign.
+func (bs *BoundQuestService) Get(qstID QuestID) (*Quest, error) {
+	return bs.s.Get(bs.campID, int(qstID))
+}
+
+// Create creates a new Quest in the bound Campaign.
+func (bs *BoundQuestService) Create(qst SimpleQuest) (*Quest, error) {
+	return bs.s.Create(bs.campID, qst)
+}
+
+// Update updates the Quest associated with qstID in the bound Campaign.
+func (bs *BoundQuestService) Update(qstID QuestID, qst SimpleQuest) (*Quest, error) {
+	return bs.s.Update(bs.campID, int(qstID), qst)
+}
+
+// Delete deletes the Quest associated with qstID in the bound Campaign.
+func (bs *BoundQuestService) Delete(qstID QuestID) error {
+	return bs.s.Delete(bs.campID, int(qstID))
+}
+
+// BoundJournalService is a JournalService bound to a single Campaign.
+type BoundJournalService struct {
+	s      *JournalService
+	campID int
+}
+
+// Index returns the list of all Journals in the bound Campaign.
+// See JournalService.Index.
+func (bs *BoundJournalService) Index(sync *time.Time) ([]*Journal, error) {
+	return bs.s.Index(bs.campID, sync)
+}
+
+// Get returns the Journal associated with jrnID in the bound Campaign.
+func (bs *BoundJournalService) Get(jrnID JournalID) (*Journal, error) {
+	return bs.s.Get(bs.campID, int(jrnID))
+}
+
+// Create creates a new Journal in the bound Campaign.
+func (bs *BoundJournalService) Create(jrn SimpleJournal) (*Journal, error) {
+	return bs.s.Create(bs.campID, jrn)
+}
+
+// Update updates the Journal associated with jrnID in the bound Campaign.
+func (bs *BoundJournalService) Update(jrnID JournalID, jrn SimpleJournal) (*Journal, error) {
+	return bs.s.Update(bs.campID, int(jrnID), jrn)
+}
+
+// Delete deletes the Journal associated with jrnID in the bound Campaign.
+func (bs *BoundJournalService) Delete(jrnID JournalID) error {
+	return bs.s.Delete(bs.campID, int(jrnID))
+}
+
+// BoundTagService is a TagService bound to a single Campaign.
+type BoundTagService struct {
+	s      *TagService
+	campID int
+}
+
+// Index returns the list of all Tags in the bound Campaign.
+// See TagService.Index.
+func (bs *BoundTagService) Index(sync *time.Time) ([]*Tag, error) {
+	return bs.s.Index(bs.campID, sync)
+}
+
+// Get returns the Tag associated with tagID in the bound Campaign.
+func (bs *BoundTagService) Get(tagID TagID) (*Tag, error) {
+	return bs.s.Get(bs.campID, int(tagID))
+}
+
+// Create creates a new Tag in the bound Campaign.
+func (bs *BoundTagService) Create(tag SimpleTag) (*Tag, error) {
+	return bs.s.Create(bs.campID, tag)
+}
+
+// Update updates the Tag associated with tagID in the bound Campaign.
+func (bs *BoundTagService) Update(tagID TagID, tag SimpleTag) (*Tag, error) {
+	return bs.s.Update(bs.campID, int(tagID), tag)
+}
+
+// Delete deletes the Tag associated with tagID in the bound Campaign.
+func (bs *BoundTagService) Delete(tagID TagID) error {
+	return bs.s.Delete(bs.campID, int(tagID))
+}
+
+// BoundCalendarService is a CalendarService bound to a single Campaign.
+type BoundCalendarService struct {
+	s      *CalendarService
+	campID int
+}
+
+// Index returns the list of all Calendars in the bound Campaign.
+// See CalendarService.Index.
+func (bs *BoundCalendarService) Index(sync *time.Time) ([]*Calendar, error) {
+	return bs.s.Index(CampaignID(bs.campID), sync)
+}
+
+// Get returns the Calendar associated with calID in the bound Campaign.
+func (bs *BoundCalendarService) Get(calID CalendarID) (*Calendar, error) {
+	return bs.s.Get(CampaignID(bs.campID), calID)
+}
+
+// BoundOrganizationMemberService is a OrganizationMemberService bound to a single Campaign.
+type BoundOrganizationMemberService struct {
+	s      *OrganizationMemberService
+	campID int
+}
+
+// Index returns the list of all OrganizationMembers of the Organization associated with orgID
+// in the bound Campaign. See OrganizationMemberService.Index.
+func (bs *BoundOrganizationMemberService) Index(orgID OrganizationID, sync *time.Time) ([]*OrganizationMember, error) {
+	return bs.s.Index(bs.campID, int(orgID), sync)
+}
+
+// Get returns the OrganizationMember associated with memID of the Organization associated
+// with orgID in the bound Campaign.
+func (bs *BoundOrganizationMemberService) Get(orgID OrganizationID, memID int) (*OrganizationMember, error) {
+	return bs.s.Get(bs.campID, int(orgID), memID)
+}
+
+// Create creates a new OrganizationMember for the Organization associated with orgID in the
+// bound Campaign.
+func (bs *BoundOrganizationMemberService) Create(orgID OrganizationID, mem SimpleOrganizationMember) (*OrganizationMember, error) {
+	return bs.s.Create(CampaignID(bs.campID), orgID, mem)
+}
+
+// Update updates the OrganizationMember associated with memID of the Organization associated
+// with orgID in the bound Campaign.
+func (bs *BoundOrganizationMemberService) Update(orgID OrganizationID, memID int, mem SimpleOrganizationMember) (*OrganizationMember, error) {
+	return bs.s.Update(bs.campID, int(orgID), memID, mem)
+}
+
+// Delete deletes the OrganizationMember associated with memID of the Organization associated
+// with orgID in the bound Campaign.
+func (bs *BoundOrganizationMemberService) Delete(orgID OrganizationID, memID int) error {
+	return bs.s.Delete(bs.campID, int(orgID), memID)
+}
+
+// BoundQuestCharacterService is a QuestCharacterService bound to a single Campaign.
+type BoundQuestCharacterService struct {
+	s      *QuestCharacterService
+	campID int
+}
+
+// Index returns the list of all QuestCharacters of the Quest associated with qstID
+// in the bound Campaign. See QuestCharacterService.Index.
+func (bs *BoundQuestCharacterService) Index(qstID QuestID, sync *time.Time) ([]*QuestCharacter, error) {
+	return bs.s.Index(bs.campID, int(qstID), sync)
+}
+
+// Get returns the QuestCharacter associated with qchID of the Quest associated
+// with qstID in the bound Campaign.
+func (bs *BoundQuestCharacterService) Get(qstID QuestID, qchID int) (*QuestCharacter, error) {
+	return bs.s.Get(bs.campID, int(qstID), qchID)
+}
+
+// Create creates a new QuestCharacter for the Quest associated with qstID in the
+// bound Campaign.
+func (bs *BoundQuestCharacterService) Create(qstID QuestID, qch SimpleQuestCharacter) (*QuestCharacter, error) {
+	return bs.s.Create(bs.campID, int(qstID), qch)
+}
+
+// Update updates the QuestCharacter associated with qchID of the Quest associated
+// with qstID in the bound Campaign.
+func (bs *BoundQuestCharacterService) Update(qstID QuestID, qchID int, qch SimpleQuestCharacter) (*QuestCharacter, error) {
+	return bs.s.Update(bs.campID, int(qstID), qchID, qch)
+}
+
+// Delete deletes the QuestCharacter associated with qchID of the Quest associated
+// with qstID in the bound Campaign.
+func (bs *BoundQuestCharacterService) Delete(qstID QuestID, qchID int) error {
+	return bs.s.Delete(bs.campID, int(qstID), qchID)
+}
+
+// BoundQuestLocationService is a QuestLocationService bound to a single Campaign.
+type BoundQuestLocationService struct {
+	s      *QuestLocationService
+	campID int
+}
+
+// Index returns the list of all QuestLocations of the Quest associated with qstID
+// in the bound Campaign. See QuestLocationService.Index.
+func (bs *BoundQuestLocationService) Index(qstID QuestID, sync *time.Time) ([]*QuestLocation, error) {
+	return bs.s.Index(bs.campID, int(qstID), sync)
+}
+
+// Get returns the QuestLocation associated with qlocID of the Quest associated
+// with qstID in the bound Campaign.
+func (bs *BoundQuestLocationService) Get(qstID QuestID, qlocID int) (*QuestLocation, error) {
+	return bs.s.Get(bs.campID, int(qstID), qlocID)
+}
+
+// Create creates a new QuestLocation for the Quest associated with qstID in the
+// bound Campaign.
+func (bs *BoundQuestLocationService) Create(qstID QuestID, qloc SimpleQuestLocation) (*QuestLocation, error) {
+	return bs.s.Create(bs.campID, int(qstID), qloc)
+}
+
+// Update updates the QuestLocation associated with qlocID of the Quest associated
+// with qstID in the bound Campaign.
+func (bs *BoundQuestLocationService) Update(qstID QuestID, qlocID int, qloc SimpleQuestLocation) (*QuestLocation, error) {
+	return bs.s.Update(bs.campID, int(qstID), qlocID, qloc)
+}
+
+// Delete deletes the QuestLocation associated with qlocID of the Quest associated
+// with qstID in the bound Campaign.
+func (bs *BoundQuestLocationService) Delete(qstID QuestID, qlocID int) error {
+	return bs.s.Delete(bs.campID, int(qstID), qlocID)
+}
+
+// BoundQuestItemService is a QuestItemService bound to a single Campaign.
+type BoundQuestItemService struct {
+	s      *QuestItemService
+	campID int
+}
+
+// Index returns the list of all QuestItems of the Quest associated with qstID
+// in the bound Campaign. See QuestItemService.Index.
+func (bs *BoundQuestItemService) Index(qstID QuestID, sync *time.Time) ([]*QuestItem, error) {
+	return bs.s.Index(bs.campID, int(qstID), sync)
+}
+
+// Get returns the QuestItem associated with itemID of the Quest associated
+// with qstID in the bound Campaign.
+func (bs *BoundQuestItemService) Get(qstID QuestID, itemID int) (*QuestItem, error) {
+	return bs.s.Get(bs.campID, int(qstID), itemID)
+}
+
+// Create creates a new QuestItem for the Quest associated with qstID in the
+// bound Campaign.
+func (bs *BoundQuestItemService) Create(qstID QuestID, item SimpleQuestItem) (*QuestItem, error) {
+	return bs.s.Create(bs.campID, int(qstID), item)
+}
+
+// Update updates the QuestItem associated with itemID of the Quest associated
+// with qstID in the bound Campaign.
+func (bs *BoundQuestItemService) Update(qstID QuestID, itemID int, item SimpleQuestItem) (*QuestItem, error) {
+	return bs.s.Update(bs.campID, int(qstID), itemID, item)
+}
+
+// Delete deletes the QuestItem associated with itemID of the Quest associated
+// with qstID in the bound Campaign.
+func (bs *BoundQuestItemService) Delete(qstID QuestID, itemID int) error {
+	return bs.s.Delete(bs.campID, int(qstID), itemID)
+}
+
+// BoundQuestOrganizationService is a QuestOrganizationService bound to a single Campaign.
+type BoundQuestOrganizationService struct {
+	s      *QuestOrganizationService
+	campID int
+}
+
+// Index returns the list of all QuestOrganizations of the Quest associated with qstID
+// in the bound Campaign. See QuestOrganizationService.Index.
+func (bs *BoundQuestOrganizationService) Index(qstID QuestID, sync *time.Time) ([]*QuestOrganization, error) {
+	return bs.s.Index(bs.campID, int(qstID), sync)
+}
+
+// Get returns the QuestOrganization associated with orgID of the Quest associated
+// with qstID in the bound Campaign.
+func (bs *BoundQuestOrganizationService) Get(qstID QuestID, orgID int) (*QuestOrganization, error) {
+	return bs.s.Get(bs.campID, int(qstID), orgID)
+}
+
+// Create creates a new QuestOrganization for the Quest associated with qstID in the
+// bound Campaign.
+func (bs *BoundQuestOrganizationService) Create(qstID QuestID, org SimpleQuestOrganization) (*QuestOrganization, error) {
+	return bs.s.Create(CampaignID(bs.campID), qstID, org)
+}
+
+// Update updates the QuestOrganization associated with orgID of the Quest associated
+// with qstID in the bound Campaign.
+func (bs *BoundQuestOrganizationService) Update(qstID QuestID, orgID int, org SimpleQuestOrganization) (*QuestOrganization, error) {
+	return bs.s.Update(bs.campID, int(qstID), orgID, org)
+}
+
+// Delete deletes the QuestOrganization associated with orgID of the Quest associated
+// with qstID in the bound Campaign.
+func (bs *BoundQuestOrganizationService) Delete(qstID QuestID, orgID int) error {
+	return bs.s.Delete(bs.campID, int(qstID), orgID)
+}
+
+// BoundMapPointService is a MapPointService bound to a single Campaign.
+type BoundMapPointService struct {
+	s      *MapPointService
+	campID int
+}
+
+// Index returns the list of all MapPoints of the Location associated with
+// locID in the bound Campaign. See MapPointService.Index.
+func (bs *BoundMapPointService) Index(locID LocationID, sync *time.Time) ([]*MapPoint, error) {
+	return bs.s.Index(bs.campID, int(locID), sync)
+}
+
+// Create creates a new MapPoint on the Location associated with locID in the
+// bound Campaign.
+func (bs *BoundMapPointService) Create(locID LocationID, mp SimpleMapPoint) (*MapPoint, error) {
+	return bs.s.Create(bs.campID, int(locID), mp)
+}
+
+// BoundAttributeService is an AttributeService bound to a single
+// entity of a single Campaign.
+type BoundAttributeService struct {
+	s      *AttributeService
+	campID int
+	entID  int
+}
+
+// Index returns the list of all Attributes of the bound entity.
+// See AttributeService.Index.
+func (bs *BoundAttributeService) Index(sync *time.Time) ([]*Attribute, error) {
+	return bs.s.Index(bs.campID, bs.entID, sync)
+}
+
+// Get returns the Attribute associated with atrID of the bound entity.
+func (bs *BoundAttributeService) Get(atrID int) (*Attribute, error) {
+	return bs.s.Get(bs.campID, bs.entID, atrID)
+}
+
+// Create creates a new Attribute for the bound entity.
+func (bs *BoundAttributeService) Create(atr SimpleAttribute) (*Attribute, error) {
+	return bs.s.Create(bs.campID, bs.entID, atr)
+}
+
+// Update updates the Attribute associated with atrID of the bound entity.
+func (bs *BoundAttributeService) Update(atrID int, atr SimpleAttribute) (*Attribute, error) {
+	return bs.s.Update(bs.campID, bs.entID, atrID, atr)
+}
+
+// Delete deletes the Attribute associated with atrID of the bound entity.
+func (bs *BoundAttributeService) Delete(atrID int) error {
+	return bs.s.Delete(bs.campID, bs.entID, atrID)
+}
+
+// Evaluate returns the computed value of every attribute of the bound entity,
+// keyed by name. See AttributeService.Evaluate.
+func (bs *BoundAttributeService) Evaluate() (map[string]string, error) {
+	return bs.s.Evaluate(CampaignID(bs.campID), EntityID(bs.entID))
+}
+
+// SyncAttributes updates the attributes of the bound entity to match the
+// struct pointed to by v. See AttributeService.SyncAttributes.
+func (bs *BoundAttributeService) SyncAttributes(v interface{}) error {
+	return bs.s.SyncAttributes(CampaignID(bs.campID), EntityID(bs.entID), v)
+}
+
+// ApplyTemplate applies the AttributeTemplate to the bound entity.
+// See AttributeService.ApplyTemplate.
+func (bs *BoundAttributeService) ApplyTemplate(tmpl *AttributeTemplate, opts *TemplateOptions) (*TemplateResult, error) {
+	return bs.s.ApplyTemplate(CampaignID(bs.campID), EntityID(bs.entID), tmpl, opts)
+}
+
+// BoundEntityEventService is an EntityEventService bound to a single
+// entity of a single Campaign.
+type BoundEntityEventService struct {
+	s      *EntityEventService
+	campID int
+	entID  int
+}
+
+// Index returns the list of all EntityEvents of the bound entity.
+// See EntityEventService.Index.
+func (bs *BoundEntityEventService) Index(sync *time.Time) ([]*EntityEvent, error) {
+	return bs.s.Index(bs.campID, bs.entID, sync)
+}
+
+// Get returns the EntityEvent associated with evtID of the bound entity.
+func (bs *BoundEntityEventService) Get(evtID int) (*EntityEvent, error) {
+	return bs.s.Get(bs.campID, bs.entID, evtID)
+}
+
+// Create creates a new EntityEvent for the bound entity.
+func (bs *BoundEntityEventService) Create(evt SimpleEntityEvent) (*EntityEvent, error) {
+	return bs.s.Create(bs.campID, bs.entID, evt)
+}
+
+// Update updates the EntityEvent associated with evtID of the bound entity.
+func (bs *BoundEntityEventService) Update(evtID int, evt SimpleEntityEvent) (*EntityEvent, error) {
+	return bs.s.Update(bs.campID, bs.entID, evtID, evt)
+}
+
+// Delete deletes the EntityEvent associated with evtID of the bound entity.
+func (bs *BoundEntityEventService) Delete(evtID int) error {
+	return bs.s.Delete(bs.campID, bs.entID, evtID)
+}
+
+// BoundEntityNoteService is an EntityNoteService bound to a single
+// entity of a single Campaign.
+type BoundEntityNoteService struct {
+	s      *EntityNoteService
+	campID int
+	entID  int
+}
+
+// Index returns the list of all EntityNotes of the bound entity.
+// See EntityNoteService.Index.
+func (bs *BoundEntityNoteService) Index(sync *time.Time) ([]*EntityNote, error) {
+	return bs.s.Index(bs.campID, bs.entID, sync)
+}
+
+// Get returns the EntityNote associated with noteID of the bound entity.
+func (bs *BoundEntityNoteService) Get(noteID int) (*EntityNote, error) {
+	return bs.s.Get(bs.campID, bs.entID, noteID)
+}
+
+// Create creates a new EntityNote for the bound entity.
+func (bs *BoundEntityNoteService) Create(note SimpleEntityNote) (*EntityNote, error) {
+	return bs.s.Create(bs.campID, bs.entID, note)
+}
+
+// Update updates the EntityNote associated with noteID of the bound entity.
+func (bs *BoundEntityNoteService) Update(noteID int, note SimpleEntityNote) (*EntityNote, error) {
+	return bs.s.Update(bs.campID, bs.entID, noteID, note)
+}
+
+// Delete deletes the EntityNote associated with noteID of the bound entity.
+func (bs *BoundEntityNoteService) Delete(noteID int) error {
+	return bs.s.Delete(bs.campID, bs.entID, noteID)
+}
+
+// BoundEntityTagService is an EntityTagService bound to a single
+// entity of a single Campaign.
+type BoundEntityTagService struct {
+	s      *EntityTagService
+	campID int
+	entID  int
+}
+
+// Index returns the list of all EntityTags of the bound entity.
+// See EntityTagService.Index.
+func (bs *BoundEntityTagService) Index(sync *time.Time) ([]*EntityTag, error) {
+	return bs.s.Index(bs.campID, bs.entID, sync)
+}
+
+// Get returns the EntityTag associated with tagID of the bound entity.
+func (bs *BoundEntityTagService) Get(tagID int) (*EntityTag, error) {
+	return bs.s.Get(bs.campID, bs.entID, tagID)
+}
+
+// Create creates a new EntityTag for the bound entity.
+func (bs *BoundEntityTagService) Create(tag SimpleEntityTag) (*EntityTag, error) {
+	return bs.s.Create(bs.campID, bs.entID, tag)
+}
+
+// Update updates the EntityTag associated with tagID of the bound entity.
+func (bs *BoundEntityTagService) Update(tagID int, tag SimpleEntityTag) (*EntityTag, error) {
+	return bs.s.Update(bs.campID, bs.entID, tagID, tag)
+}
+
+// Delete deletes the EntityTag associated with tagID of the bound entity.
+func (bs *BoundEntityTagService) Delete(tagID int) error {
+	return bs.s.Delete(bs.campID, bs.entID, tagID)
+}
+
+// BoundRelationService is a RelationService bound to a single
+// entity of a single Campaign.
+type BoundRelationService struct {
+	s      *RelationService
+	campID int
+	entID  int
+}
+
+// Index returns the list of all Relations of the bound entity.
+// See RelationService.Index.
+func (bs *BoundRelationService) Index(sync *time.Time) ([]*Relation, error) {
+	return bs.s.Index(bs.campID, bs.entID, sync)
+}
+
+// Get returns the Relation associated with relID of the bound entity.
+func (bs *BoundRelationService) Get(relID int) (*Relation, error) {
+	return bs.s.Get(bs.campID, bs.entID, relID)
+}
+
+// Create creates a new Relation for the bound entity.
+func (bs *BoundRelationService) Create(rel SimpleRelation) (*Relation, error) {
+	return bs.s.Create(bs.campID, bs.entID, rel)
+}
+
+// Update updates the Relation associated with relID of the bound entity.
+func (bs *BoundRelationService) Update(relID int, rel SimpleRelation) (*Relation, error) {
+	return bs.s.Update(bs.campID, bs.entID, relID, rel)
+}
+
+// Delete deletes the Relation associated with relID of the bound entity.
+func (bs *BoundRelationService) Delete(relID int) error {
+	return bs.s.Delete(bs.campID, bs.entID, relID)
+}
+
+// BoundEntityInventoryService is an EntityInventoryService bound to a single
+// entity of a single Campaign.
+type BoundEntityInventoryService struct {
+	s      *EntityInventoryService
+	campID int
+	entID  int
+}
+
+// Index returns the list of all EntityInventories of the bound entity.
+// See EntityInventoryService.Index.
+func (bs *BoundEntityInventoryService) Index(sync *time.Time) ([]*EntityInventory, error) {
+	return bs.s.Index(bs.campID, bs.entID, sync)
+}
+
+// Create creates a new EntityInventory for the bound entity.
+func (bs *BoundEntityInventoryService) Create(inv SimpleEntityInventory) (*EntityInventory, error) {
+	return bs.s.Create(bs.campID, bs.entID, inv)
+}
+
+// Update updates the EntityInventory associated with invID of the bound entity.
+func (bs *BoundEntityInventoryService) Update(invID int, inv SimpleEntityInventory) (*EntityInventory, error) {
+	return bs.s.Update(bs.campID, bs.entID, invID, inv)
+}
+
+// Delete deletes the EntityInventory associated with invID of the bound entity.
+func (bs *BoundEntityInventoryService) Delete(invID int) error {
+	return bs.s.Delete(bs.campID, bs.entID, invID)
+}
+
+// MergeStacks merges the bound entity's stacks that hold the same item in
+// the same position.
+// See EntityInventoryService.MergeStacks.
+func (bs *BoundEntityInventoryService) MergeStacks() ([]*EntityInventory, error) {
+	return bs.s.MergeStacks(CampaignID(bs.campID), EntityID(bs.entID))
+}
+
+// Transfer moves amount of the item associated with itemID from the
+// inventory of the bound entity to the inventory of the entity associated
+// with to. See EntityInventoryService.Transfer.
+func (bs *BoundEntityInventoryService) Transfer(to EntityID, itemID ItemID, amount int) (*EntityInventory, error) {
+	return bs.s.Transfer(CampaignID(bs.campID), EntityID(bs.entID), to, itemID, amount)
+}
@@ -0,0 +1,204 @@
+package kanka
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCampaignHandle(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+
+	camp := c.Campaign(1)
+	if camp.ID() != 1 {
+		t.Errorf("got campaign ID <%d>, want <%d>", camp.ID(), 1)
+	}
+
+	ch, err := camp.Characters.Get(30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ch.Name != "Arya Stark" {
+		t.Errorf("got character <%s>, want <%s>", ch.Name, "Arya Stark")
+	}
+
+	chars, err := camp.Characters.Index(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chars) != 2 {
+		t.Errorf("got <%d> characters, want <%d>", len(chars), 2)
+	}
+
+	loc, err := camp.Locations.Create(SimpleLocation{Name: "Riverrun"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fk.object("/campaigns/1/locations", int(loc.ID)) == nil {
+		t.Error("got no created location, want location in bound campaign")
+	}
+	if err = camp.Locations.Delete(loc.ID); err != nil {
+		t.Fatal(err)
+	}
+	if fk.object("/campaigns/1/locations", int(loc.ID)) != nil {
+		t.Error("got location after delete, want deleted")
+	}
+
+	qchs, err := camp.QuestCharacters.Index(60, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(qchs) != 1 || qchs[0].CharacterID != 31 {
+		t.Errorf("got quest characters <%v>, want Jon Snow", qchs)
+	}
+
+	if err = camp.Characters.Delete(31); err != nil {
+		t.Fatal(err)
+	}
+	if fk.object("/campaigns/1/characters", 31) != nil {
+		t.Error("got character after delete, want deleted")
+	}
+}
+
+func TestEntityHandle(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+
+	camp := c.Campaign(1)
+	ch, err := camp.Characters.Get(30)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ent := camp.Entity(ch.EntityID)
+	if ent.ID() != 130 || ent.CampaignID() != 1 {
+		t.Errorf("got entity <%d> of campaign <%d>, want <%d> of <%d>", ent.ID(), ent.CampaignID(), 130, 1)
+	}
+
+	attrs, err := ent.Attributes().Index(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(attrs) != 1 || attrs[0].Name != "Strength" {
+		t.Errorf("got attributes <%v>, want Strength", attrs)
+	}
+
+	rel, err := ent.Relations().Get(85)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rel.Relation != "Sister" {
+		t.Errorf("got relation <%s>, want <%s>", rel.Relation, "Sister")
+	}
+
+	note, err := ent.Notes().Create(SimpleEntityNote{EntityID: 130, Name: "Faces"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fk.object("/campaigns/1/entities/130/entity_notes", note.ID) == nil {
+		t.Error("got no created entity note, want note on bound entity")
+	}
+
+	var got []int
+	for _, coll := range []string{"entity_events", "entity_tags", "inventory"} {
+		got = append(got, len(fk.list("/campaigns/1/entities/130/"+coll)))
+	}
+
+	evts, err := ent.Events().Index(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tags, err := ent.Tags().Index(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inv, err := ent.Inventory().Index(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(got, []int{len(evts), len(tags), len(inv)}); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	if err = ent.Relations().Delete(85); err != nil {
+		t.Fatal(err)
+	}
+	if fk.object("/campaigns/1/entities/130/relations", 85) != nil {
+		t.Error("got relation after delete, want deleted")
+	}
+}
+
+func TestCampaignHandle_Operations(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedTagged(t, fk)
+
+	camp := c.Campaign(1)
+	b, err := camp.Backup(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Campaign.Name != "Westeros" {
+		t.Errorf("got campaign <%s>, want <%s>", b.Campaign.Name, "Westeros")
+	}
+
+	tagged, err := camp.Tagged(70, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []EntityID
+	for _, te := range tagged {
+		got = append(got, te.EntityID)
+	}
+	if diff := cmp.Diff([]EntityID{130, 131}, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	outs := camp.TagAll(72, []EntityID{130})
+	if len(outs) != 1 || !outs[0].Changed || outs[0].Err != nil {
+		t.Errorf("got outcomes <%v>, want one change", outs)
+	}
+	if diff := cmp.Diff([]int{70, 72}, entityTagIDs(fk, 130)); diff != "" {
+		t.Errorf("entity tags mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestEntityHandle_Operations(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+	fk.store("/campaigns/1/entities", map[string]interface{}{"id": 110, "name": "Winterfell", "type": "location", "child_id": 10})
+	fk.store("/campaigns/1/entities", map[string]interface{}{"id": 130, "name": "Arya Stark", "type": "character", "child_id": 30})
+	fk.store("/campaigns/1/entities", map[string]interface{}{"id": 131, "name": "Jon Snow", "type": "character", "child_id": 31})
+
+	camp := c.Campaign(1)
+	del, err := camp.Entity(131).PlanDelete(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if del.Type != TypeCharacter || del.ID != 31 {
+		t.Errorf("got plan deleting %s <%d>, want %s <%d>", del.Type, del.ID, TypeCharacter, 31)
+	}
+
+	mrg, err := camp.Entity(130).PlanMerge(131)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mrg.Type != TypeCharacter || mrg.SurvivorID != 30 || mrg.DuplicateID != 31 {
+		t.Errorf("got plan merging %s <%d> into <%d>, want %s <%d> into <%d>", mrg.Type, mrg.DuplicateID, mrg.SurvivorID, TypeCharacter, 31, 30)
+	}
+
+	if _, err = camp.Entity(130).PlanMerge(110); err == nil {
+		t.Error("got nil error for merging a location into a character, want error")
+	}
+	if _, err = camp.Entity(999).PlanDelete(nil); err == nil {
+		t.Error("got nil error for missing entity, want error")
+	}
+}
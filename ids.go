@@ -0,0 +1,83 @@
+package kanka
+
+// Kanka identifies every object twice: by its object ID, such as Character.ID,
+// which is unique among objects of the same type, and by its entity ID, such
+// as Character.EntityID, which is unique among all entities of a Campaign.
+// The named ID types below distinguish the two so that, for example, a
+// CharacterID cannot be passed where an EntityID is required. The ID fields of
+// objects and the services of CampaignHandle and EntityHandle use these types.
+
+// CampaignID identifies a Campaign.
+type CampaignID int
+
+// EntityID identifies the entity of an object, such as Character.EntityID.
+type EntityID int
+
+// CharacterID identifies a Character by its object ID, such as Character.ID.
+type CharacterID int
+
+// LocationID identifies a Location by its object ID, such as Location.ID.
+type LocationID int
+
+// FamilyID identifies a Family by its object ID, such as Family.ID.
+type FamilyID int
+
+// OrganizationID identifies an Organization by its object ID, such as Organization.ID.
+type OrganizationID int
+
+// ItemID identifies an Item by its object ID, such as Item.ID.
+type ItemID int
+
+// NoteID identifies a Note by its object ID, such as Note.ID.
+type NoteID int
+
+// EventID identifies an Event by its object ID, such as Event.ID.
+type EventID int
+
+// RaceID identifies a Race by its object ID, such as Race.ID.
+type RaceID int
+
+// QuestID identifies a Quest by its object ID, such as Quest.ID.
+type QuestID int
+
+// JournalID identifies a Journal by its object ID, such as Journal.ID.
+type JournalID int
+
+// TagID identifies a Tag by its object ID, such as Tag.ID.
+type TagID int
+
+// CalendarID identifies a Calendar by its object ID, such as Calendar.ID.
+type CalendarID int
+
+// ObjectID identifies an object of any type by its object ID when the type is
+// given alongside it, such as EntityData.ID and EntityData.Type. The object
+// ID of a known type converts to an ObjectID, such as ObjectID(ch.ID).
+type ObjectID int
+
+// tagInts returns the provided tag IDs as ints.
+func tagInts(ids []TagID) []int {
+	if ids == nil {
+		return nil
+	}
+
+	ints := make([]int, len(ids))
+	for i, id := range ids {
+		ints[i] = int(id)
+	}
+
+	return ints
+}
+
+// intTags returns the provided ints as tag IDs.
+func intTags(ints []int) []TagID {
+	if ints == nil {
+		return nil
+	}
+
+	ids := make([]TagID, len(ints))
+	for i, id := range ints {
+		ids[i] = TagID(id)
+	}
+
+	return ids
+}
@@ -0,0 +1,30 @@
+package kanka
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTagInts(t *testing.T) {
+	tests := []struct {
+		name string
+		ids  []TagID
+		want []int
+	}{
+		{"Nil", nil, nil},
+		{"Empty", []TagID{}, []int{}},
+		{"Tags", []TagID{70, 71}, []int{70, 71}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := tagInts(test.ids)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(test.ids, intTags(got)); diff != "" {
+				t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
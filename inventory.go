@@ -14,10 +14,10 @@ type Inventory struct {
 	Amount     int       `json:"amount"`
 	CreatedAt  time.Time `json:"created_at"`
 	CreatedBy  int       `json:"created_by"`
-	EntityID   int       `json:"entity_id"`
+	EntityID   EntityID  `json:"entity_id"`
 	ID         int       `json:"id"`
 	IsPrivate  bool      `json:"is_private"`
-	ItemID     int       `json:"item_id"`
+	ItemID     ItemID    `json:"item_id"`
 	Position   string    `json:"position"`
 	UpdatedAt  time.Time `json:"updated_at"`
 	UpdatedBy  int       `json:"updated_by"`
@@ -13,11 +13,11 @@ import (
 // For more information, visit: https://kanka.io/en-US/docs/1.0/items
 type Item struct {
 	SimpleItem
-	ID             int       `json:"id"`
+	ID             ItemID    `json:"id"`
 	ImageFull      string    `json:"image_full"`
 	ImageThumb     string    `json:"image_thumb"`
 	HasCustomImage bool      `json:"has_custom_image"`
-	EntityID       int       `json:"entity_id"`
+	EntityID       EntityID  `json:"entity_id"`
 	CreatedAt      time.Time `json:"created_at"`
 	CreatedBy      int       `json:"created_by"`
 	UpdatedAt      time.Time `json:"updated_at"`
@@ -34,17 +34,17 @@ type Item struct {
 // SimpleItem contains only the simple information about an item.
 // SimpleItem is primarily used to create new items for posting to Kanka.
 type SimpleItem struct {
-	Name        string `json:"name"`
-	Entry       string `json:"entry,omitempty"`
-	Type        string `json:"type,omitempty"`
-	Price       string `json:"price,omitempty"`
-	Size        string `json:"size,omitempty"`
-	LocationID  int    `json:"location_id,omitempty"`
-	CharacterID int    `json:"character_id,omitempty"`
-	Tags        []int  `json:"tags,omitempty"`
-	IsPrivate   bool   `json:"is_private,omitempty"`
-	Image       string `json:"image,omitempty"`
-	ImageURL    string `json:"image_url,omitempty"`
+	Name        string      `json:"name"`
+	Entry       string      `json:"entry,omitempty"`
+	Type        string      `json:"type,omitempty"`
+	Price       string      `json:"price,omitempty"`
+	Size        string      `json:"size,omitempty"`
+	LocationID  LocationID  `json:"location_id,omitempty"`
+	CharacterID CharacterID `json:"character_id,omitempty"`
+	Tags        []TagID     `json:"tags,omitempty"`
+	IsPrivate   bool        `json:"is_private,omitempty"`
+	Image       string      `json:"image,omitempty"`
+	ImageURL    string      `json:"image_url,omitempty"`
 }
 
 // MarshalJSON marshals the SimpleItem into its JSON-encoded form if it
@@ -58,12 +58,25 @@ func (si SimpleItem) MarshalJSON() ([]byte, error) {
 	return json.Marshal(alias(si))
 }
 
+// EntryMarkdown returns the SimpleItem's Entry converted into Markdown.
+func (si SimpleItem) EntryMarkdown() string {
+	return EntryToMarkdown(si.Entry)
+}
+
+// SetEntryMarkdown sets the SimpleItem's Entry to the HTML of the provided
+// Markdown.
+func (si *SimpleItem) SetEntryMarkdown(md string) {
+	si.Entry = MarkdownToEntry(md)
+}
+
 // ItemService handles communication with the Item endpoint.
 type ItemService service
 
 // Index returns the list of all Items in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return Items that have
 // been changed since that time.
+//
+// Deprecated: Use CampaignHandle.Items, which takes typed IDs.
 func (is *ItemService) Index(campID int, sync *time.Time) ([]*Item, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -89,6 +102,8 @@ func (is *ItemService) Index(campID int, sync *time.Time) ([]*Item, error) {
 
 // Get returns the Item associated with itemID from the Campaign
 // associated with campID.
+//
+// Deprecated: Use CampaignHandle.Items, which takes typed IDs.
 func (is *ItemService) Get(campID int, itemID int) (*Item, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -116,6 +131,8 @@ func (is *ItemService) Get(campID int, itemID int) (*Item, error) {
 // Create creates a new Item in the Campaign associated with campID using
 // the provided SimpleItem data.
 // Create returns the newly created Item.
+//
+// Deprecated: Use CampaignHandle.Items, which takes typed IDs.
 func (is *ItemService) Create(campID int, item SimpleItem) (*Item, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -143,6 +160,8 @@ func (is *ItemService) Create(campID int, item SimpleItem) (*Item, error) {
 // Update updates an existing Item associated with itemID from the
 // Campaign associated with campID using the provided SimpleItem data.
 // Update returns the newly updated Item.
+//
+// Deprecated: Use CampaignHandle.Items, which takes typed IDs.
 func (is *ItemService) Update(campID int, itemID int, item SimpleItem) (*Item, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -174,6 +193,8 @@ func (is *ItemService) Update(campID int, itemID int, item SimpleItem) (*Item, e
 
 // Delete deletes an existing Item associated with itemID from the
 // Campaign associated with campID.
+//
+// Deprecated: Use CampaignHandle.Items, which takes typed IDs.
 func (is *ItemService) Delete(campID int, itemID int) error {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
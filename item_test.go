@@ -137,7 +137,7 @@ func TestItemService_Get(t *testing.T) {
 			Entry:       "\n<p>Time dilation</p>\n",
 			Image:       "items/vyh5Fjzeij0gtdgsqjlJJOyTO4it6LG5l3ajTDNA.jpeg",
 			IsPrivate:   false,
-			Tags:        []int{34696},
+			Tags:        []TagID{34696},
 			LocationID:  111,
 			CharacterID: 222,
 			Type:        "Timepiece",
@@ -13,11 +13,11 @@ import (
 // For more information, visit: https://kanka.io/en-US/docs/1.0/journals
 type Journal struct {
 	SimpleJournal
-	ID             int       `json:"id"`
+	ID             JournalID `json:"id"`
 	ImageFull      string    `json:"image_full"`
 	ImageThumb     string    `json:"image_thumb"`
 	HasCustomImage bool      `json:"has_custom_image"`
-	EntityID       int       `json:"entity_id"`
+	EntityID       EntityID  `json:"entity_id"`
 	CreatedAt      time.Time `json:"created_at"`
 	CreatedBy      int       `json:"created_by"`
 	UpdatedAt      time.Time `json:"updated_at"`
@@ -34,16 +34,16 @@ type Journal struct {
 // SimpleJournal contains only the simple information about a journal.
 // SimpleJournal is primarily used to create new journals for posting to Kanka.
 type SimpleJournal struct {
-	Name        string `json:"name"`
-	Entry       string `json:"entry,omitempty"`
-	Type        string `json:"type,omitempty"`
-	Date        string `json:"date,omitempty"`
-	LocationID  int    `json:"location_id,omitempty"`
-	CharacterID int    `json:"character_id,omitempty"`
-	Tags        []int  `json:"tags,omitempty"`
-	IsPrivate   bool   `json:"is_private,omitempty"`
-	Image       string `json:"image,omitempty"`
-	ImageURL    string `json:"image_url,omitempty"`
+	Name        string      `json:"name"`
+	Entry       string      `json:"entry,omitempty"`
+	Type        string      `json:"type,omitempty"`
+	Date        string      `json:"date,omitempty"`
+	LocationID  LocationID  `json:"location_id,omitempty"`
+	CharacterID CharacterID `json:"character_id,omitempty"`
+	Tags        []TagID     `json:"tags,omitempty"`
+	IsPrivate   bool        `json:"is_private,omitempty"`
+	Image       string      `json:"image,omitempty"`
+	ImageURL    string      `json:"image_url,omitempty"`
 }
 
 // MarshalJSON marshals the SimpleJournal into its JSON-encoded form if it
@@ -57,12 +57,25 @@ func (sj SimpleJournal) MarshalJSON() ([]byte, error) {
 	return json.Marshal(alias(sj))
 }
 
+// EntryMarkdown returns the SimpleJournal's Entry converted into Markdown.
+func (sj SimpleJournal) EntryMarkdown() string {
+	return EntryToMarkdown(sj.Entry)
+}
+
+// SetEntryMarkdown sets the SimpleJournal's Entry to the HTML of the provided
+// Markdown.
+func (sj *SimpleJournal) SetEntryMarkdown(md string) {
+	sj.Entry = MarkdownToEntry(md)
+}
+
 // JournalService handles communication with the Journal endpoint.
 type JournalService service
 
 // Index returns the list of all Journals in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return Journals that have
 // been changed since that time.
+//
+// Deprecated: Use CampaignHandle.Journals, which takes typed IDs.
 func (js *JournalService) Index(campID int, sync *time.Time) ([]*Journal, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -88,6 +101,8 @@ func (js *JournalService) Index(campID int, sync *time.Time) ([]*Journal, error)
 
 // Get returns the Journal associated with jrnID from the Campaign
 // associated with campID.
+//
+// Deprecated: Use CampaignHandle.Journals, which takes typed IDs.
 func (js *JournalService) Get(campID int, jrnID int) (*Journal, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -115,6 +130,8 @@ func (js *JournalService) Get(campID int, jrnID int) (*Journal, error) {
 // Create creates a new Journal in the Campaign associated with campID using
 // the provided SimpleJournal data.
 // Create returns the newly created Journal.
+//
+// Deprecated: Use CampaignHandle.Journals, which takes typed IDs.
 func (js *JournalService) Create(campID int, jrn SimpleJournal) (*Journal, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -142,6 +159,8 @@ func (js *JournalService) Create(campID int, jrn SimpleJournal) (*Journal, error
 // Update updates an existing Journal associated with jrnID from the
 // Campaign associated with campID using the provided SimpleJournal data.
 // Update returns the newly updated Journal.
+//
+// Deprecated: Use CampaignHandle.Journals, which takes typed IDs.
 func (js *JournalService) Update(campID int, jrnID int, jrn SimpleJournal) (*Journal, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -173,6 +192,8 @@ func (js *JournalService) Update(campID int, jrnID int, jrn SimpleJournal) (*Jou
 
 // Delete deletes an existing Journal associated with jrnID from the
 // Campaign associated with campID.
+//
+// Deprecated: Use CampaignHandle.Journals, which takes typed IDs.
 func (js *JournalService) Delete(campID int, jrnID int) error {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -23,6 +23,7 @@ type Client struct {
 	http    *http.Client
 	rootURL string
 	token   string
+	limiter *rateLimiter
 
 	// Services
 	Profiles            *ProfileService
@@ -44,6 +45,7 @@ type Client struct {
 	QuestOrganizations  *QuestOrganizationService
 	Journals            *JournalService
 	Tags                *TagService
+	Calendars           *CalendarService
 
 	Attributes        *AttributeService
 	EntityEvents      *EntityEventService
@@ -65,6 +67,7 @@ func NewClient(token string, custom *http.Client) *Client {
 		http:    custom,
 		rootURL: kankaURL,
 		token:   token,
+		limiter: newRateLimiter(),
 	}
 
 	c.Profiles = &ProfileService{client: c, end: EndpointProfile}
@@ -86,6 +89,7 @@ func NewClient(token string, custom *http.Client) *Client {
 	c.QuestOrganizations = &QuestOrganizationService{client: c, end: EndpointQuestOrganization}
 	c.Journals = &JournalService{client: c, end: EndpointJournal}
 	c.Tags = &TagService{client: c, end: EndpointTag}
+	c.Calendars = &CalendarService{client: c, end: EndpointCalendar}
 
 	c.Attributes = &AttributeService{client: c, end: EndpointAttribute}
 	c.EntityEvents = &EntityEventService{client: c, end: EndpointEntityEvent}
@@ -116,6 +120,7 @@ func (c *Client) request(method string, end endpoint, body io.Reader) (*http.Req
 // send executes the provided request and stores the unmarshaled JSON result in
 // the provided empty interface.
 func (c *Client) send(req *http.Request, result interface{}) error {
+	c.limiter.wait()
 	resp, err := c.http.Do(req)
 	if err != nil {
 		return fmt.Errorf("http client cannot send request with method '%s' to url '%s': %w", req.Method, req.URL.String(), err)
@@ -202,6 +207,7 @@ func (c *Client) delete(end endpoint) error {
 		return err
 	}
 
+	c.limiter.wait()
 	resp, err := c.http.Do(req)
 	if err != nil {
 		return fmt.Errorf("http client cannot send request with method '%s' to url '%s': %w", req.Method, req.URL.String(), err)
@@ -0,0 +1,137 @@
+package kanka
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Holding is the amount of a single item held by a single entity across
+// all of the entity's stacks of the item.
+type Holding struct {
+	ItemID      ItemID
+	EntityID    EntityID
+	Type        EntityType
+	ID          ObjectID
+	Name        string
+	Amount      int
+	Inventories []*EntityInventory
+}
+
+// Ledger records which entities of a campaign hold which items.
+type Ledger struct {
+	holdings map[ItemID][]*Holding
+}
+
+// Ledger returns the Ledger of every inventory in the Campaign associated
+// with campID.
+func (c *Client) Ledger(campID CampaignID) (*Ledger, error) {
+	b := &Backup{}
+	if err := c.backupObjects(int(campID), b); err != nil {
+		return nil, fmt.Errorf("cannot get ledger of Campaign (ID: %d): %w", campID, err)
+	}
+
+	l := &Ledger{holdings: make(map[ItemID][]*Holding)}
+	for _, ref := range b.refs() {
+		invs, err := c.EntityInventories.Index(int(campID), ref.EntityID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get ledger of Campaign (ID: %d): %w", campID, err)
+		}
+
+		l.add(ref, invs)
+	}
+
+	return l, nil
+}
+
+// Ledger returns the Ledger of every inventory in the Backup.
+func (b *Backup) Ledger() *Ledger {
+	refs := make(map[int]entityRef)
+	for _, ref := range b.refs() {
+		refs[ref.EntityID] = ref
+	}
+
+	l := &Ledger{holdings: make(map[ItemID][]*Holding)}
+	for _, ent := range b.Entities {
+		ref, ok := refs[int(ent.EntityID)]
+		if !ok {
+			ref = entityRef{Type: ent.Type, ID: int(ent.ID), EntityID: int(ent.EntityID)}
+		}
+
+		l.add(ref, ent.Inventory)
+	}
+
+	return l
+}
+
+// add records the inventory of the referenced entity.
+func (l *Ledger) add(ref entityRef, invs []*EntityInventory) {
+	for _, inv := range invs {
+		var h *Holding
+		for _, x := range l.holdings[inv.ItemID] {
+			if int(x.EntityID) == ref.EntityID {
+				h = x
+				break
+			}
+		}
+
+		if h == nil {
+			h = &Holding{ItemID: inv.ItemID, EntityID: EntityID(ref.EntityID), Type: ref.Type, ID: ObjectID(ref.ID), Name: ref.Name}
+			l.holdings[inv.ItemID] = append(l.holdings[inv.ItemID], h)
+		}
+
+		h.Amount += inv.Amount
+		h.Inventories = append(h.Inventories, inv)
+	}
+}
+
+// Items returns the IDs of every item held by any entity, in ascending
+// order.
+func (l *Ledger) Items() []ItemID {
+	ids := make([]ItemID, 0, len(l.holdings))
+	for id := range l.holdings {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
+
+// Holders returns the Holding of every entity holding the item associated
+// with itemID, largest amount first and then by entity ID.
+func (l *Ledger) Holders(itemID ItemID) []*Holding {
+	hs := append([]*Holding(nil), l.holdings[itemID]...)
+	sort.SliceStable(hs, func(i, j int) bool {
+		if hs[i].Amount != hs[j].Amount {
+			return hs[i].Amount > hs[j].Amount
+		}
+		return hs[i].EntityID < hs[j].EntityID
+	})
+
+	return hs
+}
+
+// Total returns the amount of the item associated with itemID held across
+// the campaign.
+func (l *Ledger) Total(itemID ItemID) int {
+	var n int
+	for _, h := range l.holdings[itemID] {
+		n += h.Amount
+	}
+
+	return n
+}
+
+// Held returns the Holdings of the entity associated with entID, ordered by
+// item ID.
+func (l *Ledger) Held(entID EntityID) []*Holding {
+	var hs []*Holding
+	for _, id := range l.Items() {
+		for _, h := range l.holdings[id] {
+			if h.EntityID == entID {
+				hs = append(hs, h)
+			}
+		}
+	}
+
+	return hs
+}
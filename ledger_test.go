@@ -0,0 +1,85 @@
+package kanka
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// holdingKey summarizes a Holding for comparison.
+type holdingKey struct {
+	Name   string
+	Amount int
+	Stacks int
+}
+
+// holdingKeys returns the keys of the provided holdings.
+func holdingKeys(hs []*Holding) []holdingKey {
+	var keys []holdingKey
+	for _, h := range hs {
+		keys = append(keys, holdingKey{h.Name, h.Amount, len(h.Inventories)})
+	}
+	return keys
+}
+
+func TestBackup_Ledger(t *testing.T) {
+	inv := func(id, entID, itemID, amount int) *EntityInventory {
+		return &EntityInventory{ID: id, SimpleEntityInventory: SimpleEntityInventory{EntityID: EntityID(entID), ItemID: ItemID(itemID), Amount: amount}}
+	}
+
+	b := &Backup{
+		Characters: []*Character{
+			{ID: 30, EntityID: 130, SimpleCharacter: SimpleCharacter{Name: "Arya Stark"}},
+			{ID: 31, EntityID: 131, SimpleCharacter: SimpleCharacter{Name: "Jon Snow"}},
+		},
+		Locations: []*Location{
+			{ID: 10, EntityID: 110, SimpleLocation: SimpleLocation{Name: "Winterfell"}},
+		},
+		Entities: []*EntityData{
+			{EntityID: 130, Inventory: []*EntityInventory{inv(1, 130, 40, 1), inv(2, 130, 41, 3), inv(3, 130, 41, 2)}},
+			{EntityID: 131, Inventory: []*EntityInventory{inv(4, 131, 41, 5)}},
+			{EntityID: 110, Inventory: []*EntityInventory{inv(5, 110, 41, 20)}},
+		},
+	}
+
+	l := b.Ledger()
+
+	want := []holdingKey{{"Winterfell", 20, 1}, {"Arya Stark", 5, 2}, {"Jon Snow", 5, 1}}
+	if diff := cmp.Diff(want, holdingKeys(l.Holders(41))); diff != "" {
+		t.Errorf("holders mismatch (-want +got):\n%s", diff)
+	}
+	if got := l.Total(41); got != 30 {
+		t.Errorf("got total <%d>, want <%d>", got, 30)
+	}
+	if diff := cmp.Diff([]ItemID{40, 41}, l.Items()); diff != "" {
+		t.Errorf("items mismatch (-want +got):\n%s", diff)
+	}
+	if got := l.Held(130); len(got) != 2 || got[0].ItemID != 40 || got[1].Amount != 5 {
+		t.Errorf("got holdings <%v>, want Item 40 and 5 of Item 41", holdingKeys(got))
+	}
+	if got := l.Holders(99); len(got) != 0 {
+		t.Errorf("got <%d> holders of unknown item, want none", len(got))
+	}
+}
+
+func TestClient_Ledger(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+
+	l, err := c.Ledger(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff([]holdingKey{{"Arya Stark", 1, 1}}, holdingKeys(l.Holders(40))); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	fk.fail["GET /campaigns/1/entities/130/inventory"] = http.StatusInternalServerError
+	if _, err = c.Ledger(1); err == nil {
+		t.Error("got nil error, want error")
+	}
+}
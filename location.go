@@ -13,16 +13,16 @@ import (
 // For more information, visit: https://kanka.io/en-US/docs/1.0/locations
 type Location struct {
 	SimpleLocation
-	ID             int       `json:"id"`
-	ImageFull      string    `json:"image_full"`
-	ImageThumb     string    `json:"image_thumb"`
-	IsMapPrivate   int       `json:"is_map_private"`
-	HasCustomImage bool      `json:"has_custom_image"`
-	EntityID       int       `json:"entity_id"`
-	CreatedAt      time.Time `json:"created_at"`
-	CreatedBy      int       `json:"created_by"`
-	UpdatedAt      time.Time `json:"updated_at"`
-	UpdatedBy      int       `json:"updated_by"`
+	ID             LocationID `json:"id"`
+	ImageFull      string     `json:"image_full"`
+	ImageThumb     string     `json:"image_thumb"`
+	IsMapPrivate   int        `json:"is_map_private"`
+	HasCustomImage bool       `json:"has_custom_image"`
+	EntityID       EntityID   `json:"entity_id"`
+	CreatedAt      time.Time  `json:"created_at"`
+	CreatedBy      int        `json:"created_by"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	UpdatedBy      int        `json:"updated_by"`
 
 	Attributes   Attributes   `json:"attributes"`
 	EntityEvents EntityEvents `json:"entity_events"`
@@ -36,16 +36,16 @@ type Location struct {
 // SimpleLocation is primarily used to create new Locations for posting to
 // Kanka.
 type SimpleLocation struct {
-	Name             string `json:"name"`
-	Entry            string `json:"entry,omitempty"`
-	Type             string `json:"type,omitempty"`
-	ParentLocationID int    `json:"parent_location_id,omitempty"`
-	Tags             []int  `json:"tags,omitempty"`
-	IsPrivate        bool   `json:"is_private,omitempty"`
-	Image            string `json:"image,omitempty"`
-	ImageURL         string `json:"image_url,omitempty"`
-	Map              string `json:"map,omitempty"`
-	MapURL           string `json:"map_url,omitempty"`
+	Name             string     `json:"name"`
+	Entry            string     `json:"entry,omitempty"`
+	Type             string     `json:"type,omitempty"`
+	ParentLocationID LocationID `json:"parent_location_id,omitempty"`
+	Tags             []TagID    `json:"tags,omitempty"`
+	IsPrivate        bool       `json:"is_private,omitempty"`
+	Image            string     `json:"image,omitempty"`
+	ImageURL         string     `json:"image_url,omitempty"`
+	Map              string     `json:"map,omitempty"`
+	MapURL           string     `json:"map_url,omitempty"`
 }
 
 // MarshalJSON marshals the SimpleLocation into its JSON-encoded form if it has
@@ -59,12 +59,25 @@ func (sl SimpleLocation) MarshalJSON() ([]byte, error) {
 	return json.Marshal(alias(sl))
 }
 
+// EntryMarkdown returns the SimpleLocation's Entry converted into Markdown.
+func (sl SimpleLocation) EntryMarkdown() string {
+	return EntryToMarkdown(sl.Entry)
+}
+
+// SetEntryMarkdown sets the SimpleLocation's Entry to the HTML of the provided
+// Markdown.
+func (sl *SimpleLocation) SetEntryMarkdown(md string) {
+	sl.Entry = MarkdownToEntry(md)
+}
+
 // LocationService handles communication with the Location endpoint.
 type LocationService service
 
 // Index returns the list of all Locations in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return Locations that have
 // been changed since that time.
+//
+// Deprecated: Use CampaignHandle.Locations, which takes typed IDs.
 func (ls *LocationService) Index(campID int, sync *time.Time) ([]*Location, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -90,6 +103,8 @@ func (ls *LocationService) Index(campID int, sync *time.Time) ([]*Location, erro
 
 // Get returns the Location associated with locID from the Campaign
 // associated with campID.
+//
+// Deprecated: Use CampaignHandle.Locations, which takes typed IDs.
 func (ls *LocationService) Get(campID int, locID int) (*Location, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -117,6 +132,8 @@ func (ls *LocationService) Get(campID int, locID int) (*Location, error) {
 // Create creates a new Location in the Campaign associated with campID using
 // the provided SimpleLocation data.
 // Create returns the newly created Location.
+//
+// Deprecated: Use CampaignHandle.Locations, which takes typed IDs.
 func (ls *LocationService) Create(campID int, loc SimpleLocation) (*Location, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -144,6 +161,8 @@ func (ls *LocationService) Create(campID int, loc SimpleLocation) (*Location, er
 // Update updates an existing Location associated with locID from the
 // Campaign associated with campID using the provided SimpleLocation data.
 // Update returns the newly updated Location.
+//
+// Deprecated: Use CampaignHandle.Locations, which takes typed IDs.
 func (ls *LocationService) Update(campID int, locID int, loc SimpleLocation) (*Location, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -175,6 +194,8 @@ func (ls *LocationService) Update(campID int, locID int, loc SimpleLocation) (*L
 
 // Delete deletes an existing Location associated with locID from the
 // Campaign associated with campID.
+//
+// Deprecated: Use CampaignHandle.Locations, which takes typed IDs.
 func (ls *LocationService) Delete(campID int, locID int) error {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
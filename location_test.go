@@ -137,7 +137,7 @@ func TestLocationService_Get(t *testing.T) {
 			Entry:            "\n<p>The jewel of the North.</p>\n",
 			Image:            "locations/ox87nkFQWMn9tTpLuXNk56fq0Du2V3HjocFl9ROY.jpeg",
 			IsPrivate:        false,
-			Tags:             []int{35115},
+			Tags:             []TagID{35115},
 			Type:             "Castle",
 			Map:              "locations/7MHptkcOx4MpyAxPokfZCB4bGVDLEXq9nCHe2Tex.jpeg",
 			ParentLocationID: 115366,
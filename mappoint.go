@@ -20,15 +20,15 @@ type MapPoint struct {
 // SimpleMapPoint contains only the simple information about a map point.
 // SimpleMapPoint is primarily used to create new map points for posting to Kanka.
 type SimpleMapPoint struct {
-	LocationID     int    `json:"location_id"`
-	TargetEntityID int    `json:"target_entity_id,omitempty"`
-	Name           string `json:"name,omitempty"`
-	AxisX          int    `json:"axis_x"`
-	AxisY          int    `json:"axis_y"`
-	Color          string `json:"colour"`
-	Icon           string `json:"icon"`
-	Shape          string `json:"shape"`
-	Size           string `json:"size"`
+	LocationID     LocationID `json:"location_id"`
+	TargetEntityID EntityID   `json:"target_entity_id,omitempty"`
+	Name           string     `json:"name,omitempty"`
+	AxisX          int        `json:"axis_x"`
+	AxisY          int        `json:"axis_y"`
+	Color          string     `json:"colour"`
+	Icon           string     `json:"icon"`
+	Shape          string     `json:"shape"`
+	Size           string     `json:"size"`
 }
 
 // MarshalJSON marshals the SimpleMapPoint into its JSON-encoded form if it
@@ -58,6 +58,8 @@ type MapPointService service
 // locID in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return MapPoints that have
 // been changed since that time.
+//
+// Deprecated: Use CampaignHandle.MapPoints, which takes typed IDs.
 func (ms *MapPointService) Index(campID int, locID int, sync *time.Time) ([]*MapPoint, error) {
 	var err error
 	end := EndpointCampaign
@@ -90,6 +92,8 @@ func (ms *MapPointService) Index(campID int, locID int, sync *time.Time) ([]*Map
 // Create creates a new MapPoint for the location associated with locID in the
 // Campaign associated with campID using the provided SimpleMapPoint data.
 // Create returns the newly created MapPoint.
+//
+// Deprecated: Use CampaignHandle.MapPoints, which takes typed IDs.
 func (ms *MapPointService) Create(campID int, locID int, mp SimpleMapPoint) (*MapPoint, error) {
 	var err error
 	end := EndpointCampaign
@@ -0,0 +1,827 @@
+package kanka
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// EntryToMarkdown converts the provided entry HTML into Markdown.
+//
+// Headings, paragraphs, emphasis, links, images, lists, tables, block
+// quotes, and preformatted text are converted to their Markdown equivalents.
+// Spoilers are written as ||text|| and mentions are kept as they are. Tags
+// without a Markdown equivalent are dropped while keeping their text.
+func EntryToMarkdown(entry string) string {
+	return strings.Join(mdBlocks(parseHTML(entry).Children), "\n\n")
+}
+
+// MarkdownToEntry converts the provided Markdown into entry HTML.
+// MarkdownToEntry understands the subset of Markdown written by
+// EntryToMarkdown, including GitHub-style tables and strikethrough.
+func MarkdownToEntry(md string) string {
+	md = strings.ReplaceAll(md, "\r\n", "\n")
+	return htmlBlocks(strings.Split(md, "\n"))
+}
+
+// mdBlocks returns the Markdown blocks of the provided nodes. Runs of inline
+// nodes are gathered into paragraphs.
+func mdBlocks(nodes []*htmlNode) []string {
+	var blocks []string
+	var inline []*htmlNode
+
+	flush := func() {
+		if p := mdParagraph(mdInline(inline)); p != "" {
+			blocks = append(blocks, p)
+		}
+		inline = nil
+	}
+
+	for _, n := range nodes {
+		switch n.Tag {
+		case "p", "div", "section", "article", "details", "summary":
+			flush()
+			blocks = append(blocks, mdBlocks(n.Children)...)
+		case "h1", "h2", "h3", "h4", "h5", "h6":
+			flush()
+			level := int(n.Tag[1] - '0')
+			blocks = append(blocks, strings.Repeat("#", level)+" "+mdLine(mdInline(n.Children)))
+		case "ul", "ol":
+			flush()
+			if l := mdList(n); l != "" {
+				blocks = append(blocks, l)
+			}
+		case "table":
+			flush()
+			if t := mdTable(n); t != "" {
+				blocks = append(blocks, t)
+			}
+		case "blockquote":
+			flush()
+			inner := strings.Join(mdBlocks(n.Children), "\n\n")
+			blocks = append(blocks, prefixLines(inner, "> ", ">"))
+		case "pre":
+			flush()
+			blocks = append(blocks, "```\n"+strings.Trim(n.text(), "\n")+"\n```")
+		case "hr":
+			flush()
+			blocks = append(blocks, "---")
+		default:
+			inline = append(inline, n)
+		}
+	}
+	flush()
+
+	return blocks
+}
+
+// mdParagraph tidies the lines of a paragraph and escapes the characters at
+// the start of each line which would otherwise begin a block. Line breaks at the start and end of the paragraph are dropped.
+func mdParagraph(s string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(s), "\n") {
+		line = strings.TrimSpace(line)
+		if len(lines) == 0 && (line == "" || line == `\`) {
+			continue
+		}
+		lines = append(lines, escapeLineStart(line))
+	}
+
+	for len(lines) > 0 {
+		last := lines[len(lines)-1]
+		if !hardBreak(last) {
+			break
+		}
+		if last = strings.TrimSpace(last[:len(last)-1]); last != "" {
+			lines[len(lines)-1] = last
+			break
+		}
+		lines = lines[:len(lines)-1]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// hardBreak returns true if line ends with an unescaped backslash.
+func hardBreak(line string) bool {
+	n := len(line) - len(strings.TrimRight(line, `\`))
+	return n%2 == 1
+}
+
+// mdLine returns s on a single line.
+func mdLine(s string) string {
+	s = strings.ReplaceAll(s, "\\\n", " ")
+	return strings.TrimSpace(strings.ReplaceAll(s, "\n", " "))
+}
+
+var orderedStart = regexp.MustCompile(`^(\d+)\. `)
+
+// escapeLineStart escapes the first character of line if it would begin a
+// heading, block quote, list, or rule.
+func escapeLineStart(line string) string {
+	if line == "" {
+		return line
+	}
+
+	switch line[0] {
+	case '#', '>', '-', '+':
+		return `\` + line
+	}
+
+	if m := orderedStart.FindStringSubmatch(line); m != nil {
+		return m[1] + `\` + line[len(m[1]):]
+	}
+
+	return line
+}
+
+// mdInline returns the Markdown of the provided inline nodes.
+func mdInline(nodes []*htmlNode) string {
+	var b strings.Builder
+
+	for _, n := range nodes {
+		switch n.Tag {
+		case "":
+			b.WriteString(escapeMarkdown(collapseSpace(n.Text)))
+		case "br":
+			b.WriteString("\\\n")
+		case "strong", "b":
+			b.WriteString(wrapInline(mdInline(n.Children), "**", "**"))
+		case "em", "i":
+			b.WriteString(wrapInline(mdInline(n.Children), "*", "*"))
+		case "s", "del", "strike":
+			b.WriteString(wrapInline(mdInline(n.Children), "~~", "~~"))
+		case "code":
+			b.WriteString(wrapInline(strings.ReplaceAll(n.text(), "`", ""), "`", "`"))
+		case "a":
+			href, ok := n.Attrs["href"]
+			if !ok {
+				b.WriteString(mdInline(n.Children))
+				break
+			}
+			b.WriteString(wrapInline(mdInline(n.Children), "[", "]("+escapeURL(href)+")"))
+		case "img":
+			b.WriteString("![" + escapeMarkdown(n.Attrs["alt"]) + "](" + escapeURL(n.Attrs["src"]) + ")")
+		case "span":
+			if hasClass(n, "spoiler") {
+				b.WriteString(wrapInline(mdInline(n.Children), "||", "||"))
+				break
+			}
+			b.WriteString(mdInline(n.Children))
+		case "script", "style":
+		default:
+			b.WriteString(mdInline(n.Children))
+		}
+	}
+
+	return b.String()
+}
+
+// wrapInline surrounds s with open and close, keeping the whitespace around
+// s outside of them. Empty content is left unwrapped.
+func wrapInline(s, open, close string) string {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return s
+	}
+
+	lead := s[:strings.Index(s, trimmed)]
+	trail := s[len(lead)+len(trimmed):]
+
+	return lead + open + trimmed + close + trail
+}
+
+// hasClass returns true if the node has the provided class.
+func hasClass(n *htmlNode, class string) bool {
+	for _, c := range strings.Fields(n.Attrs["class"]) {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+var mdEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+	"~", `\~`,
+	"|", `\|`,
+	"[", `\[`,
+	"]", `\]`,
+)
+
+// escapeMarkdown escapes the Markdown syntax in s outside of mentions.
+func escapeMarkdown(s string) string {
+	var b strings.Builder
+	for _, n := range ParseEntry(s) {
+		switch n := n.(type) {
+		case Text:
+			b.WriteString(mdEscaper.Replace(string(n)))
+		case *Mention:
+			b.WriteString(n.String())
+		}
+	}
+
+	return b.String()
+}
+
+var urlEscaper = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29")
+
+// escapeURL escapes the characters which would end a Markdown link early.
+func escapeURL(s string) string {
+	return urlEscaper.Replace(s)
+}
+
+// prefixLines prefixes every line of s with prefix, or with blank if the
+// line is empty.
+func prefixLines(s, prefix, blank string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = blank
+			continue
+		}
+		lines[i] = prefix + line
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// mdList returns the Markdown of the provided ul or ol node. Nested blocks
+// are indented under their items.
+func mdList(list *htmlNode) string {
+	num := 1
+	if start, err := strconv.Atoi(list.Attrs["start"]); err == nil {
+		num = start
+	}
+
+	var items []string
+	for _, li := range list.Children {
+		if li.Tag != "li" {
+			continue
+		}
+
+		marker := "- "
+		if list.Tag == "ol" {
+			marker = strconv.Itoa(num) + ". "
+			num++
+		}
+
+		body := strings.Join(mdBlocks(li.Children), "\n")
+		if i := strings.IndexByte(body, '\n'); i >= 0 {
+			body = body[:i+1] + prefixLines(body[i+1:], strings.Repeat(" ", len(marker)), "")
+		}
+		items = append(items, strings.TrimRight(marker+body, " "))
+	}
+
+	return strings.Join(items, "\n")
+}
+
+// mdTable returns the Markdown of the provided table node. The first row is
+// used as the header of the table.
+func mdTable(table *htmlNode) string {
+	var rows [][]string
+	cols := 0
+
+	var walk func(n *htmlNode)
+	walk = func(n *htmlNode) {
+		for _, c := range n.Children {
+			switch c.Tag {
+			case "thead", "tbody", "tfoot":
+				walk(c)
+			case "tr":
+				var row []string
+				for _, cell := range c.Children {
+					if cell.Tag == "td" || cell.Tag == "th" {
+						row = append(row, mdLine(mdInline(cell.Children)))
+					}
+				}
+				if len(row) > cols {
+					cols = len(row)
+				}
+				rows = append(rows, row)
+			}
+		}
+	}
+	walk(table)
+
+	if len(rows) == 0 || cols == 0 {
+		return ""
+	}
+
+	lines := make([]string, 0, len(rows)+1)
+	for i, row := range rows {
+		for len(row) < cols {
+			row = append(row, "")
+		}
+		lines = append(lines, "| "+strings.Join(row, " | ")+" |")
+
+		if i == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", cols))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// htmlBlocks returns the entry HTML of the provided lines of Markdown.
+func htmlBlocks(lines []string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(lines); {
+		line := strings.TrimSpace(lines[i])
+
+		switch {
+		case line == "":
+			i++
+		case strings.HasPrefix(line, "```"):
+			i++
+			var code []string
+			for ; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			i++
+			b.WriteString("<pre>" + escapeText(strings.Join(code, "\n")) + "</pre>")
+		case headingLevel(line) > 0:
+			level := headingLevel(line)
+			text := strings.TrimSpace(line[level:])
+			fmt.Fprintf(&b, "<h%d>%s</h%d>", level, htmlInline(text), level)
+			i++
+		case isRule(line):
+			b.WriteString("<hr>")
+			i++
+		case strings.HasPrefix(line, ">"):
+			var quote []string
+			for ; i < len(lines); i++ {
+				l := strings.TrimSpace(lines[i])
+				if !strings.HasPrefix(l, ">") {
+					break
+				}
+				l = strings.TrimPrefix(l[1:], " ")
+				quote = append(quote, l)
+			}
+			b.WriteString("<blockquote>" + htmlBlocks(quote) + "</blockquote>")
+		case isTableStart(lines, i):
+			i = htmlTable(&b, lines, i)
+		case isListItem(lines[i]):
+			i = htmlList(&b, lines, i)
+		default:
+			var para []string
+			for ; i < len(lines); i++ {
+				if i > 0 && len(para) > 0 && isBlockStart(lines, i) {
+					break
+				}
+				para = append(para, strings.TrimSpace(lines[i]))
+			}
+			b.WriteString("<p>" + htmlInline(strings.Join(para, "\n")) + "</p>")
+		}
+	}
+
+	return b.String()
+}
+
+// isBlockStart returns true if the line at index i ends a paragraph.
+func isBlockStart(lines []string, i int) bool {
+	line := strings.TrimSpace(lines[i])
+
+	return line == "" ||
+		strings.HasPrefix(line, "```") ||
+		strings.HasPrefix(line, ">") ||
+		headingLevel(line) > 0 ||
+		isRule(line) ||
+		isListItem(lines[i]) ||
+		isTableStart(lines, i)
+}
+
+// headingLevel returns the level of the ATX heading on line, or 0 if line
+// is not a heading.
+func headingLevel(line string) int {
+	n := 0
+	for n < len(line) && line[n] == '#' {
+		n++
+	}
+	if n == 0 || n > 6 || (n < len(line) && line[n] != ' ') {
+		return 0
+	}
+
+	return n
+}
+
+// isRule returns true if line is a thematic break.
+func isRule(line string) bool {
+	s := strings.ReplaceAll(line, " ", "")
+	if len(s) < 3 {
+		return false
+	}
+
+	return strings.Trim(s, "-") == "" || strings.Trim(s, "*") == "" || strings.Trim(s, "_") == ""
+}
+
+var listItemPattern = regexp.MustCompile(`^( *)([-*+]|\d+\.)( +|$)`)
+
+// listItem parses the list marker at the start of line. It returns the
+// indentation of the marker, whether the list is ordered, the number of an
+// ordered item, and the width of the indentation and marker together.
+func listItem(line string) (indent int, ordered bool, num int, width int, ok bool) {
+	m := listItemPattern.FindStringSubmatch(line)
+	if m == nil || isRule(strings.TrimSpace(line)) {
+		return 0, false, 0, 0, false
+	}
+
+	indent = len(m[1])
+	if strings.HasSuffix(m[2], ".") {
+		ordered = true
+		num, _ = strconv.Atoi(strings.TrimSuffix(m[2], "."))
+	}
+
+	return indent, ordered, num, len(m[0]), true
+}
+
+// isListItem returns true if line starts with a list marker.
+func isListItem(line string) bool {
+	_, _, _, _, ok := listItem(line)
+	return ok
+}
+
+// htmlList writes the list starting at index i and returns the index of the
+// first line after it.
+func htmlList(b *strings.Builder, lines []string, i int) int {
+	base, ordered, start, _, _ := listItem(lines[i])
+
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+	}
+	if ordered && start != 1 {
+		fmt.Fprintf(b, `<ol start="%d">`, start)
+	} else {
+		b.WriteString("<" + tag + ">")
+	}
+
+	var item []string
+	writeItem := func() {
+		if item == nil {
+			return
+		}
+
+		n := 0
+		for n < len(item) && (n == 0 || !isBlockStart(item, n)) {
+			n++
+		}
+		b.WriteString("<li>" + htmlInline(strings.TrimSpace(strings.Join(item[:n], "\n"))) + htmlBlocks(item[n:]) + "</li>")
+		item = nil
+	}
+
+	width := 0
+	for ; i < len(lines); i++ {
+		line := lines[i]
+
+		if strings.TrimSpace(line) == "" {
+			next := i + 1
+			for next < len(lines) && strings.TrimSpace(lines[next]) == "" {
+				next++
+			}
+			if next < len(lines) && indentOf(lines[next]) > base {
+				item = append(item, "")
+				continue
+			}
+			if in, ord, _, _, ok := listItem(safeLine(lines, next)); ok && in == base && ord == ordered {
+				continue
+			}
+			break
+		}
+
+		if in, ord, _, w, ok := listItem(line); ok && in == base {
+			if ord != ordered {
+				break
+			}
+			writeItem()
+			width = w
+			item = []string{line[w:]}
+			continue
+		}
+
+		if indentOf(line) > base {
+			item = append(item, line[minInt(indentOf(line), width):])
+			continue
+		}
+
+		if isBlockStart(lines, i) {
+			break
+		}
+		item = append(item, strings.TrimSpace(line))
+	}
+	writeItem()
+
+	b.WriteString("</" + tag + ">")
+
+	return i
+}
+
+// indentOf returns the number of leading spaces of line.
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// safeLine returns the line at index i, or an empty string if there is none.
+func safeLine(lines []string, i int) string {
+	if i < len(lines) {
+		return lines[i]
+	}
+	return ""
+}
+
+var delimiterCell = regexp.MustCompile(`^:?-+:?$`)
+
+// isTableStart returns true if the line at index i begins a table, that is,
+// a row of cells followed by a delimiter row.
+func isTableStart(lines []string, i int) bool {
+	if i+1 >= len(lines) || !strings.HasPrefix(strings.TrimSpace(lines[i]), "|") {
+		return false
+	}
+
+	cells := splitRow(lines[i+1])
+	if len(cells) == 0 {
+		return false
+	}
+	for _, c := range cells {
+		if !delimiterCell.MatchString(c) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// htmlTable writes the table starting at index i and returns the index of
+// the first line after it.
+func htmlTable(b *strings.Builder, lines []string, i int) int {
+	head := splitRow(lines[i])
+
+	b.WriteString("<table><thead><tr>")
+	for _, c := range head {
+		b.WriteString("<th>" + htmlInline(c) + "</th>")
+	}
+	b.WriteString("</tr></thead>")
+
+	i += 2
+	body := false
+	for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+		if !body {
+			b.WriteString("<tbody>")
+			body = true
+		}
+
+		cells := splitRow(lines[i])
+		b.WriteString("<tr>")
+		for j := range head {
+			c := ""
+			if j < len(cells) {
+				c = cells[j]
+			}
+			b.WriteString("<td>" + htmlInline(c) + "</td>")
+		}
+		b.WriteString("</tr>")
+	}
+	if body {
+		b.WriteString("</tbody>")
+	}
+	b.WriteString("</table>")
+
+	return i
+}
+
+// splitRow splits a table row into its trimmed cells. Escaped pipes and
+// pipes within brackets, such as those of mentions, do not split cells.
+func splitRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	var cells []string
+	depth, last := 0, 0
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			if depth > 0 {
+				depth--
+			}
+		case '|':
+			if depth == 0 {
+				cells = append(cells, strings.TrimSpace(line[last:i]))
+				last = i + 1
+			}
+		}
+	}
+	cells = append(cells, strings.TrimSpace(line[last:]))
+
+	return cells
+}
+
+var (
+	textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	attrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+)
+
+// escapeText escapes s for use as HTML text.
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// escapeAttr escapes s for use as an HTML attribute value.
+func escapeAttr(s string) string {
+	return attrEscaper.Replace(s)
+}
+
+// isPunct returns true if c is ASCII punctuation and may be escaped.
+func isPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+// htmlInline returns the entry HTML of the provided inline Markdown.
+func htmlInline(s string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(s); {
+		c := s[i]
+
+		switch {
+		case c == '\\' && i+1 < len(s) && s[i+1] == '\n':
+			b.WriteString("<br>")
+			i += 2
+			continue
+		case c == '\\' && i+1 < len(s) && isPunct(s[i+1]):
+			b.WriteString(escapeText(s[i+1 : i+2]))
+			i += 2
+			continue
+		case c == '\n':
+			b.WriteString(" ")
+			i++
+			continue
+		case c == '`':
+			if end := strings.IndexByte(s[i+1:], '`'); end >= 0 {
+				b.WriteString("<code>" + escapeText(s[i+1:i+1+end]) + "</code>")
+				i += end + 2
+				continue
+			}
+		case c == '!' && strings.HasPrefix(s[i:], "!["):
+			if text, url, n, ok := parseLink(s[i+1:]); ok {
+				fmt.Fprintf(&b, `<img src="%s" alt="%s">`, escapeAttr(url), escapeAttr(unescapeMarkdown(text)))
+				i += n + 1
+				continue
+			}
+		case c == '[':
+			if loc := mentionPattern.FindStringIndex(s[i:]); loc != nil && loc[0] == 0 {
+				b.WriteString(escapeText(s[i : i+loc[1]]))
+				i += loc[1]
+				continue
+			}
+			if text, url, n, ok := parseLink(s[i:]); ok {
+				fmt.Fprintf(&b, `<a href="%s">%s</a>`, escapeAttr(url), htmlInline(text))
+				i += n
+				continue
+			}
+		case c == '*' || c == '_' || c == '~' || c == '|':
+			if n, ok := htmlEmphasis(&b, s, i); ok {
+				i = n
+				continue
+			}
+		}
+
+		b.WriteString(escapeText(s[i : i+1]))
+		i++
+	}
+
+	return b.String()
+}
+
+// emphases lists the delimiters of inline spans and their HTML tags, longest
+// delimiters first.
+var emphases = []struct {
+	delim string
+	open  string
+	close string
+}{
+	{"**", "<strong>", "</strong>"},
+	{"__", "<strong>", "</strong>"},
+	{"~~", "<s>", "</s>"},
+	{"||", `<span class="spoiler">`, "</span>"},
+	{"*", "<em>", "</em>"},
+	{"_", "<em>", "</em>"},
+}
+
+// htmlEmphasis writes the inline span starting at index i of s, if any, and
+// returns the index following it.
+func htmlEmphasis(b *strings.Builder, s string, i int) (int, bool) {
+	for _, e := range emphases {
+		if !strings.HasPrefix(s[i:], e.delim) {
+			continue
+		}
+		if e.delim[0] == '_' && i > 0 && isWordByte(s[i-1]) {
+			return 0, false
+		}
+
+		start := i + len(e.delim)
+		if start >= len(s) || s[start] == ' ' {
+			return 0, false
+		}
+
+		end := findClose(s, start, e.delim)
+		if end < 0 {
+			if len(e.delim) == 2 {
+				continue
+			}
+			return 0, false
+		}
+
+		b.WriteString(e.open + htmlInline(s[start:end]) + e.close)
+		return end + len(e.delim), true
+	}
+
+	return 0, false
+}
+
+// findClose returns the index of the delimiter closing a span which starts
+// at index start of s, or -1 if there is none. Escaped characters and, for
+// single-character delimiters, doubled delimiters are skipped.
+func findClose(s string, start int, delim string) int {
+	for j := start; j < len(s); j++ {
+		switch {
+		case s[j] == '\\':
+			j++
+		case len(delim) == 1 && strings.HasPrefix(s[j:], delim+delim):
+			j++
+		case strings.HasPrefix(s[j:], delim) && j > start && s[j-1] != ' ':
+			if delim[0] == '_' && j+len(delim) < len(s) && isWordByte(s[j+len(delim)]) {
+				continue
+			}
+			for len(delim) == 2 && j+2 < len(s) && s[j+2] == delim[0] {
+				j++
+			}
+			return j
+		}
+	}
+
+	return -1
+}
+
+// isWordByte returns true if c is an ASCII letter or digit.
+func isWordByte(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// parseLink parses a Markdown link of the form [text](url) at the start of
+// s. It returns the link's text and URL and the length of the link.
+func parseLink(s string) (text string, url string, n int, ok bool) {
+	if !strings.HasPrefix(s, "[") {
+		return "", "", 0, false
+	}
+
+	depth := 0
+	for j := 0; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth > 0 {
+				continue
+			}
+			if !strings.HasPrefix(s[j+1:], "(") {
+				return "", "", 0, false
+			}
+			end := strings.IndexByte(s[j+2:], ')')
+			if end < 0 {
+				return "", "", 0, false
+			}
+			url = strings.TrimSpace(s[j+2 : j+2+end])
+			return s[1:j], url, j + 3 + end, true
+		}
+	}
+
+	return "", "", 0, false
+}
+
+// unescapeMarkdown removes the backslashes escaping punctuation in s.
+func unescapeMarkdown(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && isPunct(s[i+1]) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+
+	return b.String()
+}
@@ -0,0 +1,143 @@
+package kanka
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+const (
+	testEntryCharacter string = "test_data/entry_character"
+	testEntryLocation  string = "test_data/entry_location"
+	testEntryKanka     string = "test_data/entry_kanka"
+)
+
+// readEntryFixture returns the HTML and Markdown fixtures with the provided
+// base name.
+func readEntryFixture(t *testing.T, name string) (string, string) {
+	h, err := ioutil.ReadFile(name + ".html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	md, err := ioutil.ReadFile(name + ".md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(h), string(md)
+}
+
+func TestEntryToMarkdown_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+	}{
+		{"Character entry", testEntryCharacter},
+		{"Location entry", testEntryLocation},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h, md := readEntryFixture(t, test.file)
+
+			if got := EntryToMarkdown(h); got != md {
+				t.Errorf("got Markdown:\n%s\nwant:\n%s", got, md)
+			}
+			if got := MarkdownToEntry(md); got != h {
+				t.Errorf("got HTML:\n%s\nwant:\n%s", got, h)
+			}
+		})
+	}
+}
+
+func TestEntryToMarkdown_Editor(t *testing.T) {
+	h, md := readEntryFixture(t, testEntryKanka)
+
+	got := EntryToMarkdown(h)
+	if got != md {
+		t.Errorf("got Markdown:\n%s\nwant:\n%s", got, md)
+	}
+
+	if again := EntryToMarkdown(MarkdownToEntry(got)); again != got {
+		t.Errorf("got Markdown after round trip:\n%s\nwant:\n%s", again, got)
+	}
+}
+
+func TestMarkdownToEntry(t *testing.T) {
+	tests := []struct {
+		name string
+		md   string
+		want string
+	}{
+		{
+			name: "Empty",
+			md:   "",
+			want: "",
+		},
+		{
+			name: "Soft line breaks",
+			md:   "one\ntwo\r\nthree",
+			want: "<p>one two three</p>",
+		},
+		{
+			name: "Nested emphasis",
+			md:   "***both*** and **bold *italic***",
+			want: "<p><strong><em>both</em></strong> and <strong>bold <em>italic</em></strong></p>",
+		},
+		{
+			name: "Intraword underscores",
+			md:   "snake_case_name and _emphasis_",
+			want: "<p>snake_case_name and <em>emphasis</em></p>",
+		},
+		{
+			name: "Unclosed delimiters",
+			md:   "2 * 3 and **open",
+			want: "<p>2 * 3 and **open</p>",
+		},
+		{
+			name: "Inline code",
+			md:   "use `<b>` tags",
+			want: "<p>use <code>&lt;b&gt;</code> tags</p>",
+		},
+		{
+			name: "Ordered list start",
+			md:   "3. three\n4. four",
+			want: `<ol start="3"><li>three</li><li>four</li></ol>`,
+		},
+		{
+			name: "Loose list",
+			md:   "- one\n\n- two\n\nafter",
+			want: "<ul><li>one</li><li>two</li></ul><p>after</p>",
+		},
+		{
+			name: "Paragraph before list",
+			md:   "Items:\n- one",
+			want: "<p>Items:</p><ul><li>one</li></ul>",
+		},
+		{
+			name: "Link with mention text",
+			md:   "[[character:1]](https://example.com) and [text](https://example.com?a=1&b=\"2\")",
+			want: `<p><a href="https://example.com">[character:1]</a> and <a href="https://example.com?a=1&amp;b=&quot;2&quot;">text</a></p>`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := MarkdownToEntry(test.md); got != test.want {
+				t.Errorf("got <%s>, want <%s>", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSimpleCharacter_EntryMarkdown(t *testing.T) {
+	var ch Character
+	ch.SetEntryMarkdown("# Arya\n\nLives in [location:10].")
+
+	want := "<h1>Arya</h1><p>Lives in [location:10].</p>"
+	if ch.Entry != want {
+		t.Errorf("got entry <%s>, want <%s>", ch.Entry, want)
+	}
+
+	if got := ch.EntryMarkdown(); got != "# Arya\n\nLives in [location:10]." {
+		t.Errorf("got Markdown <%s>, want <%s>", got, "# Arya\n\nLives in [location:10].")
+	}
+}
@@ -0,0 +1,308 @@
+package kanka
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MentionEntity is the type of mentions which refer to an entity by its
+// entity ID rather than by the ID of its object, such as [entity:678].
+const MentionEntity EntityType = "entity"
+
+const kankaWebURL string = "https://kanka.io/en/campaign/"
+
+// Mention represents a reference to another entity embedded in an entry,
+// such as [character:123] or [location:45|custom text].
+// For more information, visit: https://kanka.io/en-US/docs/1.0/mentions
+type Mention struct {
+	Type EntityType
+	ID   int
+	Text string
+}
+
+// String returns the Mention in Kanka's mention syntax.
+func (m *Mention) String() string {
+	if m.Text == "" {
+		return fmt.Sprintf("[%s:%d]", m.Type, m.ID)
+	}
+	return fmt.Sprintf("[%s:%d|%s]", m.Type, m.ID, m.Text)
+}
+
+func (m *Mention) node() {}
+
+// Node is a single node of a parsed entry.
+// Every Node is either a Text or a *Mention.
+type Node interface {
+	node()
+}
+
+// Text is a run of entry HTML containing no mentions.
+type Text string
+
+func (Text) node() {}
+
+var mentionPattern = regexp.MustCompile(`\[([a-z_]+):(\d+)(?:\|([^\[\]]*))?\]`)
+
+// ParseEntry parses the provided entry into a list of text and mention nodes.
+func ParseEntry(entry string) []Node {
+	var nodes []Node
+
+	last := 0
+	for _, loc := range mentionPattern.FindAllStringSubmatchIndex(entry, -1) {
+		id, err := strconv.Atoi(entry[loc[4]:loc[5]])
+		if err != nil {
+			continue
+		}
+
+		if loc[0] > last {
+			nodes = append(nodes, Text(entry[last:loc[0]]))
+		}
+
+		m := &Mention{Type: EntityType(entry[loc[2]:loc[3]]), ID: id}
+		if loc[6] >= 0 {
+			m.Text = entry[loc[6]:loc[7]]
+		}
+		nodes = append(nodes, m)
+
+		last = loc[1]
+	}
+
+	if last < len(entry) {
+		nodes = append(nodes, Text(entry[last:]))
+	}
+
+	return nodes
+}
+
+// FormatEntry reassembles the provided nodes into an entry.
+func FormatEntry(nodes []Node) string {
+	var b strings.Builder
+	for _, n := range nodes {
+		switch n := n.(type) {
+		case Text:
+			b.WriteString(string(n))
+		case *Mention:
+			b.WriteString(n.String())
+		}
+	}
+
+	return b.String()
+}
+
+// Mentions returns every mention in the provided entry.
+func Mentions(entry string) []*Mention {
+	var ms []*Mention
+	for _, n := range ParseEntry(entry) {
+		if m, ok := n.(*Mention); ok {
+			ms = append(ms, m)
+		}
+	}
+
+	return ms
+}
+
+// MentionTarget describes the entity a Mention refers to.
+type MentionTarget struct {
+	Type      EntityType
+	ID        ObjectID
+	EntityID  EntityID
+	Name      string
+	URL       string
+	IsPrivate bool
+}
+
+// MentionResolver resolves mentions to the entities they refer to.
+// Resolve returns a nil MentionTarget and a nil error if the entity does not
+// exist.
+type MentionResolver interface {
+	Resolve(m *Mention) (*MentionTarget, error)
+}
+
+// MentionCache is a MentionResolver backed by a local set of targets.
+type MentionCache struct {
+	objects  map[EntityType]map[int]*MentionTarget
+	entities map[int]*MentionTarget
+}
+
+// NewMentionCache returns an empty MentionCache.
+func NewMentionCache() *MentionCache {
+	return &MentionCache{
+		objects:  make(map[EntityType]map[int]*MentionTarget),
+		entities: make(map[int]*MentionTarget),
+	}
+}
+
+// MentionCache returns a MentionCache containing every core object in the
+// Backup.
+func (b *Backup) MentionCache() *MentionCache {
+	mc := NewMentionCache()
+
+	campID := 0
+	if b.Campaign != nil {
+		campID = int(b.Campaign.ID)
+	}
+
+	for _, ref := range b.refs() {
+		mc.Add(ref.target(campID))
+	}
+
+	return mc
+}
+
+// Add adds the provided target to the MentionCache.
+func (mc *MentionCache) Add(t *MentionTarget) {
+	if mc.objects[t.Type] == nil {
+		mc.objects[t.Type] = make(map[int]*MentionTarget)
+	}
+	mc.objects[t.Type][int(t.ID)] = t
+
+	if t.EntityID != 0 {
+		mc.entities[int(t.EntityID)] = t
+	}
+}
+
+// Resolve returns the target of the provided Mention, if it is cached.
+func (mc *MentionCache) Resolve(m *Mention) (*MentionTarget, error) {
+	if m.Type == MentionEntity {
+		return mc.entities[m.ID], nil
+	}
+
+	return mc.objects[m.Type][m.ID], nil
+}
+
+// target returns the MentionTarget of the referenced object.
+func (ref entityRef) target(campID int) *MentionTarget {
+	return &MentionTarget{
+		Type:      ref.Type,
+		ID:        ObjectID(ref.ID),
+		EntityID:  EntityID(ref.EntityID),
+		Name:      ref.Name,
+		URL:       entityURL(campID, ref.Type, ref.ID),
+		IsPrivate: ref.IsPrivate,
+	}
+}
+
+// entityURL returns the URL of the Kanka page of the object of the provided
+// type associated with id.
+func entityURL(campID int, typ EntityType, id int) string {
+	return fmt.Sprintf("%s%d/%s/%d", kankaWebURL, campID, typ.endpoint(), id)
+}
+
+// clientResolver is a MentionResolver which retrieves unknown targets using
+// a Client and caches them.
+type clientResolver struct {
+	client *Client
+	campID int
+	cache  *MentionCache
+}
+
+// MentionResolver returns a MentionResolver which retrieves the targets of
+// mentions from the Campaign associated with campID as they are needed.
+// Retrieved targets are cached for the lifetime of the MentionResolver.
+func (c *Client) MentionResolver(campID CampaignID) MentionResolver {
+	return &clientResolver{client: c, campID: int(campID), cache: NewMentionCache()}
+}
+
+// Resolve returns the target of the provided Mention, or nil if it does not
+// exist or is of a type the package does not model.
+func (cr *clientResolver) Resolve(m *Mention) (*MentionTarget, error) {
+	if t, _ := cr.cache.Resolve(m); t != nil {
+		return t, nil
+	}
+
+	// Kanka also mentions types the package does not model, such as
+	// abilities and maps, which are left unresolved like in a MentionCache.
+	if m.Type != MentionEntity && m.Type.endpoint() == "" {
+		return nil, nil
+	}
+
+	var ref entityRef
+	var err error
+	if m.Type == MentionEntity {
+		ref, err = cr.client.getEntityRef(cr.campID, m.ID)
+	} else {
+		ref, err = cr.client.getRef(cr.campID, m.Type, m.ID)
+	}
+
+	var se *serverError
+	if errors.As(err, &se) && se.code == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve mention '%s': %w", m, err)
+	}
+	if ref.Type.endpoint() == "" {
+		return nil, nil
+	}
+
+	t := ref.target(cr.campID)
+	cr.cache.Add(t)
+
+	return t, nil
+}
+
+// EntryFormat is an output format of RenderEntry.
+type EntryFormat int
+
+// Available entry formats.
+const (
+	FormatHTML EntryFormat = iota
+	FormatText
+	FormatMarkdown
+)
+
+// RenderEntry renders the provided entry in the provided format. Mentions
+// resolved by r are turned into links to their entities. Mentions which
+// cannot be resolved are replaced with their custom text, if any, or left
+// as they are.
+func RenderEntry(entry string, r MentionResolver, f EntryFormat) (string, error) {
+	var b strings.Builder
+
+	for _, n := range ParseEntry(entry) {
+		switch n := n.(type) {
+		case Text:
+			b.WriteString(string(n))
+		case *Mention:
+			t, err := r.Resolve(n)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(mentionHTML(n, t))
+		}
+	}
+
+	switch f {
+	case FormatHTML:
+		return b.String(), nil
+	case FormatText:
+		return htmlToText(b.String()), nil
+	case FormatMarkdown:
+		return EntryToMarkdown(b.String()), nil
+	default:
+		return "", fmt.Errorf("unknown entry format (%d)", f)
+	}
+}
+
+// mentionHTML returns the HTML link to the provided target of a Mention.
+func mentionHTML(m *Mention, t *MentionTarget) string {
+	if t == nil {
+		if m.Text != "" {
+			return m.Text
+		}
+		return m.String()
+	}
+
+	// The custom text of a mention is copied from the entry and is already
+	// HTML, unlike the name of the target.
+	text := m.Text
+	if text == "" {
+		text = html.EscapeString(t.Name)
+	}
+
+	return fmt.Sprintf(`<a href="%s" class="mention" data-entity-id="%d">%s</a>`, html.EscapeString(t.URL), t.EntityID, text)
+}
@@ -0,0 +1,215 @@
+package kanka
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseEntry(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry string
+		want  []Node
+	}{
+		{
+			name:  "No mentions",
+			entry: "<p>Plain text</p>",
+			want:  []Node{Text("<p>Plain text</p>")},
+		},
+		{
+			name:  "Mention",
+			entry: "<p>Lives in [location:10]</p>",
+			want:  []Node{Text("<p>Lives in "), &Mention{Type: TypeLocation, ID: 10}, Text("</p>")},
+		},
+		{
+			name:  "Custom text",
+			entry: "[character:30|Arya] and [entity:131]",
+			want: []Node{
+				&Mention{Type: TypeCharacter, ID: 30, Text: "Arya"},
+				Text(" and "),
+				&Mention{Type: MentionEntity, ID: 131},
+			},
+		},
+		{
+			name:  "Malformed",
+			entry: "[character:abc] [location:] [note:1",
+			want:  []Node{Text("[character:abc] [location:] [note:1")},
+		},
+		{
+			name:  "Empty",
+			entry: "",
+			want:  nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ParseEntry(test.entry)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+
+			if entry := FormatEntry(got); entry != test.entry {
+				t.Errorf("got entry <%s>, want <%s>", entry, test.entry)
+			}
+		})
+	}
+}
+
+func TestMentions(t *testing.T) {
+	got := Mentions("[character:30|Arya] met [character:31] in [location:10]")
+	want := []*Mention{
+		{Type: TypeCharacter, ID: 30, Text: "Arya"},
+		{Type: TypeCharacter, ID: 31},
+		{Type: TypeLocation, ID: 10},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRenderEntry(t *testing.T) {
+	mc := NewMentionCache()
+	mc.Add(&MentionTarget{Type: TypeLocation, ID: 10, EntityID: 110, Name: "Winterfell", URL: "https://kanka.io/en/campaign/1/locations/10"})
+	mc.Add(&MentionTarget{Type: TypeCharacter, ID: 30, EntityID: 130, Name: "Arya <Stark>", URL: "https://kanka.io/en/campaign/1/characters/30"})
+
+	entry := "<h2>Arya</h2><p>[entity:130] lives in [location:10|the North &amp; Wall].<br>She knows [character:99] and [character:98|Sam &amp; Gilly].</p>"
+
+	tests := []struct {
+		name   string
+		format EntryFormat
+		want   string
+	}{
+		{
+			name:   "HTML",
+			format: FormatHTML,
+			want: `<h2>Arya</h2><p><a href="https://kanka.io/en/campaign/1/characters/30" class="mention" data-entity-id="130">Arya &lt;Stark&gt;</a>` +
+				` lives in <a href="https://kanka.io/en/campaign/1/locations/10" class="mention" data-entity-id="110">the North &amp; Wall</a>.<br>She knows [character:99] and Sam &amp; Gilly.</p>`,
+		},
+		{
+			name:   "Text",
+			format: FormatText,
+			want:   "Arya\n\nArya <Stark> lives in the North & Wall.\nShe knows [character:99] and Sam & Gilly.",
+		},
+		{
+			name:   "Markdown",
+			format: FormatMarkdown,
+			want: "## Arya\n\n[Arya <Stark>](https://kanka.io/en/campaign/1/characters/30) lives in " +
+				"[the North & Wall](https://kanka.io/en/campaign/1/locations/10).\\\nShe knows [character:99] and Sam & Gilly.",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := RenderEntry(entry, mc, test.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("got <%s>, want <%s>", got, test.want)
+			}
+		})
+	}
+}
+
+func TestBackup_MentionCache(t *testing.T) {
+	b := &Backup{
+		Campaign:   &Campaign{ID: 1},
+		Characters: []*Character{{ID: 31, EntityID: 131, SimpleCharacter: SimpleCharacter{Name: "Jon Snow", IsPrivate: true}}},
+	}
+
+	want := &MentionTarget{
+		Type:      TypeCharacter,
+		ID:        31,
+		EntityID:  131,
+		Name:      "Jon Snow",
+		URL:       "https://kanka.io/en/campaign/1/characters/31",
+		IsPrivate: true,
+	}
+
+	mc := b.MentionCache()
+	for _, m := range []*Mention{{Type: TypeCharacter, ID: 31}, {Type: MentionEntity, ID: 131}} {
+		got, err := mc.Resolve(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
+
+func TestClient_MentionResolver(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+	fk.store("/campaigns/1/entities", map[string]interface{}{"id": 131, "name": "Jon Snow", "type": "character", "child_id": 31, "is_private": true})
+	fk.store("/campaigns/1/entities", map[string]interface{}{"id": 190, "name": "The North", "type": "map", "child_id": 9})
+
+	r := c.MentionResolver(1)
+
+	tests := []struct {
+		name    string
+		mention *Mention
+		want    *MentionTarget
+	}{
+		{
+			name:    "Object",
+			mention: &Mention{Type: TypeLocation, ID: 10},
+			want:    &MentionTarget{Type: TypeLocation, ID: 10, EntityID: 110, Name: "Winterfell", URL: "https://kanka.io/en/campaign/1/locations/10"},
+		},
+		{
+			name:    "Entity",
+			mention: &Mention{Type: MentionEntity, ID: 131},
+			want:    &MentionTarget{Type: TypeCharacter, ID: 31, EntityID: 131, Name: "Jon Snow", URL: "https://kanka.io/en/campaign/1/characters/31", IsPrivate: true},
+		},
+		{
+			name:    "Missing",
+			mention: &Mention{Type: TypeCharacter, ID: 99},
+			want:    nil,
+		},
+		{
+			name:    "Unmodelled type",
+			mention: &Mention{Type: "ability", ID: 4},
+			want:    nil,
+		},
+		{
+			name:    "Unmodelled entity",
+			mention: &Mention{Type: MentionEntity, ID: 190},
+			want:    nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := r.Resolve(test.mention)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	got, err := RenderEntry("<p>[ability:4|Fireball] at [location:10]</p>", r, FormatText)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "Fireball at Winterfell" {
+		t.Errorf("got rendered entry <%s>, want <%s>", got, "Fireball at Winterfell")
+	}
+
+	calls := len(fk.calls)
+	if _, err := r.Resolve(&Mention{Type: TypeCharacter, ID: 31}); err != nil {
+		t.Fatal(err)
+	}
+	if len(fk.calls) != calls {
+		t.Error("got request for cached mention, want none")
+	}
+
+	fk.fail["GET /campaigns/1/notes/5"] = http.StatusInternalServerError
+	if _, err := r.Resolve(&Mention{Type: TypeNote, ID: 5}); err == nil {
+		t.Error("got nil error, want error")
+	}
+}
@@ -0,0 +1,911 @@
+package kanka
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MergeAction is what a MergePlan does to a single record.
+type MergeAction string
+
+// Available merge actions.
+const (
+	// MergeCopy copies a record of the duplicate to the survivor.
+	MergeCopy MergeAction = "copy"
+	// MergeUpdate points a record referring to the duplicate at the
+	// survivor.
+	MergeUpdate MergeAction = "update"
+	// MergeRewrite rewrites the mentions of the duplicate in an entry.
+	MergeRewrite MergeAction = "rewrite"
+	// MergeDelete deletes the duplicate.
+	MergeDelete MergeAction = "delete"
+	// MergeSkip leaves a record unmerged for the stated reason.
+	MergeSkip MergeAction = "skip"
+)
+
+// MergeStep is a single step of a MergePlan. EntityID, Type, ID, and Name
+// identify the entity owning the affected record. RecordID and Label
+// identify the record itself. For ResourceEntity, Label is the updated field
+// of the entity's object, if any. Reason explains skipped steps. Err is set
+// if the step failed when the MergePlan was applied.
+type MergeStep struct {
+	Action   MergeAction
+	EntityID EntityID
+	Type     EntityType
+	ID       ObjectID
+	Name     string
+	Resource Resource
+	RecordID int
+	Label    string
+	Reason   string
+	Err      error
+
+	method string
+	end    endpoint
+	body   map[string]interface{}
+	done   bool
+}
+
+// String returns a description of the MergeStep.
+func (ms *MergeStep) String() string {
+	desc := fmt.Sprintf("%s %s '%s' (Entity ID: %d)", ms.Action, ms.Type, ms.Name, ms.EntityID)
+
+	switch {
+	case ms.Resource == ResourceEntity && ms.Label != "":
+		desc += fmt.Sprintf(": %s", ms.Label)
+	case ms.Resource == ResourceEntity:
+	case ms.RecordID == 0:
+		desc += fmt.Sprintf(": %s '%s'", ms.Resource, ms.Label)
+	default:
+		desc += fmt.Sprintf(": %s '%s' (ID: %d)", ms.Resource, ms.Label, ms.RecordID)
+	}
+
+	if ms.Reason != "" {
+		desc += fmt.Sprintf(" (%s)", ms.Reason)
+	}
+
+	return desc
+}
+
+// MergePlan lists every step needed to merge a duplicate object into a
+// surviving object of the same type. A MergePlan is a dry run; nothing
+// changes until it is applied with ApplyMerge.
+type MergePlan struct {
+	CampaignID  CampaignID
+	Type        EntityType
+	SurvivorID  ObjectID
+	DuplicateID ObjectID
+	Steps       []*MergeStep
+}
+
+// String returns a description of every step of the MergePlan, one per
+// line.
+func (p *MergePlan) String() string {
+	var sb strings.Builder
+	for _, ms := range p.Steps {
+		sb.WriteString(ms.String())
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// PlanMerge returns the MergePlan merging the object of the provided type
+// associated with dupID into the object associated with survID in the
+// Campaign associated with campID. The plan copies the attributes, entity
+// notes, entity events, tags, inventory, and relations of the duplicate to
+// the survivor, along with its organization members, quest elements, or map
+// points. Relations, inventories, quest elements, organization members,
+// entity tags, and objects referring to the duplicate are pointed at the
+// survivor, and mentions of the duplicate are rewritten in every entry and
+// entity note. The duplicate is deleted last. Records the survivor already
+// has are skipped, as are entity files and map points in other locations
+// targeting the duplicate, which Kanka does not allow to be moved.
+func (c *Client) PlanMerge(campID CampaignID, typ EntityType, survID ObjectID, dupID ObjectID) (*MergePlan, error) {
+	if survID == dupID {
+		return nil, fmt.Errorf("cannot merge %s (ID: %d) into itself", typ, survID)
+	}
+
+	b, err := c.Backup(campID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot plan merge of %s (ID: %d) into %s (ID: %d) in Campaign (ID: %d): %w", typ, dupID, typ, survID, campID, err)
+	}
+
+	p, err := b.planMerge(int(campID), typ, int(survID), int(dupID))
+	if err != nil {
+		return nil, fmt.Errorf("cannot plan merge of %s (ID: %d) into %s (ID: %d) in Campaign (ID: %d): %w", typ, dupID, typ, survID, campID, err)
+	}
+
+	return p, nil
+}
+
+// mergePlanner holds the state of a single call to planMerge.
+type mergePlanner struct {
+	b      *Backup
+	campID int
+	ix     *ReferenceIndex
+	ents   map[int]*EntityData
+	surv   entityRef
+	dup    entityRef
+	steps  []*MergeStep
+	err    error
+}
+
+// planMerge returns the MergePlan merging the object of the provided type
+// associated with dupID into the object associated with survID in the
+// Backup of the Campaign associated with campID.
+func (b *Backup) planMerge(campID int, typ EntityType, survID int, dupID int) (*MergePlan, error) {
+	mp := newMergePlanner(b, campID)
+
+	surv, ok := mp.ix.objects[typ][survID]
+	if !ok {
+		return nil, fmt.Errorf("cannot find %s (ID: %d)", typ, survID)
+	}
+	dup, ok := mp.ix.objects[typ][dupID]
+	if !ok {
+		return nil, fmt.Errorf("cannot find %s (ID: %d)", typ, dupID)
+	}
+
+	return mp.plan(surv, dup)
+}
+
+// newMergePlanner returns a mergePlanner for the Backup of the Campaign
+// associated with campID.
+func newMergePlanner(b *Backup, campID int) *mergePlanner {
+	mp := &mergePlanner{
+		b:      b,
+		campID: campID,
+		ix:     b.ReferenceIndex(),
+		ents:   make(map[int]*EntityData),
+	}
+	for _, ent := range b.Entities {
+		mp.ents[int(ent.EntityID)] = ent
+	}
+
+	return mp
+}
+
+// plan returns the MergePlan merging the object described by dup into the
+// object described by surv.
+func (mp *mergePlanner) plan(surv entityRef, dup entityRef) (*MergePlan, error) {
+	mp.surv, mp.dup = surv, dup
+
+	mp.copySubResources()
+	mp.copyChildren()
+	mp.updateReferences()
+	mp.rewriteMentions()
+
+	ms := mp.add(MergeDelete, dup, ResourceEntity, dup.ID, "")
+	mp.request(ms, "DELETE", dup.Type.endpoint(), dup.ID, "", 0, nil)
+
+	if mp.err != nil {
+		return nil, mp.err
+	}
+
+	return &MergePlan{CampaignID: CampaignID(mp.campID), Type: dup.Type, SurvivorID: ObjectID(surv.ID), DuplicateID: ObjectID(dup.ID), Steps: mp.steps}, nil
+}
+
+// add appends a step of the record of the entity described by ref to the
+// plan and returns it.
+func (mp *mergePlanner) add(action MergeAction, ref entityRef, res Resource, id int, label string) *MergeStep {
+	ms := &MergeStep{
+		Action:   action,
+		EntityID: EntityID(ref.EntityID),
+		Type:     ref.Type,
+		ID:       ObjectID(ref.ID),
+		Name:     ref.Name,
+		Resource: res,
+		RecordID: id,
+		Label:    label,
+	}
+	mp.steps = append(mp.steps, ms)
+
+	return ms
+}
+
+// skip appends a skipped step of the record of the entity described by ref
+// to the plan.
+func (mp *mergePlanner) skip(ref entityRef, res Resource, id int, label string, reason string) {
+	mp.add(MergeSkip, ref, res, id, label).Reason = reason
+}
+
+// request sets the request of the MergeStep. The endpoint is built as
+// nestedEndpoint builds it and the body holds the JSON fields of v, if any.
+// The first error is kept in the mergePlanner.
+func (mp *mergePlanner) request(ms *MergeStep, method string, owner endpoint, ownerID int, sub endpoint, id int, v interface{}) {
+	if mp.err != nil {
+		return
+	}
+
+	if ms.end, mp.err = nestedEndpoint(mp.campID, owner, ownerID, sub, id); mp.err != nil {
+		return
+	}
+	ms.method = method
+
+	switch v := v.(type) {
+	case nil:
+	case map[string]interface{}:
+		ms.body = v
+	default:
+		ms.body, mp.err = recordBody(ms.Resource, ms.RecordID, v)
+	}
+}
+
+// entity returns the sub-resources of the entity described by ref, which
+// are empty if the entity is missing from the Backup.
+func (mp *mergePlanner) entity(ref entityRef) *EntityData {
+	if ent, ok := mp.ents[ref.EntityID]; ok {
+		return ent
+	}
+
+	return &EntityData{EntityID: EntityID(ref.EntityID), Type: ref.Type, ID: ObjectID(ref.ID)}
+}
+
+// tags returns the IDs of the tags carried by the entity described by ref,
+// mapped to the ID of their entity tag, which is 0 if the tag is only listed
+// by the entity's object.
+func (mp *mergePlanner) tags(ref entityRef) map[int]int {
+	tags := make(map[int]int)
+	for _, id := range ref.Tags {
+		tags[id] = 0
+	}
+	for _, et := range mp.entity(ref).EntityTags {
+		tags[int(et.TagID)] = et.ID
+	}
+
+	return tags
+}
+
+// copySubResources copies the entity-level sub-resources of the duplicate to
+// the survivor.
+func (mp *mergePlanner) copySubResources() {
+	surv, dup := mp.entity(mp.surv), mp.entity(mp.dup)
+	survEnt := mp.surv.EntityID
+
+	attrs := make(map[string]bool)
+	for _, a := range surv.Attributes {
+		attrs[a.Name] = true
+	}
+	for _, a := range dup.Attributes {
+		if attrs[a.Name] {
+			mp.skip(mp.dup, ResourceAttribute, a.ID, a.Name, "survivor has attribute")
+			continue
+		}
+
+		ms := mp.add(MergeCopy, mp.dup, ResourceAttribute, a.ID, a.Name)
+		mp.request(ms, "POST", endpointEntity, survEnt, EndpointAttribute, 0, a.SimpleAttribute)
+	}
+
+	// Entity events, entity notes, and inventory have no name to match, so
+	// those the survivor already has with the same content, such as copies
+	// made by an earlier merge that failed, are skipped.
+	evs := make(map[SimpleEntityEvent]bool)
+	for _, e := range surv.EntityEvents {
+		evs[e.SimpleEntityEvent] = true
+	}
+	for _, e := range dup.EntityEvents {
+		label := e.CalendarDate().String()
+		if e.Comment != "" {
+			label = e.Comment
+		}
+
+		s := e.SimpleEntityEvent
+		s.EntityID = EntityID(survEnt)
+		if evs[s] {
+			mp.skip(mp.dup, ResourceEntityEvent, e.ID, label, "survivor has entity event")
+			continue
+		}
+
+		ms := mp.add(MergeCopy, mp.dup, ResourceEntityEvent, e.ID, label)
+		mp.request(ms, "POST", endpointEntity, survEnt, EndpointEntityEvent, 0, s)
+	}
+
+	notes := make(map[SimpleEntityNote]bool)
+	for _, n := range surv.EntityNotes {
+		notes[n.SimpleEntityNote] = true
+	}
+	for _, n := range dup.EntityNotes {
+		s := n.SimpleEntityNote
+		s.EntityID = EntityID(survEnt)
+		s.Entry = mp.retarget(s.Entry)
+		if notes[s] {
+			mp.skip(mp.dup, ResourceEntityNote, n.ID, n.Name, "survivor has entity note")
+			continue
+		}
+
+		ms := mp.add(MergeCopy, mp.dup, ResourceEntityNote, n.ID, n.Name)
+		mp.request(ms, "POST", endpointEntity, survEnt, EndpointEntityNote, 0, s)
+	}
+
+	if f, ok := mp.b.fields()[mp.dup.EntityID]; ok {
+		for _, ef := range f.files.Data {
+			mp.skip(mp.dup, ResourceEntityFile, ef.ID, ef.Name, "files cannot be moved")
+		}
+	}
+
+	survTags, dupTags := mp.tags(mp.surv), mp.tags(mp.dup)
+	var tagIDs []int
+	for id := range dupTags {
+		tagIDs = append(tagIDs, id)
+	}
+	sort.Ints(tagIDs)
+
+	for _, tagID := range tagIDs {
+		if _, ok := survTags[tagID]; ok {
+			continue
+		}
+
+		label := mp.ix.objects[TypeTag][tagID].Name
+		if mp.dup.Type == TypeTag && (tagID == mp.dup.ID || tagID == mp.surv.ID) {
+			mp.skip(mp.dup, ResourceEntityTag, dupTags[tagID], label, "tag cannot carry itself")
+			continue
+		}
+
+		ms := mp.add(MergeCopy, mp.dup, ResourceEntityTag, dupTags[tagID], label)
+		mp.request(ms, "POST", endpointEntity, survEnt, EndpointEntityTag, 0, SimpleEntityTag{EntityID: EntityID(survEnt), TagID: TagID(tagID)})
+	}
+
+	invs := make(map[SimpleEntityInventory]bool)
+	for _, inv := range surv.Inventory {
+		invs[inv.SimpleEntityInventory] = true
+	}
+	for _, inv := range dup.Inventory {
+		s := inv.SimpleEntityInventory
+		s.EntityID = EntityID(survEnt)
+		if mp.dup.Type == TypeItem && mp.surv.Type == TypeItem && int(s.ItemID) == mp.dup.ID {
+			s.ItemID = ItemID(mp.surv.ID)
+		}
+		if invs[s] {
+			mp.skip(mp.dup, ResourceInventory, inv.ID, fmt.Sprintf("item %d", inv.ItemID), "survivor has inventory")
+			continue
+		}
+
+		ms := mp.add(MergeCopy, mp.dup, ResourceInventory, inv.ID, fmt.Sprintf("item %d", inv.ItemID))
+		mp.request(ms, "POST", endpointEntity, survEnt, EndpointEntityInventory, 0, s)
+	}
+
+	for _, r := range dup.Relations {
+		switch {
+		case int(r.TargetID) == survEnt:
+			mp.skip(mp.dup, ResourceRelation, r.ID, r.Relation, "would relate survivor to itself")
+		case hasRelation(surv, r.Relation, int(r.TargetID)):
+			mp.skip(mp.dup, ResourceRelation, r.ID, r.Relation, "survivor has relation")
+		default:
+			s := r.SimpleRelation
+			s.OwnerID = EntityID(survEnt)
+			s.TwoWay = false
+
+			ms := mp.add(MergeCopy, mp.dup, ResourceRelation, r.ID, r.Relation)
+			mp.request(ms, "POST", endpointEntity, survEnt, EndpointRelation, 0, s)
+		}
+	}
+}
+
+// hasRelation returns true if the entity has a relation of the provided name
+// to the entity associated with target.
+func hasRelation(ent *EntityData, name string, target int) bool {
+	for _, r := range ent.Relations {
+		if r.Relation == name && int(r.TargetID) == target {
+			return true
+		}
+	}
+
+	return false
+}
+
+// copyChildren copies the organization members of a duplicate organization,
+// the quest elements of a duplicate quest, or the map points of a duplicate
+// location to the survivor.
+func (mp *mergePlanner) copyChildren() {
+	surv, dup := mp.surv.ID, mp.dup.ID
+	if mp.surv.Type != mp.dup.Type {
+		// Nothing of the survivor's type can already hold the children.
+		surv = 0
+	}
+	name := func(typ EntityType, id int) string {
+		return mp.ix.objects[typ][id].Name
+	}
+
+	switch mp.dup.Type {
+	case TypeOrganization:
+		members := make(map[int]bool)
+		for _, v := range mp.b.OrganizationMembers {
+			if int(v.OrganizationID) == surv {
+				members[int(v.CharacterID)] = true
+			}
+		}
+		for _, v := range mp.b.OrganizationMembers {
+			if int(v.OrganizationID) != dup {
+				continue
+			}
+			if members[int(v.CharacterID)] {
+				mp.skip(mp.dup, ResourceMember, v.ID, name(TypeCharacter, int(v.CharacterID)), "already a member of survivor")
+				continue
+			}
+
+			s := v.SimpleOrganizationMember
+			s.OrganizationID = OrganizationID(surv)
+			mp.copyChild(ResourceMember, v.ID, name(TypeCharacter, int(v.CharacterID)), EndpointOrganizationMember, s)
+		}
+	case TypeQuest:
+		listed := make(map[Resource]map[int]bool)
+		list := func(res Resource, quest int, id int) {
+			if quest != surv {
+				return
+			}
+			if listed[res] == nil {
+				listed[res] = make(map[int]bool)
+			}
+			listed[res][id] = true
+		}
+		for _, v := range mp.b.QuestCharacters {
+			list(ResourceQuestCharacter, int(v.QuestID), int(v.CharacterID))
+		}
+		for _, v := range mp.b.QuestLocations {
+			list(ResourceQuestLocation, int(v.QuestID), int(v.LocationID))
+		}
+		for _, v := range mp.b.QuestItems {
+			list(ResourceQuestItem, int(v.QuestID), int(v.ItemID))
+		}
+		for _, v := range mp.b.QuestOrganizations {
+			list(ResourceQuestOrganization, int(v.QuestID), int(v.OrganizationID))
+		}
+
+		// element copies the quest element of the duplicate if the survivor
+		// does not list its object already.
+		element := func(res Resource, sub endpoint, recID int, label string, id int, s interface{}) {
+			if listed[res][id] {
+				mp.skip(mp.dup, res, recID, label, "already listed by survivor")
+				return
+			}
+
+			mp.copyChild(res, recID, label, sub, s)
+		}
+		for _, v := range mp.b.QuestCharacters {
+			if int(v.QuestID) == dup {
+				s := v.SimpleQuestCharacter
+				s.QuestID = QuestID(surv)
+				element(ResourceQuestCharacter, EndpointQuestCharacters, v.ID, name(TypeCharacter, int(v.CharacterID)), int(v.CharacterID), s)
+			}
+		}
+		for _, v := range mp.b.QuestLocations {
+			if int(v.QuestID) == dup {
+				s := v.SimpleQuestLocation
+				s.QuestID = QuestID(surv)
+				element(ResourceQuestLocation, EndpointQuestLocation, v.ID, name(TypeLocation, int(v.LocationID)), int(v.LocationID), s)
+			}
+		}
+		for _, v := range mp.b.QuestItems {
+			if int(v.QuestID) == dup {
+				s := v.SimpleQuestItem
+				s.QuestID = QuestID(surv)
+				element(ResourceQuestItem, EndpointQuestItem, v.ID, name(TypeItem, int(v.ItemID)), int(v.ItemID), s)
+			}
+		}
+		for _, v := range mp.b.QuestOrganizations {
+			if int(v.QuestID) == dup {
+				s := v.SimpleQuestOrganization
+				s.QuestID = QuestID(surv)
+				element(ResourceQuestOrganization, EndpointQuestOrganization, v.ID, name(TypeOrganization, int(v.OrganizationID)), int(v.OrganizationID), s)
+			}
+		}
+	case TypeLocation:
+		for _, v := range mp.b.MapPoints {
+			if int(v.LocationID) != dup {
+				continue
+			}
+
+			s := v.SimpleMapPoint
+			s.LocationID = LocationID(surv)
+			if int(s.TargetEntityID) == mp.dup.EntityID {
+				s.TargetEntityID = EntityID(mp.surv.EntityID)
+			}
+
+			mp.copyChild(ResourceMapPoint, 0, v.Name, EndpointMapPoint, s)
+		}
+	}
+}
+
+// copyChild copies a child record of the duplicate to the survivor, which
+// can only own it if both have the same type.
+func (mp *mergePlanner) copyChild(res Resource, id int, label string, sub endpoint, v interface{}) {
+	if mp.surv.Type != mp.dup.Type {
+		mp.skip(mp.dup, res, id, label, fmt.Sprintf("%s cannot own it", mp.surv.Type))
+		return
+	}
+
+	ms := mp.add(MergeCopy, mp.dup, res, id, label)
+	mp.request(ms, "POST", mp.surv.Type.endpoint(), mp.surv.ID, sub, 0, v)
+}
+
+// typed returns true if the survivor has the type of the duplicate, so that
+// the record of the entity described by from, which refers to the duplicate
+// by its object ID, can refer to the survivor instead. Otherwise, the record
+// is skipped.
+func (mp *mergePlanner) typed(from entityRef, res Resource, id int, label string) bool {
+	if mp.surv.Type == mp.dup.Type {
+		return true
+	}
+
+	mp.skip(from, res, id, label, fmt.Sprintf("cannot refer to a %s", mp.surv.Type))
+	return false
+}
+
+// updateReferences points every record and object referring to the
+// duplicate at the survivor.
+func (mp *mergePlanner) updateReferences() {
+	survEnt, dupEnt := mp.surv.EntityID, mp.dup.EntityID
+
+	quests := make(map[Resource]map[int]map[int]bool)
+	list := func(res Resource, quest int, id int) {
+		if quests[res] == nil {
+			quests[res] = make(map[int]map[int]bool)
+		}
+		if quests[res][quest] == nil {
+			quests[res][quest] = make(map[int]bool)
+		}
+		quests[res][quest][id] = true
+	}
+
+	elements := make(map[int]interface{})
+	for _, v := range mp.b.QuestCharacters {
+		list(ResourceQuestCharacter, int(v.QuestID), int(v.CharacterID))
+		elements[v.ID] = v
+	}
+	for _, v := range mp.b.QuestLocations {
+		list(ResourceQuestLocation, int(v.QuestID), int(v.LocationID))
+		elements[v.ID] = v
+	}
+	for _, v := range mp.b.QuestItems {
+		list(ResourceQuestItem, int(v.QuestID), int(v.ItemID))
+		elements[v.ID] = v
+	}
+	for _, v := range mp.b.QuestOrganizations {
+		list(ResourceQuestOrganization, int(v.QuestID), int(v.OrganizationID))
+		elements[v.ID] = v
+	}
+
+	members := make(map[int]map[int]bool)
+	mems := make(map[int]*OrganizationMember)
+	for _, v := range mp.b.OrganizationMembers {
+		if members[int(v.OrganizationID)] == nil {
+			members[int(v.OrganizationID)] = make(map[int]bool)
+		}
+		members[int(v.OrganizationID)][int(v.CharacterID)] = true
+		mems[v.ID] = v
+	}
+
+	// element updates the quest element of the quest described by from
+	// unless the quest already lists the survivor.
+	element := func(from entityRef, res Resource, sub endpoint, recID int, s interface{}) {
+		if !mp.typed(from, res, recID, mp.dup.Name) {
+			return
+		}
+		if quests[res][from.ID][mp.surv.ID] {
+			mp.skip(from, res, recID, mp.dup.Name, "already lists survivor")
+			return
+		}
+
+		ms := mp.add(MergeUpdate, from, res, recID, mp.dup.Name)
+		mp.request(ms, "PUT", EndpointQuest, from.ID, sub, recID, s)
+	}
+
+	// relink updates the field of the object described by from, clearing
+	// it if the survivor has another type.
+	relink := func(from entityRef, field string, target int) {
+		if mp.surv.Type != mp.dup.Type {
+			target = 0
+		}
+
+		body := map[string]interface{}{"name": from.Name, field: nil}
+		if target != 0 {
+			body[field] = target
+		}
+
+		ms := mp.add(MergeUpdate, from, ResourceEntity, from.ID, field)
+		mp.request(ms, "PUT", from.Type.endpoint(), from.ID, "", 0, body)
+	}
+
+	// A survivor nested below the duplicate takes the duplicate's place
+	// first, so that the duplicate's children moving below the survivor
+	// cannot form a cycle.
+	if mp.nested() {
+		target := mp.dup.Parent
+		if target == mp.surv.ID {
+			target = 0
+		}
+		relink(mp.surv, mp.surv.Type.parentKey(), target)
+	}
+
+	for _, r := range mp.ix.To(EntityID(dupEnt)) {
+		if int(r.FromEntityID) == dupEnt {
+			continue
+		}
+
+		from := mp.ix.objects[r.FromType][int(r.FromID)]
+		ent := mp.entity(from)
+
+		switch r.Kind {
+		case RefRelation:
+			var rel *Relation
+			for _, v := range ent.Relations {
+				if v.ID == r.RecordID {
+					rel = v
+				}
+			}
+
+			switch {
+			case int(r.FromEntityID) == survEnt:
+				mp.skip(from, ResourceRelation, rel.ID, rel.Relation, "would relate survivor to itself")
+			case hasRelation(ent, rel.Relation, survEnt):
+				mp.skip(from, ResourceRelation, rel.ID, rel.Relation, "already related to survivor")
+			default:
+				s := rel.SimpleRelation
+				s.TargetID = EntityID(survEnt)
+
+				ms := mp.add(MergeUpdate, from, ResourceRelation, rel.ID, rel.Relation)
+				mp.request(ms, "PUT", endpointEntity, from.EntityID, EndpointRelation, rel.ID, s)
+			}
+		case RefInventory:
+			for _, v := range ent.Inventory {
+				if v.ID != r.RecordID || !mp.typed(from, ResourceInventory, v.ID, fmt.Sprintf("item %d", v.ItemID)) {
+					continue
+				}
+
+				s := v.SimpleEntityInventory
+				s.ItemID = ItemID(mp.surv.ID)
+
+				ms := mp.add(MergeUpdate, from, ResourceInventory, v.ID, fmt.Sprintf("item %d", v.ItemID))
+				mp.request(ms, "PUT", endpointEntity, from.EntityID, EndpointEntityInventory, v.ID, s)
+			}
+		case RefQuestCharacter:
+			s := elements[r.RecordID].(*QuestCharacter).SimpleQuestCharacter
+			s.CharacterID = CharacterID(mp.surv.ID)
+			element(from, ResourceQuestCharacter, EndpointQuestCharacters, r.RecordID, s)
+		case RefQuestLocation:
+			s := elements[r.RecordID].(*QuestLocation).SimpleQuestLocation
+			s.LocationID = LocationID(mp.surv.ID)
+			element(from, ResourceQuestLocation, EndpointQuestLocation, r.RecordID, s)
+		case RefQuestItem:
+			s := elements[r.RecordID].(*QuestItem).SimpleQuestItem
+			s.ItemID = ItemID(mp.surv.ID)
+			element(from, ResourceQuestItem, EndpointQuestItem, r.RecordID, s)
+		case RefQuestOrganization:
+			s := elements[r.RecordID].(*QuestOrganization).SimpleQuestOrganization
+			s.OrganizationID = OrganizationID(mp.surv.ID)
+			element(from, ResourceQuestOrganization, EndpointQuestOrganization, r.RecordID, s)
+		case RefMember:
+			if !mp.typed(from, ResourceMember, r.RecordID, mp.dup.Name) {
+				continue
+			}
+			if members[from.ID][mp.surv.ID] {
+				mp.skip(from, ResourceMember, r.RecordID, mp.dup.Name, "survivor is already a member")
+				continue
+			}
+
+			s := mems[r.RecordID].SimpleOrganizationMember
+			s.CharacterID = CharacterID(mp.surv.ID)
+
+			ms := mp.add(MergeUpdate, from, ResourceMember, r.RecordID, mp.dup.Name)
+			mp.request(ms, "PUT", EndpointOrganization, from.ID, EndpointOrganizationMember, r.RecordID, s)
+		case RefParent:
+			if int(r.FromEntityID) == survEnt {
+				continue
+			}
+			relink(from, from.Type.parentKey(), mp.surv.ID)
+		case RefLocation:
+			relink(from, "location_id", mp.surv.ID)
+		case RefFamily:
+			relink(from, "family_id", mp.surv.ID)
+		}
+	}
+
+	if mp.dup.Type == TypeCharacter {
+		for _, v := range mp.b.Items {
+			if int(v.CharacterID) == mp.dup.ID {
+				relink(mp.ix.objects[TypeItem][int(v.ID)], "character_id", mp.surv.ID)
+			}
+		}
+	}
+
+	if mp.dup.Type == TypeTag {
+		for _, ent := range mp.b.Entities {
+			if int(ent.EntityID) == dupEnt {
+				continue
+			}
+
+			from := mp.ix.objects[ent.Type][int(ent.ID)]
+			_, tagged := mp.tags(from)[mp.surv.ID]
+			for _, et := range ent.EntityTags {
+				if int(et.TagID) != mp.dup.ID || !mp.typed(from, ResourceEntityTag, et.ID, mp.dup.Name) {
+					continue
+				}
+				if tagged {
+					mp.skip(from, ResourceEntityTag, et.ID, mp.dup.Name, "already tagged with survivor")
+					continue
+				}
+
+				ms := mp.add(MergeUpdate, from, ResourceEntityTag, et.ID, mp.dup.Name)
+				mp.request(ms, "PUT", endpointEntity, int(ent.EntityID), EndpointEntityTag, et.ID, SimpleEntityTag{EntityID: ent.EntityID, TagID: TagID(mp.surv.ID)})
+			}
+		}
+	}
+
+	for _, v := range mp.b.MapPoints {
+		if int(v.TargetEntityID) == dupEnt && int(v.LocationID) != mp.dupLocation() {
+			mp.skip(mp.ix.objects[TypeLocation][int(v.LocationID)], ResourceMapPoint, 0, v.Name, "map points cannot be updated")
+		}
+	}
+}
+
+// nested returns true if the survivor is nested below the duplicate, at any
+// depth.
+func (mp *mergePlanner) nested() bool {
+	if mp.surv.Type != mp.dup.Type {
+		return false
+	}
+
+	seen := make(map[int]bool)
+	for id := mp.surv.Parent; id != 0 && !seen[id]; id = mp.ix.objects[mp.surv.Type][id].Parent {
+		if id == mp.dup.ID {
+			return true
+		}
+		seen[id] = true
+	}
+
+	return false
+}
+
+// dupLocation returns the ID of the duplicate if it is a location, whose map
+// points are copied rather than updated, or 0 otherwise.
+func (mp *mergePlanner) dupLocation() int {
+	if mp.dup.Type == TypeLocation {
+		return mp.dup.ID
+	}
+
+	return 0
+}
+
+// rewriteMentions rewrites the mentions of the duplicate in the entries and
+// entity notes of every other entity.
+func (mp *mergePlanner) rewriteMentions() {
+	fields := mp.b.fields()
+
+	for _, r := range mp.ix.To(EntityID(mp.dup.EntityID), RefMention) {
+		if int(r.FromEntityID) == mp.dup.EntityID {
+			continue
+		}
+
+		from := mp.ix.objects[r.FromType][int(r.FromID)]
+		if r.RecordID == 0 {
+			body := map[string]interface{}{"name": from.Name, "entry": mp.retarget(*fields[from.EntityID].entry)}
+
+			ms := mp.add(MergeRewrite, from, ResourceEntity, from.ID, "entry")
+			mp.request(ms, "PUT", from.Type.endpoint(), from.ID, "", 0, body)
+			continue
+		}
+
+		for _, n := range mp.entity(from).EntityNotes {
+			if n.ID != r.RecordID {
+				continue
+			}
+
+			s := n.SimpleEntityNote
+			s.Entry = mp.retarget(s.Entry)
+
+			ms := mp.add(MergeRewrite, from, ResourceEntityNote, n.ID, n.Name)
+			mp.request(ms, "PUT", endpointEntity, from.EntityID, EndpointEntityNote, n.ID, s)
+		}
+	}
+}
+
+// retarget returns the entry with its mentions of the duplicate pointed at
+// the survivor.
+func (mp *mergePlanner) retarget(entry string) string {
+	if !strings.Contains(entry, "[") {
+		return entry
+	}
+
+	nodes := ParseEntry(entry)
+	for _, n := range nodes {
+		m, ok := n.(*Mention)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case m.Type == MentionEntity && m.ID == mp.dup.EntityID:
+			m.ID = mp.surv.EntityID
+		case m.Type == mp.dup.Type && m.ID == mp.dup.ID:
+			m.Type, m.ID = mp.surv.Type, mp.surv.ID
+		}
+	}
+
+	return FormatEntry(nodes)
+}
+
+// ApplyMerge applies every step of the MergePlan. Records are copied,
+// updated, and rewritten concurrently first. The duplicate is deleted
+// afterwards, and only if every other step succeeded, so that a failure
+// never loses data of the duplicate. A failed step does not stop the other
+// steps; its Err is set and ApplyMerge returns an error counting the
+// failures. Applying the MergePlan again retries only the steps that did
+// not succeed.
+func (c *Client) ApplyMerge(p *MergePlan) error {
+	var records, deletes []*MergeStep
+	for _, ms := range p.Steps {
+		ms.Err = nil
+		switch {
+		case ms.Action == MergeSkip || ms.done:
+		case ms.Action == MergeDelete:
+			deletes = append(deletes, ms)
+		default:
+			records = append(records, ms)
+		}
+	}
+
+	forEach(len(records), func(i int) {
+		records[i].Err = c.applyMergeStep(int(p.CampaignID), records[i])
+		records[i].done = records[i].Err == nil
+	})
+
+	if err := mergeFailures(p, len(records)); err != nil {
+		return err
+	}
+
+	for _, ms := range deletes {
+		ms.Err = c.applyMergeStep(int(p.CampaignID), ms)
+		ms.done = ms.Err == nil
+	}
+
+	return mergeFailures(p, len(records)+len(deletes))
+}
+
+// mergeFailures returns an error counting the failed steps of the MergePlan
+// out of the n steps attempted, or nil if none failed.
+func mergeFailures(p *MergePlan, n int) error {
+	var failed []*MergeStep
+	for _, ms := range p.Steps {
+		if ms.Err != nil {
+			failed = append(failed, ms)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("cannot apply %d of %d merge steps in Campaign (ID: %d): %w", len(failed), n, p.CampaignID, failed[0].Err)
+	}
+
+	return nil
+}
+
+// applyMergeStep sends the request of the MergeStep.
+func (c *Client) applyMergeStep(campID int, ms *MergeStep) error {
+	if ms.method == "DELETE" {
+		if err := c.delete(ms.end); err != nil {
+			return fmt.Errorf("cannot delete %s (ID: %d) for Campaign (ID: %d): %w", ms.Type, ms.ID, campID, err)
+		}
+		return nil
+	}
+
+	b, err := json.Marshal(ms.body)
+	if err != nil {
+		return fmt.Errorf("cannot marshal %s (ID: %d): %w", ms.Resource, ms.RecordID, err)
+	}
+
+	var wrap struct {
+		Data json.RawMessage `json:"data"`
+	}
+
+	if ms.method == "POST" {
+		err = c.post(ms.end, bytes.NewReader(b), &wrap)
+	} else {
+		err = c.put(ms.end, bytes.NewReader(b), &wrap)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot %s %s (ID: %d) for Campaign (ID: %d): %w", ms.Action, ms.Resource, ms.RecordID, campID, err)
+	}
+
+	return nil
+}
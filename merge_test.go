@@ -0,0 +1,237 @@
+package kanka
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// seedDuplicate seeds a duplicate of Arya Stark as character 32 with its
+// own sub-resources and references from other entities.
+func seedDuplicate(t *testing.T, fk *fakeKanka) {
+	fk.seed(t, "/campaigns/1/characters", &Character{ID: 32, EntityID: 132, SimpleCharacter: SimpleCharacter{Name: "Arya", LocationID: 10}})
+	fk.seed(t, "/campaigns/1/items", &Item{ID: 41, EntityID: 141, SimpleItem: SimpleItem{Name: "Dagger", CharacterID: 32}})
+	fk.seed(t, "/campaigns/1/notes", &Note{ID: 45, EntityID: 145, SimpleNote: SimpleNote{Name: "Rumors", Entry: "<p>[character:32] and [entity:132|her]</p>"}})
+
+	fk.seed(t, "/campaigns/1/entities/132/attributes", &Attribute{ID: 90, EntityID: 132, SimpleAttribute: SimpleAttribute{Name: "Strength", Value: "10"}})
+	fk.seed(t, "/campaigns/1/entities/132/attributes", &Attribute{ID: 91, EntityID: 132, SimpleAttribute: SimpleAttribute{Name: "Agility", Value: "16"}})
+	fk.seed(t, "/campaigns/1/entities/132/relations", &Relation{ID: 92, SimpleRelation: SimpleRelation{Relation: "Rival", OwnerID: 132, TargetID: 131, Attitude: -10}})
+	fk.seed(t, "/campaigns/1/entities/132/entity_tags", &EntityTag{ID: 93, SimpleEntityTag: SimpleEntityTag{EntityID: 132, TagID: 70}})
+	fk.seed(t, "/campaigns/1/entities/132/entity_notes", &EntityNote{ID: 94, SimpleEntityNote: SimpleEntityNote{EntityID: 132, Name: "Alias", Entry: "<p>[character:32] is Cat</p>"}})
+
+	fk.seed(t, "/campaigns/1/entities/131/relations", &Relation{ID: 95, SimpleRelation: SimpleRelation{Relation: "Cousin", OwnerID: 131, TargetID: 132, Attitude: 50}})
+	fk.seed(t, "/campaigns/1/quests/60/quest_characters", &QuestCharacter{ID: 96, SimpleQuestCharacter: SimpleQuestCharacter{QuestID: 60, CharacterID: 32}})
+	fk.seed(t, "/campaigns/1/organisations/50/organisation_members", &OrganizationMember{ID: 97, SimpleOrganizationMember: SimpleOrganizationMember{CharacterID: 32, OrganizationID: 50}})
+	fk.seed(t, "/campaigns/1/locations/10/map_points", &MapPoint{SimpleMapPoint: SimpleMapPoint{LocationID: 10, TargetEntityID: 132, Name: "Hideout", Color: "red", Icon: "pin", Shape: "circle", Size: "small"}})
+}
+
+func TestClient_PlanMerge(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+	seedDuplicate(t, fk)
+
+	p, err := c.PlanMerge(1, TypeCharacter, 30, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"skip character 'Arya' (Entity ID: 132): attribute 'Strength' (ID: 90) (survivor has attribute)",
+		"copy character 'Arya' (Entity ID: 132): attribute 'Agility' (ID: 91)",
+		"copy character 'Arya' (Entity ID: 132): entity note 'Alias' (ID: 94)",
+		"copy character 'Arya' (Entity ID: 132): relation 'Rival' (ID: 92)",
+		"update organisation 'Night's Watch' (Entity ID: 150): organization member 'Arya' (ID: 97)",
+		"update quest 'Beyond the Wall' (Entity ID: 160): quest character 'Arya' (ID: 96)",
+		"update character 'Jon Snow' (Entity ID: 131): relation 'Cousin' (ID: 95)",
+		"update item 'Dagger' (Entity ID: 141): character_id",
+		"skip location 'Winterfell' (Entity ID: 110): map point 'Hideout' (map points cannot be updated)",
+		"rewrite note 'Rumors' (Entity ID: 145): entry",
+		"delete character 'Arya' (Entity ID: 132)",
+		"",
+	}
+	if diff := cmp.Diff(want, strings.Split(p.String(), "\n")); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if fk.count("POST") != 0 || fk.count("PUT") != 0 || fk.count("DELETE") != 0 {
+		t.Error("got changes during dry run, want none")
+	}
+
+	if _, err = c.PlanMerge(1, TypeCharacter, 30, 30); err == nil {
+		t.Error("got nil error for merge into itself, want error")
+	}
+	if _, err = c.PlanMerge(1, TypeCharacter, 30, 99); err == nil {
+		t.Error("got nil error for missing duplicate, want error")
+	}
+}
+
+func TestClient_ApplyMerge(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+	seedDuplicate(t, fk)
+
+	p, err := c.PlanMerge(1, TypeCharacter, 30, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.ApplyMerge(p); err != nil {
+		t.Fatal(err)
+	}
+
+	if fk.object("/campaigns/1/characters", 32) != nil {
+		t.Error("got duplicate after merge, want deleted")
+	}
+
+	var attrs []string
+	for _, a := range fk.list("/campaigns/1/entities/130/attributes") {
+		attrs = append(attrs, a["name"].(string))
+	}
+	if diff := cmp.Diff([]string{"Strength", "Agility"}, attrs); diff != "" {
+		t.Errorf("attributes mismatch (-want +got):\n%s", diff)
+	}
+
+	notes := fk.list("/campaigns/1/entities/130/entity_notes")
+	if got := notes[len(notes)-1]["entry"]; got != "<p>[character:30] is Cat</p>" {
+		t.Errorf("got copied note entry <%v>, want mention of survivor", got)
+	}
+	if got := fk.object("/campaigns/1/notes", 45)["entry"]; got != "<p>[character:30] and [entity:130|her]</p>" {
+		t.Errorf("got rewritten entry <%v>, want mentions of survivor", got)
+	}
+
+	tests := []struct {
+		coll  string
+		id    int
+		field string
+		want  int
+	}{
+		{"/campaigns/1/entities/131/relations", 95, "target_id", 130},
+		{"/campaigns/1/quests/60/quest_characters", 96, "character_id", 30},
+		{"/campaigns/1/organisations/50/organisation_members", 97, "character_id", 30},
+		{"/campaigns/1/items", 41, "character_id", 30},
+	}
+	for _, tt := range tests {
+		if got := intField(fk.object(tt.coll, tt.id), tt.field); got != tt.want {
+			t.Errorf("got %s of %s/%d <%d>, want <%d>", tt.field, tt.coll, tt.id, got, tt.want)
+		}
+	}
+
+	rels := fk.list("/campaigns/1/entities/130/relations")
+	if got := rels[len(rels)-1]; got["relation"] != "Rival" || intField(got, "owner_id") != 130 {
+		t.Errorf("got copied relation <%v>, want Rival owned by survivor", got)
+	}
+}
+
+func TestClient_ApplyMerge_Failure(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+	seedDuplicate(t, fk)
+
+	p, err := c.PlanMerge(1, TypeCharacter, 30, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fk.fail["POST /campaigns/1/entities/130/attributes"] = http.StatusInternalServerError
+	if err = c.ApplyMerge(p); err == nil {
+		t.Fatal("got nil error, want error")
+	}
+
+	if fk.object("/campaigns/1/characters", 32) == nil {
+		t.Error("got deleted duplicate after failed copy, want duplicate kept")
+	}
+	if got := p.Steps[1].Err; got == nil {
+		t.Error("got nil error for failed attribute copy, want error")
+	}
+
+	notes := len(fk.list("/campaigns/1/entities/130/entity_notes"))
+	delete(fk.fail, "POST /campaigns/1/entities/130/attributes")
+	if err = c.ApplyMerge(p); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := len(fk.list("/campaigns/1/entities/130/entity_notes")); got != notes {
+		t.Errorf("got <%d> entity notes after retry, want <%d>", got, notes)
+	}
+	if got := len(fk.list("/campaigns/1/entities/130/attributes")); got != 2 {
+		t.Errorf("got <%d> attributes after retry, want <%d>", got, 2)
+	}
+	if fk.object("/campaigns/1/characters", 32) != nil {
+		t.Error("got duplicate after retry, want deleted")
+	}
+}
+
+func TestClient_PlanMerge_Replan(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+	seedDuplicate(t, fk)
+	fk.seed(t, "/campaigns/1/entities/132/entity_events", &EntityEvent{ID: 98, SimpleEntityEvent: SimpleEntityEvent{EntityID: 132, Day: 1, Month: 2, Year: 290}})
+	fk.seed(t, "/campaigns/1/entities/132/entity_events", &EntityEvent{ID: 99, SimpleEntityEvent: SimpleEntityEvent{EntityID: 132, Day: 3, Month: 4, Year: 300, Comment: "Braavos"}})
+	fk.seed(t, "/campaigns/1/entities/132/inventory", &EntityInventory{ID: 100, SimpleEntityInventory: SimpleEntityInventory{EntityID: 132, ItemID: 40, Amount: 1}})
+
+	p, err := c.PlanMerge(1, TypeCharacter, 30, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fk.fail["POST /campaigns/1/entities/130/attributes"] = http.StatusInternalServerError
+	if err = c.ApplyMerge(p); err == nil {
+		t.Fatal("got nil error, want error")
+	}
+
+	if p, err = c.PlanMerge(1, TypeCharacter, 30, 32); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, ms := range p.Steps {
+		switch ms.Resource {
+		case ResourceEntityEvent, ResourceEntityNote, ResourceInventory:
+			got = append(got, ms.String())
+		}
+	}
+
+	want := []string{
+		"skip character 'Arya' (Entity ID: 132): entity event '290-2-1' (ID: 98) (survivor has entity event)",
+		"skip character 'Arya' (Entity ID: 132): entity event 'Braavos' (ID: 99) (survivor has entity event)",
+		"skip character 'Arya' (Entity ID: 132): entity note 'Alias' (ID: 94) (survivor has entity note)",
+		"skip character 'Arya' (Entity ID: 132): inventory 'item 40' (ID: 100) (survivor has inventory)",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBackup_planMerge_Nested(t *testing.T) {
+	p, err := testBackup(t).planMerge(1, TypeLocation, 3, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"update location 'Winterfell' (Entity ID: 103): parent_location_id",
+		"update location 'The North' (Entity ID: 102): parent_location_id",
+		"update location 'The Reach' (Entity ID: 105): parent_location_id",
+		"update location 'King's Landing' (Entity ID: 110): parent_location_id",
+		"delete location 'Westeros' (Entity ID: 101)",
+		"",
+	}
+	if diff := cmp.Diff(want, strings.Split(p.String(), "\n")); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	if got := p.Steps[0].body["parent_location_id"]; got != nil {
+		t.Errorf("got survivor parent <%v>, want <nil>", got)
+	}
+	if got := p.Steps[1].body["parent_location_id"]; got != 3 {
+		t.Errorf("got child parent <%v>, want <%d>", got, 3)
+	}
+}
@@ -13,11 +13,11 @@ import (
 // For more information, visit: https://kanka.io/en-US/docs/1.0/notes
 type Note struct {
 	SimpleNote
-	ID             int       `json:"id"`
+	ID             NoteID    `json:"id"`
 	ImageFull      string    `json:"image_full"`
 	ImageThumb     string    `json:"image_thumb"`
 	HasCustomImage bool      `json:"has_custom_image"`
-	EntityID       int       `json:"entity_id"`
+	EntityID       EntityID  `json:"entity_id"`
 	CreatedAt      time.Time `json:"created_at"`
 	CreatedBy      int       `json:"created_by"`
 	UpdatedAt      time.Time `json:"updated_at"`
@@ -34,13 +34,13 @@ type Note struct {
 // SimpleNote contains only the simple information about a note.
 // SimpleNote is primarily used to create new notes for posting to Kanka.
 type SimpleNote struct {
-	Name      string `json:"name"`
-	Entry     string `json:"entry,omitempty"`
-	Type      string `json:"type,omitempty"`
-	Tags      []int  `json:"tags,omitempty"`
-	IsPrivate bool   `json:"is_private,omitempty"`
-	Image     string `json:"image,omitempty"`
-	ImageURL  string `json:"image_url,omitempty"`
+	Name      string  `json:"name"`
+	Entry     string  `json:"entry,omitempty"`
+	Type      string  `json:"type,omitempty"`
+	Tags      []TagID `json:"tags,omitempty"`
+	IsPrivate bool    `json:"is_private,omitempty"`
+	Image     string  `json:"image,omitempty"`
+	ImageURL  string  `json:"image_url,omitempty"`
 }
 
 // MarshalJSON marshals the SimpleNote into its JSON-encoded form if it
@@ -54,12 +54,25 @@ func (sn SimpleNote) MarshalJSON() ([]byte, error) {
 	return json.Marshal(alias(sn))
 }
 
+// EntryMarkdown returns the SimpleNote's Entry converted into Markdown.
+func (sn SimpleNote) EntryMarkdown() string {
+	return EntryToMarkdown(sn.Entry)
+}
+
+// SetEntryMarkdown sets the SimpleNote's Entry to the HTML of the provided
+// Markdown.
+func (sn *SimpleNote) SetEntryMarkdown(md string) {
+	sn.Entry = MarkdownToEntry(md)
+}
+
 // NoteService handles communication with the Note endpoint.
 type NoteService service
 
 // Index returns the list of all Notes in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return Notes that have
 // been changed since that time.
+//
+// Deprecated: Use CampaignHandle.Notes, which takes typed IDs.
 func (ns *NoteService) Index(campID int, sync *time.Time) ([]*Note, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -85,6 +98,8 @@ func (ns *NoteService) Index(campID int, sync *time.Time) ([]*Note, error) {
 
 // Get returns the Note associated with noteID from the Campaign
 // associated with campID.
+//
+// Deprecated: Use CampaignHandle.Notes, which takes typed IDs.
 func (ns *NoteService) Get(campID int, noteID int) (*Note, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -112,6 +127,8 @@ func (ns *NoteService) Get(campID int, noteID int) (*Note, error) {
 // Create creates a new Note in the Campaign associated with campID using
 // the provided SimpleNote data.
 // Create returns the newly created Note.
+//
+// Deprecated: Use CampaignHandle.Notes, which takes typed IDs.
 func (ns *NoteService) Create(campID int, note SimpleNote) (*Note, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -139,6 +156,8 @@ func (ns *NoteService) Create(campID int, note SimpleNote) (*Note, error) {
 // Update updates an existing Note associated with noteID from the
 // Campaign associated with campID using the provided SimpleNote data.
 // Update returns the newly updated Note.
+//
+// Deprecated: Use CampaignHandle.Notes, which takes typed IDs.
 func (ns *NoteService) Update(campID int, noteID int, note SimpleNote) (*Note, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -170,6 +189,8 @@ func (ns *NoteService) Update(campID int, noteID int, note SimpleNote) (*Note, e
 
 // Delete deletes an existing Note associated with noteID from the
 // Campaign associated with campID.
+//
+// Deprecated: Use CampaignHandle.Notes, which takes typed IDs.
 func (ns *NoteService) Delete(campID int, noteID int) error {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
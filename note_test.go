@@ -137,7 +137,7 @@ func TestNoteService_Get(t *testing.T) {
 			Entry:     "\n<p>A very tasty plant whose seeds taste of pepper, Emberweed possesses a hidden danger. Its famous seeds, used often in Dwarven and Gnomish cooking, are incredibly flammable and will explode if left too long near a fire.</p>\n",
 			Image:     "notes/2XAeetUHrO0TDB0lU6tAsa46wvdIEmZ1jmsSUC20.jpeg",
 			IsPrivate: false,
-			Tags:      []int{3742},
+			Tags:      []TagID{3742},
 			Type:      "Worldbuilding",
 		},
 		ID:             2142,
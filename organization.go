@@ -13,16 +13,16 @@ import (
 // For more information. visit: https://kanka.io/en-US/docs/1.0/organisations
 type Organization struct {
 	SimpleOrganization
-	ID             int       `json:"id"`
-	ImageFull      string    `json:"image_full"`
-	ImageThumb     string    `json:"image_thumb"`
-	HasCustomImage bool      `json:"has_custom_image"`
-	EntityID       int       `json:"entity_id"`
-	CreatedAt      time.Time `json:"created_at"`
-	CreatedBy      int       `json:"created_by"`
-	UpdatedAt      time.Time `json:"updated_at"`
-	UpdatedBy      int       `json:"updated_by"`
-	Members        int       `json:"members"`
+	ID             OrganizationID `json:"id"`
+	ImageFull      string         `json:"image_full"`
+	ImageThumb     string         `json:"image_thumb"`
+	HasCustomImage bool           `json:"has_custom_image"`
+	EntityID       EntityID       `json:"entity_id"`
+	CreatedAt      time.Time      `json:"created_at"`
+	CreatedBy      int            `json:"created_by"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	UpdatedBy      int            `json:"updated_by"`
+	Members        int            `json:"members"`
 
 	Attributes   Attributes   `json:"attributes"`
 	EntityEvents EntityEvents `json:"entity_events"`
@@ -36,15 +36,15 @@ type Organization struct {
 // SimpleOrganization is primarily used to create new organizations for posting
 // to Kanka.
 type SimpleOrganization struct {
-	Name           string `json:"name"`
-	Entry          string `json:"entry,omitempty"`
-	Type           string `json:"type,omitempty"`
-	OrganizationID int    `json:"organisation_id,omitempty"`
-	LocationID     int    `json:"location_id,omitempty"`
-	Tags           []int  `json:"tags,omitempty"`
-	IsPrivate      bool   `json:"is_private,omitempty"`
-	Image          string `json:"image,omitempty"`
-	ImageURL       string `json:"image_url,omitempty"`
+	Name           string         `json:"name"`
+	Entry          string         `json:"entry,omitempty"`
+	Type           string         `json:"type,omitempty"`
+	OrganizationID OrganizationID `json:"organisation_id,omitempty"`
+	LocationID     LocationID     `json:"location_id,omitempty"`
+	Tags           []TagID        `json:"tags,omitempty"`
+	IsPrivate      bool           `json:"is_private,omitempty"`
+	Image          string         `json:"image,omitempty"`
+	ImageURL       string         `json:"image_url,omitempty"`
 }
 
 // MarshalJSON marshals the SimpleOrganization into its JSON-encoded form if it
@@ -58,12 +58,25 @@ func (so SimpleOrganization) MarshalJSON() ([]byte, error) {
 	return json.Marshal(alias(so))
 }
 
+// EntryMarkdown returns the SimpleOrganization's Entry converted into Markdown.
+func (so SimpleOrganization) EntryMarkdown() string {
+	return EntryToMarkdown(so.Entry)
+}
+
+// SetEntryMarkdown sets the SimpleOrganization's Entry to the HTML of the provided
+// Markdown.
+func (so *SimpleOrganization) SetEntryMarkdown(md string) {
+	so.Entry = MarkdownToEntry(md)
+}
+
 // OrganizationService handles communication with the Organization endpoint.
 type OrganizationService service
 
 // Index returns the list of all Organizations in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return Organizations that have
 // been changed since that time.
+//
+// Deprecated: Use CampaignHandle.Organizations, which takes typed IDs.
 func (os *OrganizationService) Index(campID int, sync *time.Time) ([]*Organization, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -89,6 +102,8 @@ func (os *OrganizationService) Index(campID int, sync *time.Time) ([]*Organizati
 
 // Get returns the Organization associated with orgID from the Campaign
 // associated with campID.
+//
+// Deprecated: Use CampaignHandle.Organizations, which takes typed IDs.
 func (os *OrganizationService) Get(campID int, orgID int) (*Organization, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -116,6 +131,8 @@ func (os *OrganizationService) Get(campID int, orgID int) (*Organization, error)
 // Create creates a new Organization in the Campaign associated with campID using
 // the provided SimpleOrganization data.
 // Create returns the newly created Organization.
+//
+// Deprecated: Use CampaignHandle.Organizations, which takes typed IDs.
 func (os *OrganizationService) Create(campID int, org SimpleOrganization) (*Organization, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -143,6 +160,8 @@ func (os *OrganizationService) Create(campID int, org SimpleOrganization) (*Orga
 // Update updates an existing Organization associated with orgID from the
 // Campaign associated with campID using the provided SimpleOrganization data.
 // Update returns the newly updated Organization.
+//
+// Deprecated: Use CampaignHandle.Organizations, which takes typed IDs.
 func (os *OrganizationService) Update(campID int, orgID int, org SimpleOrganization) (*Organization, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -174,6 +193,8 @@ func (os *OrganizationService) Update(campID int, orgID int, org SimpleOrganizat
 
 // Delete deletes an existing Organization associated with orgID from the
 // Campaign associated with campID.
+//
+// Deprecated: Use CampaignHandle.Organizations, which takes typed IDs.
 func (os *OrganizationService) Delete(campID int, orgID int) error {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
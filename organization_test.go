@@ -138,7 +138,7 @@ func TestOrganizationService_Get(t *testing.T) {
 			Type:           "Company",
 			Image:          "organisations/PZQVp6lFwpcXSbw0kERQUfklZ7nSc4rAlieHaieh.png",
 			IsPrivate:      false,
-			Tags:           []int{35131},
+			Tags:           []TagID{35131},
 			LocationID:     115366,
 			OrganizationID: 23578,
 		},
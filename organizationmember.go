@@ -23,10 +23,10 @@ type OrganizationMember struct {
 // SimpleOrganizationMember is primarily used to create new organization
 // members for posting to Kanka.
 type SimpleOrganizationMember struct {
-	CharacterID    int    `json:"character_id"`
-	OrganizationID int    `json:"organisation_id"`
-	Role           string `json:"role,omitempty"`
-	IsPrivate      bool   `json:"is_private,omitempty"`
+	CharacterID    CharacterID    `json:"character_id"`
+	OrganizationID OrganizationID `json:"organisation_id"`
+	Role           string         `json:"role,omitempty"`
+	IsPrivate      bool           `json:"is_private,omitempty"`
 }
 
 // OrganizationMemberService handles communication with the OrganizationMember endpoint.
@@ -36,6 +36,8 @@ type OrganizationMemberService service
 // associated with orgID in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return OrganizationMembers
 // that have been changed since that time.
+//
+// Deprecated: Use CampaignHandle.OrganizationMembers, which takes typed IDs.
 func (os *OrganizationMemberService) Index(campID int, orgID int, sync *time.Time) ([]*OrganizationMember, error) {
 	var err error
 	end := EndpointCampaign
@@ -67,6 +69,8 @@ func (os *OrganizationMemberService) Index(campID int, orgID int, sync *time.Tim
 
 // Get returns the OrganizationMember associated with memID for the organization
 // associated with orgID from the Campaign associated with campID.
+//
+// Deprecated: Use CampaignHandle.OrganizationMembers, which takes typed IDs.
 func (os *OrganizationMemberService) Get(campID int, orgID int, memID int) (*OrganizationMember, error) {
 	var err error
 	end := EndpointCampaign
@@ -96,10 +100,46 @@ func (os *OrganizationMemberService) Get(campID int, orgID int, memID int) (*Org
 	return wrap.Data, nil
 }
 
+// Create creates a new OrganizationMember for the organization associated
+// with orgID in the Campaign associated with campID using the provided
+// SimpleOrganizationMember data.
+// Create returns the newly created OrganizationMember.
+func (os *OrganizationMemberService) Create(campID CampaignID, orgID OrganizationID, mem SimpleOrganizationMember) (*OrganizationMember, error) {
+	var err error
+	end := EndpointCampaign
+
+	if end, err = end.id(int(campID)); err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(EndpointOrganization)
+
+	if end, err = end.id(int(orgID)); err != nil {
+		return nil, fmt.Errorf("invalid Organization ID: %w", err)
+	}
+	end = end.concat(os.end)
+
+	b, err := json.Marshal(mem)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal SimpleOrganizationMember: %w", err)
+	}
+
+	var wrap struct {
+		Data *OrganizationMember `json:"data"`
+	}
+
+	if err = os.client.post(end, bytes.NewReader(b), &wrap); err != nil {
+		return nil, fmt.Errorf("cannot create OrganizationMember for Campaign (ID: %d): %w", campID, err)
+	}
+
+	return wrap.Data, nil
+}
+
 // Update updates an existing OrganizationMember associated with memID for the
 // organization associated with orgID from the Campaign associated with campID
 // using the provided SimpleOrganizationMember data.
 // Update returns the newly updated OrganizationMember.
+//
+// Deprecated: Use CampaignHandle.OrganizationMembers, which takes typed IDs.
 func (os *OrganizationMemberService) Update(campID int, orgID int, memID int, mem SimpleOrganizationMember) (*OrganizationMember, error) {
 	var err error
 	end := EndpointCampaign
@@ -136,6 +176,8 @@ func (os *OrganizationMemberService) Update(campID int, orgID int, memID int, me
 
 // Delete deletes an existing OrganizationMember associated with memID from the
 // Campaign associated with campID.
+//
+// Deprecated: Use CampaignHandle.OrganizationMembers, which takes typed IDs.
 func (os *OrganizationMemberService) Delete(campID int, orgID int, memID int) error {
 	var err error
 	end := EndpointCampaign
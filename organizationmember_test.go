@@ -11,6 +11,7 @@ import (
 
 const (
 	testOrganizationMemberIndex  string = "test_data/organizationmember_index.json"
+	testOrganizationMemberCreate string = "test_data/organizationmember_create.json"
 	testOrganizationMemberGet    string = "test_data/organizationmember_get.json"
 	testOrganizationMemberUpdate string = "test_data/organizationmember_update.json"
 )
@@ -283,6 +284,127 @@ func TestOrganizationMemberService_Get(t *testing.T) {
 	}
 }
 
+func TestOrganizationMemberService_Create(t *testing.T) {
+	mem := SimpleOrganizationMember{
+		CharacterID:    111,
+		OrganizationID: 222,
+		Role:           "Treasurer",
+	}
+	type args struct {
+		campID CampaignID
+		orgID  OrganizationID
+		mem    SimpleOrganizationMember
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    *OrganizationMember
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testOrganizationMemberCreate,
+			args:    args{campID: 5272, orgID: 23579, mem: mem},
+			want:    &OrganizationMember{SimpleOrganizationMember: mem},
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, valid response, invalid campID",
+			status:  http.StatusOK,
+			file:    testOrganizationMemberCreate,
+			args:    args{campID: -123, orgID: 23579, mem: mem},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid orgID",
+			status:  http.StatusOK,
+			file:    testOrganizationMemberCreate,
+			args:    args{campID: 5272, orgID: -123, mem: mem},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, empty mem",
+			status:  http.StatusOK,
+			file:    testOrganizationMemberCreate,
+			args:    args{campID: 5272, orgID: 23579, mem: SimpleOrganizationMember{}},
+			want:    &OrganizationMember{SimpleOrganizationMember: mem},
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, valid response, invalid args",
+			status:  http.StatusOK,
+			file:    testOrganizationMemberCreate,
+			args:    args{campID: -123, orgID: -123, mem: SimpleOrganizationMember{}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, valid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, orgID: 23579, mem: mem},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, invalid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: -123, orgID: -123, mem: SimpleOrganizationMember{}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusUnauthorized, valid args",
+			status:  http.StatusUnauthorized,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, orgID: 23579, mem: mem},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusForbidden, valid args",
+			status:  http.StatusForbidden,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, orgID: 23579, mem: mem},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, orgID: 23579, mem: mem},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.OrganizationMembers.Create(test.args.campID, test.args.orgID, test.args.mem)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestOrganizationMemberService_Update(t *testing.T) {
 	mem := SimpleOrganizationMember{
 		Role:           "Treasurer",
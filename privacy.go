@@ -0,0 +1,341 @@
+package kanka
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Resource identifies the kind of record affected by a bulk operation.
+type Resource string
+
+// Available resources.
+const (
+	ResourceEntity      Resource = "entity"
+	ResourceAttribute   Resource = "attribute"
+	ResourceEntityEvent Resource = "entity event"
+	ResourceEntityNote  Resource = "entity note"
+	ResourceInventory   Resource = "inventory"
+	ResourceRelation    Resource = "relation"
+)
+
+// Available visibilities of entity notes and inventories.
+const (
+	VisibilityAll       = "all"
+	VisibilityAdmin     = "admin"
+	VisibilitySelf      = "self"
+	VisibilityAdminSelf = "admin-self"
+	VisibilityMembers   = "members"
+)
+
+// PrivacyChange is a single change of privacy in a PrivacyPlan. EntityID,
+// Type, ID, and Name identify the entity owning the changed record.
+// RecordID and Label identify the record itself, which is the entity's
+// object for ResourceEntity. Visibility is the new visibility of entity
+// notes and inventories, if any. Err is set if the change failed when the
+// PrivacyPlan was applied.
+type PrivacyChange struct {
+	EntityID   EntityID
+	Type       EntityType
+	ID         ObjectID
+	Name       string
+	Resource   Resource
+	RecordID   int
+	Label      string
+	Private    bool
+	Visibility string
+	Err        error
+
+	end  endpoint
+	body map[string]interface{}
+}
+
+// String returns a description of the PrivacyChange.
+func (pc *PrivacyChange) String() string {
+	state := "public"
+	if pc.Private {
+		state = "private"
+	}
+	if pc.Visibility != "" {
+		state += fmt.Sprintf(" (visibility '%s')", pc.Visibility)
+	}
+
+	if pc.Resource == ResourceEntity {
+		return fmt.Sprintf("%s '%s' (Entity ID: %d): %s", pc.Type, pc.Name, pc.EntityID, state)
+	}
+
+	return fmt.Sprintf("%s '%s' (Entity ID: %d): %s '%s' (ID: %d): %s", pc.Type, pc.Name, pc.EntityID, pc.Resource, pc.Label, pc.RecordID, state)
+}
+
+// PrivacyPlan lists every change needed to make the entities chosen by an
+// EntitySelector, and their sub-resources, private or public. A PrivacyPlan
+// is a preview; nothing changes until it is applied with ApplyPrivacy.
+type PrivacyPlan struct {
+	CampaignID CampaignID
+	Selector   EntitySelector
+	Private    bool
+	Changes    []*PrivacyChange
+}
+
+// String returns a description of every change of the PrivacyPlan, one per
+// line.
+func (p *PrivacyPlan) String() string {
+	var sb strings.Builder
+	for _, pc := range p.Changes {
+		sb.WriteString(pc.String())
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// PlanPrivacy returns the PrivacyPlan making the entities of the Campaign
+// associated with campID chosen by sel private, or public if private is
+// false. The plan covers each entity and its attributes, entity events,
+// entity notes, inventory, and relations. Records that already match are
+// left out. Private entity notes and inventories are made visible to admins
+// only, while public ones are made visible to all.
+func (c *Client) PlanPrivacy(campID CampaignID, sel EntitySelector, private bool) (*PrivacyPlan, error) {
+	b := &Backup{}
+	if err := c.backupObjects(int(campID), b); err != nil {
+		return nil, fmt.Errorf("cannot plan privacy of %s in Campaign (ID: %d): %w", sel, campID, err)
+	}
+
+	refs, err := b.selectRefs(sel)
+	if err != nil {
+		return nil, err
+	}
+
+	ents := make([]*EntityData, len(refs))
+	errs := make([]error, len(refs))
+	forEach(len(refs), func(i int) {
+		ents[i], errs[i] = c.backupEntity(int(campID), refs[i])
+	})
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("cannot plan privacy of Entity (ID: %d) in Campaign (ID: %d): %w", refs[i].EntityID, campID, err)
+		}
+	}
+
+	p := &PrivacyPlan{CampaignID: campID, Selector: sel, Private: private}
+	for i, ref := range refs {
+		changes, err := planEntityPrivacy(int(campID), ref, ents[i], private)
+		if err != nil {
+			return nil, fmt.Errorf("cannot plan privacy of Entity (ID: %d) in Campaign (ID: %d): %w", ref.EntityID, campID, err)
+		}
+		p.Changes = append(p.Changes, changes...)
+	}
+
+	return p, nil
+}
+
+// planEntityPrivacy returns the changes needed to make the referenced
+// object and its sub-resources private or public.
+func planEntityPrivacy(campID int, ref entityRef, ent *EntityData, private bool) ([]*PrivacyChange, error) {
+	var changes []*PrivacyChange
+	add := func(res Resource, id int, label string, current bool, vis string, v interface{}) error {
+		want := visibilityFor(vis, private)
+		if current == private && want == vis {
+			return nil
+		}
+
+		pc := &PrivacyChange{
+			EntityID: EntityID(ref.EntityID),
+			Type:     ref.Type,
+			ID:       ObjectID(ref.ID),
+			Name:     ref.Name,
+			Resource: res,
+			RecordID: id,
+			Label:    label,
+			Private:  private,
+		}
+		if want != vis {
+			pc.Visibility = want
+		}
+
+		var err error
+		if pc.end, pc.body, err = privacyRequest(campID, ref, res, id, v); err != nil {
+			return err
+		}
+		pc.body["is_private"] = private
+		if pc.Visibility != "" {
+			pc.body["visibility"] = pc.Visibility
+		}
+
+		changes = append(changes, pc)
+		return nil
+	}
+
+	if err := add(ResourceEntity, ref.ID, ref.Name, ref.IsPrivate, "", nil); err != nil {
+		return nil, err
+	}
+	for _, a := range ent.Attributes {
+		if err := add(ResourceAttribute, a.ID, a.Name, a.IsPrivate, "", a.SimpleAttribute); err != nil {
+			return nil, err
+		}
+	}
+	for _, e := range ent.EntityEvents {
+		label := e.CalendarDate().String()
+		if e.Comment != "" {
+			label = e.Comment
+		}
+		if err := add(ResourceEntityEvent, e.ID, label, e.IsPrivate, "", e.SimpleEntityEvent); err != nil {
+			return nil, err
+		}
+	}
+	for _, n := range ent.EntityNotes {
+		if err := add(ResourceEntityNote, n.ID, n.Name, n.IsPrivate, n.Visibility, n.SimpleEntityNote); err != nil {
+			return nil, err
+		}
+	}
+	for _, inv := range ent.Inventory {
+		label := fmt.Sprintf("item %d", inv.ItemID)
+		if err := add(ResourceInventory, inv.ID, label, inv.IsPrivate, inv.Visibility, inv.SimpleEntityInventory); err != nil {
+			return nil, err
+		}
+	}
+	for _, r := range ent.Relations {
+		if err := add(ResourceRelation, r.ID, r.Relation, r.IsPrivate, "", r.SimpleRelation); err != nil {
+			return nil, err
+		}
+	}
+
+	return changes, nil
+}
+
+// visibilityFor returns the visibility a record with the current visibility
+// needs to be private or public. Records without a visibility keep none.
+func visibilityFor(current string, private bool) string {
+	switch {
+	case current == "":
+		return ""
+	case !private:
+		return VisibilityAll
+	case current == VisibilityAll || current == VisibilityMembers:
+		return VisibilityAdmin
+	default:
+		return current
+	}
+}
+
+// privacyRequest returns the endpoint and body of the request updating the
+// provided record. Entities are updated through their object, whose name
+// Kanka requires. Sub-resources are updated with their current data, which
+// is provided as v.
+func privacyRequest(campID int, ref entityRef, res Resource, id int, v interface{}) (endpoint, map[string]interface{}, error) {
+	end, err := EndpointCampaign.id(campID)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+
+	if res == ResourceEntity {
+		if end, err = end.concat(ref.Type.endpoint()).id(id); err != nil {
+			return "", nil, fmt.Errorf("invalid %s ID: %w", ref.Type, err)
+		}
+		return end, map[string]interface{}{"name": ref.Name}, nil
+	}
+
+	if end, err = end.concat(endpointEntity).id(ref.EntityID); err != nil {
+		return "", nil, fmt.Errorf("invalid Entity ID: %w", err)
+	}
+
+	switch res {
+	case ResourceAttribute:
+		end = end.concat(EndpointAttribute)
+	case ResourceEntityEvent:
+		end = end.concat(EndpointEntityEvent)
+	case ResourceEntityNote:
+		end = end.concat(EndpointEntityNote)
+	case ResourceInventory:
+		end = end.concat(EndpointEntityInventory)
+	case ResourceRelation:
+		end = end.concat(EndpointRelation)
+	}
+
+	if end, err = end.id(id); err != nil {
+		return "", nil, fmt.Errorf("invalid %s ID: %w", res, err)
+	}
+
+	body, err := recordBody(res, id, v)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return end, body, nil
+}
+
+// recordBody returns the JSON fields of the provided record of the
+// resource associated with id as a map, so that they can be changed before
+// the record is sent.
+func recordBody(res Resource, id int, v interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal %s (ID: %d): %w", res, id, err)
+	}
+
+	var body map[string]interface{}
+	if err = json.Unmarshal(b, &body); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal %s (ID: %d): %w", res, id, err)
+	}
+
+	return body, nil
+}
+
+// ApplyPrivacy applies every change of the PrivacyPlan concurrently. When
+// hiding, entities are hidden before their sub-resources, and when
+// revealing, entities are revealed after them, so that nothing is exposed
+// early. A failed change does not stop the others; its Err is set and
+// ApplyPrivacy returns an error counting the failures.
+func (c *Client) ApplyPrivacy(p *PrivacyPlan) error {
+	var entities, records []*PrivacyChange
+	for _, pc := range p.Changes {
+		pc.Err = nil
+		if pc.Resource == ResourceEntity {
+			entities = append(entities, pc)
+		} else {
+			records = append(records, pc)
+		}
+	}
+
+	phases := [][]*PrivacyChange{records, entities}
+	if p.Private {
+		phases = [][]*PrivacyChange{entities, records}
+	}
+
+	for _, phase := range phases {
+		forEach(len(phase), func(i int) {
+			phase[i].Err = c.applyPrivacyChange(int(p.CampaignID), phase[i])
+		})
+	}
+
+	var failed []*PrivacyChange
+	for _, pc := range p.Changes {
+		if pc.Err != nil {
+			failed = append(failed, pc)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("cannot apply %d of %d privacy changes in Campaign (ID: %d): %w", len(failed), len(p.Changes), p.CampaignID, failed[0].Err)
+	}
+
+	return nil
+}
+
+// applyPrivacyChange sends the request of the PrivacyChange.
+func (c *Client) applyPrivacyChange(campID int, pc *PrivacyChange) error {
+	b, err := json.Marshal(pc.body)
+	if err != nil {
+		return fmt.Errorf("cannot marshal privacy of %s (ID: %d): %w", pc.Resource, pc.RecordID, err)
+	}
+
+	var wrap struct {
+		Data json.RawMessage `json:"data"`
+	}
+
+	if err = c.put(pc.end, bytes.NewReader(b), &wrap); err != nil {
+		return fmt.Errorf("cannot update privacy of %s (ID: %d) for Campaign (ID: %d): %w", pc.Resource, pc.RecordID, campID, err)
+	}
+
+	return nil
+}
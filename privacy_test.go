@@ -0,0 +1,103 @@
+package kanka
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_PlanPrivacy(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+	fk.seed(t, "/campaigns/1/entities/130/entity_notes", &EntityNote{ID: 88, SimpleEntityNote: SimpleEntityNote{EntityID: 130, Name: "Training", Visibility: VisibilityAll}})
+
+	sel := EntitySelector{Types: []EntityType{TypeCharacter}, LocationID: 10}
+	p, err := c.PlanPrivacy(1, sel, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"character 'Arya Stark' (Entity ID: 130): private",
+		"character 'Arya Stark' (Entity ID: 130): attribute 'Strength' (ID: 80): private",
+		"character 'Arya Stark' (Entity ID: 130): entity event '290-2-1' (ID: 81): private",
+		"character 'Arya Stark' (Entity ID: 130): entity note 'Training' (ID: 88): private (visibility 'admin')",
+		"character 'Arya Stark' (Entity ID: 130): inventory 'item 40' (ID: 84): private",
+		"character 'Arya Stark' (Entity ID: 130): relation 'Sister' (ID: 85): private",
+		"",
+	}
+	if diff := cmp.Diff(want, strings.Split(p.String(), "\n")); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if got := fk.object("/campaigns/1/characters", 30)["is_private"]; got == true {
+		t.Error("got private character after planning, want unchanged")
+	}
+
+	if _, err = c.PlanPrivacy(1, EntitySelector{}, true); err == nil {
+		t.Error("got nil error for empty selector, want error")
+	}
+}
+
+func TestClient_ApplyPrivacy(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+	fk.seed(t, "/campaigns/1/entities/130/entity_notes", &EntityNote{ID: 88, SimpleEntityNote: SimpleEntityNote{EntityID: 130, Name: "Training", Visibility: VisibilityAll}})
+
+	p, err := c.PlanPrivacy(1, EntitySelector{LocationID: 10, Types: []EntityType{TypeCharacter}}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.ApplyPrivacy(p); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := fk.object("/campaigns/1/characters", 30)["is_private"]; got != true {
+		t.Errorf("got character privacy <%v>, want <true>", got)
+	}
+	note := fk.object("/campaigns/1/entities/130/entity_notes", 88)
+	if note["is_private"] != true || note["visibility"] != VisibilityAdmin || note["name"] != "Training" {
+		t.Errorf("got note <%v>, want private note visible to admins", note)
+	}
+	if got := fk.object("/campaigns/1/entities/130/relations", 85)["relation"]; got != "Sister" {
+		t.Errorf("got relation <%v>, want <Sister>", got)
+	}
+
+	p, err = c.PlanPrivacy(1, EntitySelector{Types: []EntityType{TypeCharacter}}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Changes) != 1 || p.Changes[0].EntityID != 131 || p.Changes[0].Resource != ResourceRelation {
+		t.Errorf("got changes <%v>, want only the relation of Jon", p.Changes)
+	}
+
+	p, err = c.PlanPrivacy(1, EntitySelector{Types: []EntityType{TypeCharacter}}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fk.fail["PUT /campaigns/1/entities/130/relations/85"] = http.StatusInternalServerError
+	if err = c.ApplyPrivacy(p); err == nil {
+		t.Fatal("got nil error for failed change, want error")
+	}
+
+	failed := 0
+	for _, pc := range p.Changes {
+		if pc.Err != nil {
+			failed++
+		}
+	}
+	if failed != 1 {
+		t.Errorf("got <%d> failed changes, want <%d>", failed, 1)
+	}
+	if got := fk.object("/campaigns/1/characters", 31)["is_private"]; got != false {
+		t.Errorf("got character privacy <%v>, want <false>", got)
+	}
+	if got := fk.object("/campaigns/1/entities/130/entity_notes", 88)["visibility"]; got != VisibilityAll {
+		t.Errorf("got note visibility <%v>, want <%s>", got, VisibilityAll)
+	}
+}
@@ -5,16 +5,16 @@ import "fmt"
 // Profile provides simple data about the current user.
 // For more information, visit: https://kanka.io/en-US/docs/1.0/profile
 type Profile struct {
-	ID                int    `json:"id"`
-	Name              string `json:"name"`
-	Avatar            string `json:"avatar"`
-	AvatarThumb       string `json:"avatar_thumb"`
-	Locale            string `json:"locale"`
-	Timezone          string `json:"timezone"`
-	DateFormat        string `json:"date_format"`
-	DefaultPagination int    `json:"default_pagination"`
-	LastCampaignID    int    `json:"last_campaign_id"`
-	IsPatreon         bool   `json:"is_patreon"`
+	ID                int        `json:"id"`
+	Name              string     `json:"name"`
+	Avatar            string     `json:"avatar"`
+	AvatarThumb       string     `json:"avatar_thumb"`
+	Locale            string     `json:"locale"`
+	Timezone          string     `json:"timezone"`
+	DateFormat        string     `json:"date_format"`
+	DefaultPagination int        `json:"default_pagination"`
+	LastCampaignID    CampaignID `json:"last_campaign_id"`
+	IsPatreon         bool       `json:"is_patreon"`
 }
 
 // ProfileService handles communication with the Profile endpoint.
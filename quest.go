@@ -13,11 +13,11 @@ import (
 // For more information, visit: https://kanka.io/en-US/docs/1.0/quests
 type Quest struct {
 	SimpleQuest
-	ID             int       `json:"id"`
+	ID             QuestID   `json:"id"`
 	ImageFull      string    `json:"image_full"`
 	ImageThumb     string    `json:"image_thumb"`
 	HasCustomImage bool      `json:"has_custom_image"`
-	EntityID       int       `json:"entity_id"`
+	EntityID       EntityID  `json:"entity_id"`
 	CreatedAt      time.Time `json:"created_at"`
 	CreatedBy      int       `json:"created_by"`
 	UpdatedAt      time.Time `json:"updated_at"`
@@ -36,16 +36,16 @@ type Quest struct {
 // SimpleQuest contains only the simple information about a quest.
 // SimpleQuest is primarily used to create new quests for posting to Kanka.
 type SimpleQuest struct {
-	Name        string `json:"name"`
-	Entry       string `json:"entry,omitempty"`
-	Type        string `json:"type,omitempty"`
-	QuestID     int    `json:"quest_id,omitempty"`
-	CharacterID int    `json:"character_id,omitempty"`
-	Tags        []int  `json:"tags,omitempty"`
-	IsPrivate   bool   `json:"is_private,omitempty"`
-	IsCompleted bool   `json:"is_completed,omitempty"`
-	Image       string `json:"image,omitempty"`
-	ImageURL    string `json:"image_url,omitempty"`
+	Name        string      `json:"name"`
+	Entry       string      `json:"entry,omitempty"`
+	Type        string      `json:"type,omitempty"`
+	QuestID     QuestID     `json:"quest_id,omitempty"`
+	CharacterID CharacterID `json:"character_id,omitempty"`
+	Tags        []TagID     `json:"tags,omitempty"`
+	IsPrivate   bool        `json:"is_private,omitempty"`
+	IsCompleted bool        `json:"is_completed,omitempty"`
+	Image       string      `json:"image,omitempty"`
+	ImageURL    string      `json:"image_url,omitempty"`
 }
 
 // MarshalJSON marshals the SimpleQuest into its JSON-encoded form if it
@@ -59,12 +59,25 @@ func (sq SimpleQuest) MarshalJSON() ([]byte, error) {
 	return json.Marshal(alias(sq))
 }
 
+// EntryMarkdown returns the SimpleQuest's Entry converted into Markdown.
+func (sq SimpleQuest) EntryMarkdown() string {
+	return EntryToMarkdown(sq.Entry)
+}
+
+// SetEntryMarkdown sets the SimpleQuest's Entry to the HTML of the provided
+// Markdown.
+func (sq *SimpleQuest) SetEntryMarkdown(md string) {
+	sq.Entry = MarkdownToEntry(md)
+}
+
 // QuestService handles communication with the Quest endpoint.
 type QuestService service
 
 // Index returns the list of all Quests in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return Quests that have
 // been changed since that time.
+//
+// Deprecated: Use CampaignHandle.Quests, which takes typed IDs.
 func (qs *QuestService) Index(campID int, sync *time.Time) ([]*Quest, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -90,6 +103,8 @@ func (qs *QuestService) Index(campID int, sync *time.Time) ([]*Quest, error) {
 
 // Get returns the Quest associated with qstID from the Campaign
 // associated with campID.
+//
+// Deprecated: Use CampaignHandle.Quests, which takes typed IDs.
 func (qs *QuestService) Get(campID int, qstID int) (*Quest, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -117,6 +132,8 @@ func (qs *QuestService) Get(campID int, qstID int) (*Quest, error) {
 // Create creates a new Quest in the Campaign associated with campID using
 // the provided SimpleQuest data.
 // Create returns the newly created Quest.
+//
+// Deprecated: Use CampaignHandle.Quests, which takes typed IDs.
 func (qs *QuestService) Create(campID int, qst SimpleQuest) (*Quest, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -144,6 +161,8 @@ func (qs *QuestService) Create(campID int, qst SimpleQuest) (*Quest, error) {
 // Update updates an existing Quest associated with qstID from the
 // Campaign associated with campID using the provided SimpleQuest data.
 // Update returns the newly updated Quest.
+//
+// Deprecated: Use CampaignHandle.Quests, which takes typed IDs.
 func (qs *QuestService) Update(campID int, qstID int, qst SimpleQuest) (*Quest, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -175,6 +194,8 @@ func (qs *QuestService) Update(campID int, qstID int, qst SimpleQuest) (*Quest,
 
 // Delete deletes an existing Quest associated with qstID from the
 // Campaign associated with campID.
+//
+// Deprecated: Use CampaignHandle.Quests, which takes typed IDs.
 func (qs *QuestService) Delete(campID int, qstID int) error {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
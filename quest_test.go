@@ -137,7 +137,7 @@ func TestQuestService_Get(t *testing.T) {
 			Entry:       "\n<p>Just do it</p>\n",
 			Image:       "quests/MWmHjSk0NvwkuZMXJyfIc1CIMBqBauUxpFjZIUK7.jpeg",
 			IsPrivate:   false,
-			Tags:        []int{35131},
+			Tags:        []TagID{35131},
 			CharacterID: 116623,
 			QuestID:     10393,
 			Type:        "Cliche",
@@ -21,11 +21,11 @@ type QuestCharacter struct {
 // SimpleQuestCharacter contains only the simple information about a questcharacter.
 // SimpleQuestCharacter is primarily used to create new quest characters for posting to Kanka.
 type SimpleQuestCharacter struct {
-	QuestID     int    `json:"quest_id"`
-	CharacterID int    `json:"character_id"`
-	Description string `json:"description,omitempty"`
-	Role        string `json:"role,omitempty"`
-	IsPrivate   bool   `json:"is_private,omitempty"`
+	QuestID     QuestID     `json:"quest_id"`
+	CharacterID CharacterID `json:"character_id"`
+	Description string      `json:"description,omitempty"`
+	Role        string      `json:"role,omitempty"`
+	IsPrivate   bool        `json:"is_private,omitempty"`
 }
 
 // QuestCharacterService handles communication with the QuestCharacter endpoint.
@@ -35,6 +35,8 @@ type QuestCharacterService service
 // qstID in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return QuestCharacters that have
 // been changed since that time.
+//
+// Deprecated: Use CampaignHandle.QuestCharacters, which takes typed IDs.
 func (qs *QuestCharacterService) Index(campID int, qstID int, sync *time.Time) ([]*QuestCharacter, error) {
 	var err error
 	end := EndpointCampaign
@@ -66,6 +68,8 @@ func (qs *QuestCharacterService) Index(campID int, qstID int, sync *time.Time) (
 
 // Get returns the QuestCharacter associated with qchID for the quest associated
 // with qstID from the Campaign associated with campID.
+//
+// Deprecated: Use CampaignHandle.QuestCharacters, which takes typed IDs.
 func (qs *QuestCharacterService) Get(campID int, qstID int, qchID int) (*QuestCharacter, error) {
 	var err error
 	end := EndpointCampaign
@@ -98,6 +102,8 @@ func (qs *QuestCharacterService) Get(campID int, qstID int, qchID int) (*QuestCh
 // Create creates a new QuestCharacter for the quest associated with qstID in the
 // Campaign associated with campID using the provided SimpleQuestCharacter data.
 // Create returns the newly created QuestCharacter.
+//
+// Deprecated: Use CampaignHandle.QuestCharacters, which takes typed IDs.
 func (qs *QuestCharacterService) Create(campID int, qstID int, qch SimpleQuestCharacter) (*QuestCharacter, error) {
 	var err error
 	end := EndpointCampaign
@@ -132,6 +138,8 @@ func (qs *QuestCharacterService) Create(campID int, qstID int, qch SimpleQuestCh
 // associated with qstID from the Campaign associated with campID using the
 // provided SimpleQuestCharacter data.
 // Update returns the newly updated QuestCharacter.
+//
+// Deprecated: Use CampaignHandle.QuestCharacters, which takes typed IDs.
 func (qs *QuestCharacterService) Update(campID int, qstID int, qchID int, qch SimpleQuestCharacter) (*QuestCharacter, error) {
 	var err error
 	end := EndpointCampaign
@@ -168,6 +176,8 @@ func (qs *QuestCharacterService) Update(campID int, qstID int, qchID int, qch Si
 
 // Delete deletes an existing QuestCharacter associated with qchID from the
 // Campaign associated with campID.
+//
+// Deprecated: Use CampaignHandle.QuestCharacters, which takes typed IDs.
 func (qs *QuestCharacterService) Delete(campID int, qstID int, qchID int) error {
 	var err error
 	end := EndpointCampaign
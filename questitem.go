@@ -21,11 +21,11 @@ type QuestItem struct {
 // SimpleQuestItem contains only the simple information about a quest item.
 // SimpleQuestItem is primarily used to create new quest items for posting to Kanka.
 type SimpleQuestItem struct {
-	QuestID     int    `json:"quest_id"`
-	ItemID      int    `json:"item_id"`
-	Description string `json:"description,omitempty"`
-	Role        string `json:"role,omitempty"`
-	IsPrivate   bool   `json:"is_private,omitempty"`
+	QuestID     QuestID `json:"quest_id"`
+	ItemID      ItemID  `json:"item_id"`
+	Description string  `json:"description,omitempty"`
+	Role        string  `json:"role,omitempty"`
+	IsPrivate   bool    `json:"is_private,omitempty"`
 }
 
 // QuestItemService handles communication with the QuestItem endpoint.
@@ -35,6 +35,8 @@ type QuestItemService service
 // qstID in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return QuestItems that have
 // been changed since that time.
+//
+// Deprecated: Use CampaignHandle.QuestItems, which takes typed IDs.
 func (qs *QuestItemService) Index(campID int, qstID int, sync *time.Time) ([]*QuestItem, error) {
 	var err error
 	end := EndpointCampaign
@@ -66,6 +68,8 @@ func (qs *QuestItemService) Index(campID int, qstID int, sync *time.Time) ([]*Qu
 
 // Get returns the QuestItem associated with itemID for the quest associated
 // with qstID from the Campaign associated with campID.
+//
+// Deprecated: Use CampaignHandle.QuestItems, which takes typed IDs.
 func (qs *QuestItemService) Get(campID int, qstID int, itemID int) (*QuestItem, error) {
 	var err error
 	end := EndpointCampaign
@@ -98,6 +102,8 @@ func (qs *QuestItemService) Get(campID int, qstID int, itemID int) (*QuestItem,
 // Create creates a new QuestItem for the quest associated with qstID in the
 // Campaign associated with campID using the provided SimpleQuestItem data.
 // Create returns the newly created QuestItem.
+//
+// Deprecated: Use CampaignHandle.QuestItems, which takes typed IDs.
 func (qs *QuestItemService) Create(campID int, qstID int, item SimpleQuestItem) (*QuestItem, error) {
 	var err error
 	end := EndpointCampaign
@@ -132,6 +138,8 @@ func (qs *QuestItemService) Create(campID int, qstID int, item SimpleQuestItem)
 // associated with qstID from the Campaign associated with campID using the
 // provided SimpleQuestItem data.
 // Update returns the newly updated QuestItem.
+//
+// Deprecated: Use CampaignHandle.QuestItems, which takes typed IDs.
 func (qs *QuestItemService) Update(campID int, qstID int, itemID int, item SimpleQuestItem) (*QuestItem, error) {
 	var err error
 	end := EndpointCampaign
@@ -168,6 +176,8 @@ func (qs *QuestItemService) Update(campID int, qstID int, itemID int, item Simpl
 
 // Delete deletes an existing QuestItem associated with itemID from the
 // Campaign associated with campID.
+//
+// Deprecated: Use CampaignHandle.QuestItems, which takes typed IDs.
 func (qs *QuestItemService) Delete(campID int, qstID int, itemID int) error {
 	var err error
 	end := EndpointCampaign
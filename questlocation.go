@@ -21,11 +21,11 @@ type QuestLocation struct {
 // SimpleQuestLocation contains only the simple information about a quest location.
 // SimpleQuestLocation is primarily used to create new quest locations for posting to Kanka.
 type SimpleQuestLocation struct {
-	QuestID     int    `json:"quest_id"`
-	LocationID  int    `json:"location_id"`
-	Description string `json:"description,omitempty"`
-	Role        string `json:"role,omitempty"`
-	IsPrivate   bool   `json:"is_private,omitempty"`
+	QuestID     QuestID    `json:"quest_id"`
+	LocationID  LocationID `json:"location_id"`
+	Description string     `json:"description,omitempty"`
+	Role        string     `json:"role,omitempty"`
+	IsPrivate   bool       `json:"is_private,omitempty"`
 }
 
 // QuestLocationService handles communication with the QuestLocation endpoint.
@@ -35,6 +35,8 @@ type QuestLocationService service
 // qstID in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return QuestLocations that have
 // been changed since that time.
+//
+// Deprecated: Use CampaignHandle.QuestLocations, which takes typed IDs.
 func (qs *QuestLocationService) Index(campID int, qstID int, sync *time.Time) ([]*QuestLocation, error) {
 	var err error
 	end := EndpointCampaign
@@ -42,7 +44,7 @@ func (qs *QuestLocationService) Index(campID int, qstID int, sync *time.Time) ([
 	if end, err = end.id(campID); err != nil {
 		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
 	}
-	end = end.concat(EndpointQuestLocation)
+	end = end.concat(EndpointQuest)
 
 	if end, err = end.id(qstID); err != nil {
 		return nil, fmt.Errorf("invalid Quest ID: %w", err)
@@ -66,6 +68,8 @@ func (qs *QuestLocationService) Index(campID int, qstID int, sync *time.Time) ([
 
 // Get returns the QuestLocation associated with qlocID for the quest associated
 // with qstID from the Campaign associated with campID.
+//
+// Deprecated: Use CampaignHandle.QuestLocations, which takes typed IDs.
 func (qs *QuestLocationService) Get(campID int, qstID int, qlocID int) (*QuestLocation, error) {
 	var err error
 	end := EndpointCampaign
@@ -73,7 +77,7 @@ func (qs *QuestLocationService) Get(campID int, qstID int, qlocID int) (*QuestLo
 	if end, err = end.id(campID); err != nil {
 		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
 	}
-	end = end.concat(EndpointQuestLocation)
+	end = end.concat(EndpointQuest)
 
 	if end, err = end.id(qstID); err != nil {
 		return nil, fmt.Errorf("invalid Quest ID: %w", err)
@@ -98,6 +102,8 @@ func (qs *QuestLocationService) Get(campID int, qstID int, qlocID int) (*QuestLo
 // Create creates a new QuestLocation for the quest associated with qstID in the
 // Campaign associated with campID using the provided SimpleQuestLocation data.
 // Create returns the newly created QuestLocation.
+//
+// Deprecated: Use CampaignHandle.QuestLocations, which takes typed IDs.
 func (qs *QuestLocationService) Create(campID int, qstID int, qloc SimpleQuestLocation) (*QuestLocation, error) {
 	var err error
 	end := EndpointCampaign
@@ -105,7 +111,7 @@ func (qs *QuestLocationService) Create(campID int, qstID int, qloc SimpleQuestLo
 	if end, err = end.id(campID); err != nil {
 		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
 	}
-	end = end.concat(EndpointQuestLocation)
+	end = end.concat(EndpointQuest)
 
 	if end, err = end.id(qstID); err != nil {
 		return nil, fmt.Errorf("invalid Quest ID: %w", err)
@@ -132,6 +138,8 @@ func (qs *QuestLocationService) Create(campID int, qstID int, qloc SimpleQuestLo
 // associated with qstID from the Campaign associated with campID using the
 // provided SimpleQuestLocation data.
 // Update returns the newly updated QuestLocation.
+//
+// Deprecated: Use CampaignHandle.QuestLocations, which takes typed IDs.
 func (qs *QuestLocationService) Update(campID int, qstID int, qlocID int, qloc SimpleQuestLocation) (*QuestLocation, error) {
 	var err error
 	end := EndpointCampaign
@@ -139,7 +147,7 @@ func (qs *QuestLocationService) Update(campID int, qstID int, qlocID int, qloc S
 	if end, err = end.id(campID); err != nil {
 		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
 	}
-	end = end.concat(EndpointQuestLocation)
+	end = end.concat(EndpointQuest)
 
 	if end, err = end.id(qstID); err != nil {
 		return nil, fmt.Errorf("invalid Quest ID: %w", err)
@@ -168,6 +176,8 @@ func (qs *QuestLocationService) Update(campID int, qstID int, qlocID int, qloc S
 
 // Delete deletes an existing QuestLocation associated with qlocID from the
 // Campaign associated with campID.
+//
+// Deprecated: Use CampaignHandle.QuestLocations, which takes typed IDs.
 func (qs *QuestLocationService) Delete(campID int, qstID int, qlocID int) error {
 	var err error
 	end := EndpointCampaign
@@ -175,7 +185,7 @@ func (qs *QuestLocationService) Delete(campID int, qstID int, qlocID int) error
 	if end, err = end.id(campID); err != nil {
 		return fmt.Errorf("invalid Campaign ID: %w", err)
 	}
-	end = end.concat(EndpointQuestLocation)
+	end = end.concat(EndpointQuest)
 
 	if end, err = end.id(qstID); err != nil {
 		return fmt.Errorf("invalid Quest ID: %w", err)
@@ -1,8 +1,11 @@
 package kanka
 
 import (
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -607,3 +610,46 @@ func TestQuestLocationService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestQuestLocationService_endpoint(t *testing.T) {
+	var got []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Method+" "+r.URL.Path)
+		if r.Method == "GET" && strings.HasSuffix(r.URL.Path, "quest_locations") {
+			io.WriteString(w, `{"data": []}`)
+			return
+		}
+		io.WriteString(w, `{"data": {"id": 3}}`)
+	}))
+	defer ts.Close()
+
+	c := NewClient(testToken, ts.Client())
+	c.rootURL = ts.URL + "/"
+
+	if _, err := c.QuestLocations.Index(1, 2, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.QuestLocations.Get(1, 2, 3); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.QuestLocations.Create(1, 2, SimpleQuestLocation{LocationID: 4}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.QuestLocations.Update(1, 2, 3, SimpleQuestLocation{LocationID: 4}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.QuestLocations.Delete(1, 2, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"GET /campaigns/1/quests/2/quest_locations",
+		"GET /campaigns/1/quests/2/quest_locations/3",
+		"POST /campaigns/1/quests/2/quest_locations",
+		"PUT /campaigns/1/quests/2/quest_locations/3",
+		"DELETE /campaigns/1/quests/2/quest_locations/3",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
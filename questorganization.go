@@ -21,11 +21,11 @@ type QuestOrganization struct {
 // SimpleQuestOrganization contains only the simple information about a quest organization.
 // SimpleQuestOrganization is primarily used to create new quest organizations for posting to Kanka.
 type SimpleQuestOrganization struct {
-	QuestID        int    `json:"quest_id"`
-	OrganizationID int    `json:"organisation_id"`
-	Description    string `json:"description,omitempty"`
-	Role           string `json:"role,omitempty"`
-	IsPrivate      bool   `json:"is_private,omitempty"`
+	QuestID        QuestID        `json:"quest_id"`
+	OrganizationID OrganizationID `json:"organisation_id"`
+	Description    string         `json:"description,omitempty"`
+	Role           string         `json:"role,omitempty"`
+	IsPrivate      bool           `json:"is_private,omitempty"`
 }
 
 // QuestOrganizationService handles communication with the QuestOrganization endpoint.
@@ -35,6 +35,8 @@ type QuestOrganizationService service
 // qstID in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return QuestOrganizations that have
 // been changed since that time.
+//
+// Deprecated: Use CampaignHandle.QuestOrganizations, which takes typed IDs.
 func (qs *QuestOrganizationService) Index(campID int, qstID int, sync *time.Time) ([]*QuestOrganization, error) {
 	var err error
 	end := EndpointCampaign
@@ -66,6 +68,8 @@ func (qs *QuestOrganizationService) Index(campID int, qstID int, sync *time.Time
 
 // Get returns the QuestOrganization associated with orgID for the quest associated
 // with qstID from the Campaign associated with campID.
+//
+// Deprecated: Use CampaignHandle.QuestOrganizations, which takes typed IDs.
 func (qs *QuestOrganizationService) Get(campID int, qstID int, orgID int) (*QuestOrganization, error) {
 	var err error
 	end := EndpointCampaign
@@ -95,10 +99,46 @@ func (qs *QuestOrganizationService) Get(campID int, qstID int, orgID int) (*Ques
 	return wrap.Data, nil
 }
 
+// Create creates a new QuestOrganization for the quest associated with qstID
+// in the Campaign associated with campID using the provided
+// SimpleQuestOrganization data.
+// Create returns the newly created QuestOrganization.
+func (qs *QuestOrganizationService) Create(campID CampaignID, qstID QuestID, org SimpleQuestOrganization) (*QuestOrganization, error) {
+	var err error
+	end := EndpointCampaign
+
+	if end, err = end.id(int(campID)); err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(EndpointQuest)
+
+	if end, err = end.id(int(qstID)); err != nil {
+		return nil, fmt.Errorf("invalid Quest ID: %w", err)
+	}
+	end = end.concat(qs.end)
+
+	b, err := json.Marshal(org)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal SimpleQuestOrganization: %w", err)
+	}
+
+	var wrap struct {
+		Data *QuestOrganization `json:"data"`
+	}
+
+	if err = qs.client.post(end, bytes.NewReader(b), &wrap); err != nil {
+		return nil, fmt.Errorf("cannot create QuestOrganization for Campaign (ID: %d): %w", campID, err)
+	}
+
+	return wrap.Data, nil
+}
+
 // Update updates an existing QuestOrganization associated with orgID for the quest
 // associated with qstID from the Campaign associated with campID using the
 // provided SimpleQuestOrganization data.
 // Update returns the newly updated QuestOrganization.
+//
+// Deprecated: Use CampaignHandle.QuestOrganizations, which takes typed IDs.
 func (qs *QuestOrganizationService) Update(campID int, qstID int, orgID int, org SimpleQuestOrganization) (*QuestOrganization, error) {
 	var err error
 	end := EndpointCampaign
@@ -135,6 +175,8 @@ func (qs *QuestOrganizationService) Update(campID int, qstID int, orgID int, org
 
 // Delete deletes an existing QuestOrganization associated with orgID from the
 // Campaign associated with campID.
+//
+// Deprecated: Use CampaignHandle.QuestOrganizations, which takes typed IDs.
 func (qs *QuestOrganizationService) Delete(campID int, qstID int, orgID int) error {
 	var err error
 	end := EndpointCampaign
@@ -11,6 +11,7 @@ import (
 
 const (
 	testQuestOrganizationIndex  string = "test_data/questorganization_index.json"
+	testQuestOrganizationCreate string = "test_data/questorganization_create.json"
 	testQuestOrganizationGet    string = "test_data/questorganization_get.json"
 	testQuestOrganizationUpdate string = "test_data/questorganization_update.json"
 )
@@ -277,6 +278,127 @@ func TestQuestOrganizationService_Get(t *testing.T) {
 	}
 }
 
+func TestQuestOrganizationService_Create(t *testing.T) {
+	org := SimpleQuestOrganization{
+		QuestID:        777,
+		OrganizationID: 888,
+		Role:           "Threshold Guardian",
+	}
+	type args struct {
+		campID CampaignID
+		qstID  QuestID
+		org    SimpleQuestOrganization
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    *QuestOrganization
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testQuestOrganizationCreate,
+			args:    args{campID: 5272, qstID: 10394, org: org},
+			want:    &QuestOrganization{SimpleQuestOrganization: org},
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, valid response, invalid campID",
+			status:  http.StatusOK,
+			file:    testQuestOrganizationCreate,
+			args:    args{campID: -123, qstID: 10394, org: org},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid qstID",
+			status:  http.StatusOK,
+			file:    testQuestOrganizationCreate,
+			args:    args{campID: 5272, qstID: -123, org: org},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, empty org",
+			status:  http.StatusOK,
+			file:    testQuestOrganizationCreate,
+			args:    args{campID: 5272, qstID: 10394, org: SimpleQuestOrganization{}},
+			want:    &QuestOrganization{SimpleQuestOrganization: org},
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, valid response, invalid args",
+			status:  http.StatusOK,
+			file:    testQuestOrganizationCreate,
+			args:    args{campID: -123, qstID: -123, org: SimpleQuestOrganization{}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, valid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, qstID: 10394, org: org},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, invalid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: -123, qstID: -123, org: SimpleQuestOrganization{}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusUnauthorized, valid args",
+			status:  http.StatusUnauthorized,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, qstID: 10394, org: org},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusForbidden, valid args",
+			status:  http.StatusForbidden,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, qstID: 10394, org: org},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, qstID: 10394, org: org},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.QuestOrganizations.Create(test.args.campID, test.args.qstID, test.args.org)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestQuestOrganizationService_Update(t *testing.T) {
 	org := SimpleQuestOrganization{
 		QuestID:        777,
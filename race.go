@@ -13,11 +13,11 @@ import (
 // For more information, visit: https://kanka.io/en-US/docs/1.0/races
 type Race struct {
 	SimpleRace
-	ID             int       `json:"id"`
+	ID             RaceID    `json:"id"`
 	ImageFull      string    `json:"image_full"`
 	ImageThumb     string    `json:"image_thumb"`
 	HasCustomImage bool      `json:"has_custom_image"`
-	EntityID       int       `json:"entity_id"`
+	EntityID       EntityID  `json:"entity_id"`
 	CreatedAt      time.Time `json:"created_at"`
 	CreatedBy      int       `json:"created_by"`
 	UpdatedAt      time.Time `json:"updated_at"`
@@ -34,14 +34,14 @@ type Race struct {
 // SimpleRace contains only the simple information about a race.
 // SimpleRace is primarily used to create new races for posting to Kanka.
 type SimpleRace struct {
-	Name      string `json:"name"`
-	Entry     string `json:"entry,omitempty"`
-	Type      string `json:"type,omitempty"`
-	RaceID    int    `json:"race_id,omitempty"`
-	Tags      []int  `json:"tags,omitempty"`
-	IsPrivate bool   `json:"is_private,omitempty"`
-	Image     string `json:"image,omitempty"`
-	ImageURL  string `json:"image_url,omitempty"`
+	Name      string  `json:"name"`
+	Entry     string  `json:"entry,omitempty"`
+	Type      string  `json:"type,omitempty"`
+	RaceID    RaceID  `json:"race_id,omitempty"`
+	Tags      []TagID `json:"tags,omitempty"`
+	IsPrivate bool    `json:"is_private,omitempty"`
+	Image     string  `json:"image,omitempty"`
+	ImageURL  string  `json:"image_url,omitempty"`
 }
 
 // MarshalJSON marshals the SimpleRace into its JSON-encoded form if it
@@ -55,12 +55,25 @@ func (sr SimpleRace) MarshalJSON() ([]byte, error) {
 	return json.Marshal(alias(sr))
 }
 
+// EntryMarkdown returns the SimpleRace's Entry converted into Markdown.
+func (sr SimpleRace) EntryMarkdown() string {
+	return EntryToMarkdown(sr.Entry)
+}
+
+// SetEntryMarkdown sets the SimpleRace's Entry to the HTML of the provided
+// Markdown.
+func (sr *SimpleRace) SetEntryMarkdown(md string) {
+	sr.Entry = MarkdownToEntry(md)
+}
+
 // RaceService handles communication with the Race endpoint.
 type RaceService service
 
 // Index returns the list of all Races in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return Races that have
 // been changed since that time.
+//
+// Deprecated: Use CampaignHandle.Races, which takes typed IDs.
 func (rs *RaceService) Index(campID int, sync *time.Time) ([]*Race, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -86,6 +99,8 @@ func (rs *RaceService) Index(campID int, sync *time.Time) ([]*Race, error) {
 
 // Get returns the Race associated with raceID from the Campaign
 // associated with campID.
+//
+// Deprecated: Use CampaignHandle.Races, which takes typed IDs.
 func (rs *RaceService) Get(campID int, raceID int) (*Race, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -113,6 +128,8 @@ func (rs *RaceService) Get(campID int, raceID int) (*Race, error) {
 // Create creates a new Race in the Campaign associated with campID using
 // the provided SimpleRace data.
 // Create returns the newly created Race.
+//
+// Deprecated: Use CampaignHandle.Races, which takes typed IDs.
 func (rs *RaceService) Create(campID int, race SimpleRace) (*Race, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -140,6 +157,8 @@ func (rs *RaceService) Create(campID int, race SimpleRace) (*Race, error) {
 // Update updates an existing Race associated with raceID from the
 // Campaign associated with campID using the provided SimpleRace data.
 // Update returns the newly updated Race.
+//
+// Deprecated: Use CampaignHandle.Races, which takes typed IDs.
 func (rs *RaceService) Update(campID int, raceID int, race SimpleRace) (*Race, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -171,6 +190,8 @@ func (rs *RaceService) Update(campID int, raceID int, race SimpleRace) (*Race, e
 
 // Delete deletes an existing Race associated with raceID from the
 // Campaign associated with campID.
+//
+// Deprecated: Use CampaignHandle.Races, which takes typed IDs.
 func (rs *RaceService) Delete(campID int, raceID int) error {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -137,7 +137,7 @@ func TestRaceService_Get(t *testing.T) {
 			Entry:     "\n<p>Fish people!</p>\n",
 			Image:     "races/0QRnoZ5GK8aOMMMGIIRycEhODN4KPXHAInSmwed2.jpeg",
 			IsPrivate: false,
-			Tags:      []int{35131},
+			Tags:      []TagID{35131},
 			Type:      "Amphibious",
 			RaceID:    44044,
 		},
@@ -0,0 +1,134 @@
+package kanka
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// rateLimiter spaces out the requests of a Client and every goroutine
+// sharing it. rateLimiter allows bursts of up to burst requests and then
+// one request per interval. A zero interval disables the limit.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+
+	now   func() time.Time
+	sleep func(time.Duration)
+}
+
+// newRateLimiter returns a rateLimiter without a limit.
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{now: time.Now, sleep: time.Sleep}
+}
+
+// set limits the rateLimiter to n requests per duration. A non-positive n
+// or duration disables the limit.
+func (l *rateLimiter) set(n int, per time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if n <= 0 || per <= 0 {
+		l.interval, l.burst, l.tokens = 0, 0, 0
+		return
+	}
+
+	l.interval = per / time.Duration(n)
+	l.burst = float64(n)
+	l.tokens = l.burst
+	l.last = l.now()
+}
+
+// wait blocks until another request is allowed.
+func (l *rateLimiter) wait() {
+	l.mu.Lock()
+	if l.interval == 0 {
+		l.mu.Unlock()
+		return
+	}
+
+	now := l.now()
+	l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	// Reserve a token even if it is not yet available so that waiting
+	// requests are served in turn.
+	l.tokens--
+	d := time.Duration(-l.tokens * float64(l.interval))
+	l.mu.Unlock()
+
+	if d > 0 {
+		l.sleep(d)
+	}
+}
+
+// SetRateLimit limits the Client to n requests per duration, shared by every
+// service and goroutine using the Client. Short bursts of up to n requests
+// are sent immediately. A non-positive n or duration removes the limit,
+// which is the default. Bulk operations retry requests rejected by the
+// Kanka rate limit either way. For the current Kanka rate limits, visit:
+// https://kanka.io/en-US/docs/1.0/setup#endpoints
+func (c *Client) SetRateLimit(n int, per time.Duration) {
+	c.limiter.set(n, per)
+}
+
+// bulkWorkers is the number of concurrent requests made by bulk
+// operations.
+const bulkWorkers = 4
+
+// bulkRetries is the number of times bulk operations retry a request
+// rejected by the Kanka rate limit.
+const bulkRetries = 5
+
+// bulkBackoff is the wait before the first retry of a bulk operation. The
+// wait doubles before every further retry so that the retries span more
+// than the minute over which Kanka counts requests.
+const bulkBackoff = 2 * time.Second
+
+// retry calls fn until it returns an error other than a rejection by the
+// Kanka rate limit or until it has been retried bulkRetries times, waiting
+// longer before every retry. retry returns the last error of fn.
+func (l *rateLimiter) retry(fn func() error) error {
+	d := bulkBackoff
+	for i := 0; ; i++ {
+		err := fn()
+
+		var se *serverError
+		if i == bulkRetries || !errors.As(err, &se) || se.code != http.StatusTooManyRequests {
+			return err
+		}
+
+		l.sleep(d)
+		d *= 2
+	}
+}
+
+// forEach calls fn with every index up to n using up to bulkWorkers
+// goroutines and waits for every call to return.
+func forEach(n int, fn func(i int)) {
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < bulkWorkers && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
@@ -0,0 +1,82 @@
+package kanka
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRateLimiter_Wait(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	var slept []time.Duration
+
+	l := newRateLimiter()
+	l.now = func() time.Time { return now }
+	l.sleep = func(d time.Duration) { slept = append(slept, d) }
+
+	l.wait()
+	if len(slept) != 0 {
+		t.Fatalf("got sleeps <%v> without limit, want none", slept)
+	}
+
+	l.set(2, time.Minute)
+	for i := 0; i < 4; i++ {
+		l.wait()
+	}
+
+	want := []time.Duration{30 * time.Second, time.Minute}
+	if diff := cmp.Diff(want, slept); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	slept = nil
+	now = now.Add(2 * time.Minute)
+	l.wait()
+	if len(slept) != 0 {
+		t.Errorf("got sleeps <%v> after refill, want none", slept)
+	}
+
+	l.set(0, time.Minute)
+	l.wait()
+	if len(slept) != 0 {
+		t.Errorf("got sleeps <%v> after removing limit, want none", slept)
+	}
+}
+
+func TestClient_SetRateLimit(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+
+	var sleeps int32
+	c.limiter.sleep = func(time.Duration) { atomic.AddInt32(&sleeps, 1) }
+	c.SetRateLimit(2, time.Hour)
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.Characters.Get(1, 30); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := c.EntityTags.Delete(1, 130, 83); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := atomic.LoadInt32(&sleeps); got != 2 {
+		t.Errorf("got <%d> waits, want <%d>", got, 2)
+	}
+}
+
+func TestForEach(t *testing.T) {
+	got := make([]int, 10)
+	forEach(len(got), func(i int) { got[i] = i * i })
+
+	want := []int{0, 1, 4, 9, 16, 25, 36, 49, 64, 81}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	forEach(0, func(i int) { t.Errorf("got call <%d> for no items, want none", i) })
+}
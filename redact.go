@@ -0,0 +1,378 @@
+package kanka
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrPrivate is returned by Redact for objects which are private
+// themselves and therefore cannot be shown to players at all.
+var ErrPrivate = errors.New("object is private")
+
+// Redact returns a copy of the provided object which is safe to show to
+// players. The object must be a pointer to one of the core objects, such as
+// a *Character or a *Quest, and is returned as the same type. The original
+// object is left unchanged.
+//
+// The copy excludes private attributes, entity events, entity files,
+// relations, inventory, and character traits, as well as entity notes that
+// are private or not visible to all. Relations targeting private entities,
+// inventory holding private items, and mentions of private entities in
+// entries, attribute values, and traits are removed using r. So are
+// references by ID to private objects, such as private tags and a private
+// parent location, family, or race. Mentions and references that r cannot
+// resolve are kept.
+//
+// Redact returns an error wrapping ErrPrivate if the object itself is
+// private.
+func Redact(v interface{}, r MentionResolver) (interface{}, error) {
+	if r == nil {
+		return nil, fmt.Errorf("cannot redact %T: missing MentionResolver", v)
+	}
+
+	var err error
+	switch v := v.(type) {
+	case *Character:
+		cp := *v
+		if err = redactEntity(r, cp.entityFields()); err != nil {
+			return nil, err
+		}
+		if cp.Traits.Data, err = redactTraits(r, cp.Traits.Data); err != nil {
+			return nil, err
+		}
+		return &cp, nil
+	case *Location:
+		cp := *v
+		err = redactEntity(r, cp.entityFields())
+		return &cp, err
+	case *Family:
+		cp := *v
+		err = redactEntity(r, cp.entityFields())
+		return &cp, err
+	case *Organization:
+		cp := *v
+		err = redactEntity(r, cp.entityFields())
+		return &cp, err
+	case *Item:
+		cp := *v
+		err = redactEntity(r, cp.entityFields())
+		return &cp, err
+	case *Note:
+		cp := *v
+		err = redactEntity(r, cp.entityFields())
+		return &cp, err
+	case *Event:
+		cp := *v
+		err = redactEntity(r, cp.entityFields())
+		return &cp, err
+	case *Race:
+		cp := *v
+		err = redactEntity(r, cp.entityFields())
+		return &cp, err
+	case *Quest:
+		cp := *v
+		err = redactEntity(r, cp.entityFields())
+		return &cp, err
+	case *Journal:
+		cp := *v
+		err = redactEntity(r, cp.entityFields())
+		return &cp, err
+	case *Tag:
+		cp := *v
+		err = redactEntity(r, cp.entityFields())
+		return &cp, err
+	default:
+		return nil, fmt.Errorf("cannot redact %T: unsupported type", v)
+	}
+}
+
+// entityFields holds the identity and privacy of a core object and pointers
+// to the fields shared by every core object.
+type entityFields struct {
+	typ       EntityType
+	id        int
+	isPrivate bool
+	entry     *string
+
+	attrs  *Attributes
+	events *EntityEvents
+	files  *EntityFiles
+	notes  *EntityNotes
+	rels   *Relations
+	inv    *Inventory
+
+	refs []idRef
+}
+
+// idRef is a field holding the ID of an object of the type, such as a parent
+// location, or a list of such IDs, such as tags.
+type idRef struct {
+	typ EntityType
+	v   reflect.Value
+}
+
+// newIDRef returns an idRef of the ID field that ptr points to.
+func newIDRef(typ EntityType, ptr interface{}) idRef {
+	return idRef{typ, reflect.ValueOf(ptr).Elem()}
+}
+
+// entityFields returns the shared fields of the Character.
+func (v *Character) entityFields() *entityFields {
+	return &entityFields{TypeCharacter, int(v.ID), v.IsPrivate, &v.Entry, &v.Attributes, &v.EntityEvents, &v.EntityFiles, &v.EntityNotes, &v.Relations, &v.Inventory, []idRef{newIDRef(TypeFamily, &v.FamilyID), newIDRef(TypeLocation, &v.LocationID), newIDRef(TypeRace, &v.RaceID), newIDRef(TypeTag, &v.Tags)}}
+}
+
+func (v *Location) entityFields() *entityFields {
+	return &entityFields{TypeLocation, int(v.ID), v.IsPrivate, &v.Entry, &v.Attributes, &v.EntityEvents, &v.EntityFiles, &v.EntityNotes, &v.Relations, &v.Inventory, []idRef{newIDRef(TypeLocation, &v.ParentLocationID), newIDRef(TypeTag, &v.Tags)}}
+}
+
+func (v *Family) entityFields() *entityFields {
+	return &entityFields{TypeFamily, int(v.ID), v.IsPrivate, &v.Entry, &v.Attributes, &v.EntityEvents, &v.EntityFiles, &v.EntityNotes, &v.Relations, &v.Inventory, []idRef{newIDRef(TypeLocation, &v.LocationID), newIDRef(TypeFamily, &v.FamilyID), newIDRef(TypeTag, &v.Tags), newIDRef(TypeCharacter, &v.Members)}}
+}
+
+func (v *Organization) entityFields() *entityFields {
+	return &entityFields{TypeOrganization, int(v.ID), v.IsPrivate, &v.Entry, &v.Attributes, &v.EntityEvents, &v.EntityFiles, &v.EntityNotes, &v.Relations, &v.Inventory, []idRef{newIDRef(TypeOrganization, &v.OrganizationID), newIDRef(TypeLocation, &v.LocationID), newIDRef(TypeTag, &v.Tags)}}
+}
+
+func (v *Item) entityFields() *entityFields {
+	return &entityFields{TypeItem, int(v.ID), v.IsPrivate, &v.Entry, &v.Attributes, &v.EntityEvents, &v.EntityFiles, &v.EntityNotes, &v.Relations, &v.Inventory, []idRef{newIDRef(TypeLocation, &v.LocationID), newIDRef(TypeCharacter, &v.CharacterID), newIDRef(TypeTag, &v.Tags)}}
+}
+
+func (v *Note) entityFields() *entityFields {
+	return &entityFields{TypeNote, int(v.ID), v.IsPrivate, &v.Entry, &v.Attributes, &v.EntityEvents, &v.EntityFiles, &v.EntityNotes, &v.Relations, &v.Inventory, []idRef{newIDRef(TypeTag, &v.Tags)}}
+}
+
+func (v *Event) entityFields() *entityFields {
+	return &entityFields{TypeEvent, int(v.ID), v.IsPrivate, &v.Entry, &v.Attributes, &v.EntityEvents, &v.EntityFiles, &v.EntityNotes, &v.Relations, &v.Inventory, []idRef{newIDRef(TypeLocation, &v.LocationID), newIDRef(TypeTag, &v.Tags)}}
+}
+
+func (v *Race) entityFields() *entityFields {
+	return &entityFields{TypeRace, int(v.ID), v.IsPrivate, &v.Entry, &v.Attributes, &v.EntityEvents, &v.EntityFiles, &v.EntityNotes, &v.Relations, &v.Inventory, []idRef{newIDRef(TypeRace, &v.RaceID), newIDRef(TypeTag, &v.Tags)}}
+}
+
+func (v *Quest) entityFields() *entityFields {
+	return &entityFields{TypeQuest, int(v.ID), v.IsPrivate, &v.Entry, &v.Attributes, &v.EntityEvents, &v.EntityFiles, &v.EntityNotes, &v.Relations, &v.Inventory, []idRef{newIDRef(TypeQuest, &v.QuestID), newIDRef(TypeCharacter, &v.CharacterID), newIDRef(TypeTag, &v.Tags)}}
+}
+
+func (v *Journal) entityFields() *entityFields {
+	return &entityFields{TypeJournal, int(v.ID), v.IsPrivate, &v.Entry, &v.Attributes, &v.EntityEvents, &v.EntityFiles, &v.EntityNotes, &v.Relations, &v.Inventory, []idRef{newIDRef(TypeLocation, &v.LocationID), newIDRef(TypeCharacter, &v.CharacterID), newIDRef(TypeTag, &v.Tags)}}
+}
+
+func (v *Tag) entityFields() *entityFields {
+	return &entityFields{TypeTag, int(v.ID), v.IsPrivate, &v.Entry, &v.Attributes, &v.EntityEvents, &v.EntityFiles, &v.EntityNotes, &v.Relations, &v.Inventory, []idRef{newIDRef(TypeTag, &v.TagID), newIDRef(TypeTag, &v.Tags), newIDRef(MentionEntity, &v.Entities)}}
+}
+
+// fields returns the shared fields of every core object of the Backup keyed
+// by entity ID.
+func (b *Backup) fields() map[int]*entityFields {
+	fields := make(map[int]*entityFields)
+	for _, v := range b.Characters {
+		fields[int(v.EntityID)] = v.entityFields()
+	}
+	for _, v := range b.Locations {
+		fields[int(v.EntityID)] = v.entityFields()
+	}
+	for _, v := range b.Families {
+		fields[int(v.EntityID)] = v.entityFields()
+	}
+	for _, v := range b.Organizations {
+		fields[int(v.EntityID)] = v.entityFields()
+	}
+	for _, v := range b.Items {
+		fields[int(v.EntityID)] = v.entityFields()
+	}
+	for _, v := range b.Notes {
+		fields[int(v.EntityID)] = v.entityFields()
+	}
+	for _, v := range b.Events {
+		fields[int(v.EntityID)] = v.entityFields()
+	}
+	for _, v := range b.Races {
+		fields[int(v.EntityID)] = v.entityFields()
+	}
+	for _, v := range b.Quests {
+		fields[int(v.EntityID)] = v.entityFields()
+	}
+	for _, v := range b.Journals {
+		fields[int(v.EntityID)] = v.entityFields()
+	}
+	for _, v := range b.Tags {
+		fields[int(v.EntityID)] = v.entityFields()
+	}
+
+	return fields
+}
+
+// redactEntity replaces the shared fields of a copied core object with
+// their redacted versions. The lists are replaced rather than modified so
+// that the original object is left unchanged.
+func redactEntity(r MentionResolver, f *entityFields) error {
+	if f.isPrivate {
+		return fmt.Errorf("cannot redact %s (ID: %d): %w", f.typ, f.id, ErrPrivate)
+	}
+
+	var err error
+	if *f.entry, err = redactMentions(r, *f.entry); err != nil {
+		return fmt.Errorf("cannot redact %s (ID: %d): %w", f.typ, f.id, err)
+	}
+
+	var attrs []*Attribute
+	for _, a := range f.attrs.Data {
+		if a.IsPrivate {
+			continue
+		}
+
+		cp := *a
+		if cp.Value, err = redactMentions(r, cp.Value); err != nil {
+			return fmt.Errorf("cannot redact %s (ID: %d): %w", f.typ, f.id, err)
+		}
+		attrs = append(attrs, &cp)
+	}
+	f.attrs.Data = attrs
+
+	var events []*EntityEvent
+	for _, e := range f.events.Data {
+		if !e.IsPrivate {
+			events = append(events, e)
+		}
+	}
+	f.events.Data = events
+
+	var files []*EntityFile
+	for _, ef := range f.files.Data {
+		if !ef.IsPrivate && (ef.Visibility == "" || ef.Visibility == VisibilityAll) {
+			files = append(files, ef)
+		}
+	}
+	f.files.Data = files
+
+	var notes []EntityNote
+	for _, n := range f.notes.Data {
+		if n.IsPrivate || n.Visibility != VisibilityAll {
+			continue
+		}
+
+		if n.Entry, err = redactMentions(r, n.Entry); err != nil {
+			return fmt.Errorf("cannot redact %s (ID: %d): %w", f.typ, f.id, err)
+		}
+		notes = append(notes, n)
+	}
+	f.notes.Data = notes
+
+	var rels []Relation
+	for _, rel := range f.rels.Data {
+		if rel.IsPrivate {
+			continue
+		}
+
+		hidden, err := isPrivateMention(r, &Mention{Type: MentionEntity, ID: int(rel.TargetID)})
+		if err != nil {
+			return fmt.Errorf("cannot redact %s (ID: %d): %w", f.typ, f.id, err)
+		}
+		if !hidden {
+			rels = append(rels, rel)
+		}
+	}
+	f.rels.Data = rels
+
+	for _, ref := range f.refs {
+		if ref.v.Kind() == reflect.Slice {
+			ids := reflect.Zero(ref.v.Type())
+			for i := 0; i < ref.v.Len(); i++ {
+				hidden, err := isPrivateMention(r, &Mention{Type: ref.typ, ID: int(ref.v.Index(i).Int())})
+				if err != nil {
+					return fmt.Errorf("cannot redact %s (ID: %d): %w", f.typ, f.id, err)
+				}
+				if !hidden {
+					ids = reflect.Append(ids, ref.v.Index(i))
+				}
+			}
+			if ids.Len() != ref.v.Len() {
+				ref.v.Set(ids)
+			}
+			continue
+		}
+
+		if ref.v.Int() == 0 {
+			continue
+		}
+
+		hidden, err := isPrivateMention(r, &Mention{Type: ref.typ, ID: int(ref.v.Int())})
+		if err != nil {
+			return fmt.Errorf("cannot redact %s (ID: %d): %w", f.typ, f.id, err)
+		}
+		if hidden {
+			ref.v.SetInt(0)
+		}
+	}
+
+	if *f.inv != (Inventory{}) {
+		hidden := f.inv.IsPrivate || (f.inv.Visibility != "" && f.inv.Visibility != VisibilityAll)
+		if !hidden {
+			if hidden, err = isPrivateMention(r, &Mention{Type: TypeItem, ID: int(f.inv.ItemID)}); err != nil {
+				return fmt.Errorf("cannot redact %s (ID: %d): %w", f.typ, f.id, err)
+			}
+		}
+		if hidden {
+			*f.inv = Inventory{}
+		}
+	}
+
+	return nil
+}
+
+// redactTraits returns copies of the public traits with mentions of private
+// entities removed.
+func redactTraits(r MentionResolver, traits []*Trait) ([]*Trait, error) {
+	var public []*Trait
+	for _, t := range traits {
+		if t.IsPrivate {
+			continue
+		}
+
+		cp := *t
+		var err error
+		if cp.Entry, err = redactMentions(r, cp.Entry); err != nil {
+			return nil, fmt.Errorf("cannot redact trait '%s': %w", t.Name, err)
+		}
+		public = append(public, &cp)
+	}
+
+	return public, nil
+}
+
+// redactMentions returns the entry without its mentions of private
+// entities.
+func redactMentions(r MentionResolver, entry string) (string, error) {
+	if !strings.Contains(entry, "[") {
+		return entry, nil
+	}
+
+	var nodes []Node
+	for _, n := range ParseEntry(entry) {
+		if m, ok := n.(*Mention); ok {
+			hidden, err := isPrivateMention(r, m)
+			if err != nil {
+				return "", err
+			}
+			if hidden {
+				continue
+			}
+		}
+		nodes = append(nodes, n)
+	}
+
+	return FormatEntry(nodes), nil
+}
+
+// isPrivateMention returns true if the Mention resolves to a private
+// entity.
+func isPrivateMention(r MentionResolver, m *Mention) (bool, error) {
+	t, err := r.Resolve(m)
+	if err != nil {
+		return false, err
+	}
+
+	return t != nil && t.IsPrivate, nil
+}
@@ -0,0 +1,158 @@
+package kanka
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// testRedactCache returns a MentionCache of a public location, a private
+// character, a private item, a private family, and a public and a private
+// tag.
+func testRedactCache() *MentionCache {
+	mc := NewMentionCache()
+	mc.Add(&MentionTarget{Type: TypeLocation, ID: 10, EntityID: 110, Name: "Winterfell"})
+	mc.Add(&MentionTarget{Type: TypeCharacter, ID: 31, EntityID: 131, Name: "Faceless Man", IsPrivate: true})
+	mc.Add(&MentionTarget{Type: TypeItem, ID: 41, EntityID: 141, Name: "Poison", IsPrivate: true})
+	mc.Add(&MentionTarget{Type: TypeFamily, ID: 20, EntityID: 120, Name: "Faceless Men", IsPrivate: true})
+	mc.Add(&MentionTarget{Type: TypeTag, ID: 70, EntityID: 170, Name: "Braavos"})
+	mc.Add(&MentionTarget{Type: TypeTag, ID: 71, EntityID: 171, Name: "Assassins", IsPrivate: true})
+
+	return mc
+}
+
+func TestRedact(t *testing.T) {
+	ch := &Character{
+		ID:       30,
+		EntityID: 130,
+		SimpleCharacter: SimpleCharacter{
+			Name:       "Arya Stark",
+			Entry:      "<p>Trained in [location:10] by [character:31|a stranger] and [entity:131].</p>",
+			FamilyID:   20,
+			LocationID: 10,
+			RaceID:     99,
+			Tags:       []TagID{70, 71},
+		},
+		Traits: Traits{Data: []*Trait{
+			{ID: 1, Name: "Voice", Entry: "Like [character:31]"},
+			{ID: 2, Name: "True Name", IsPrivate: true},
+		}},
+		Attributes: Attributes{Data: []*Attribute{
+			{ID: 80, SimpleAttribute: SimpleAttribute{Name: "Strength", Value: "12"}},
+			{ID: 81, SimpleAttribute: SimpleAttribute{Name: "Kills", Value: "7", IsPrivate: true}},
+			{ID: 82, SimpleAttribute: SimpleAttribute{Name: "Mentor", Value: "[character:31]"}},
+		}},
+		EntityEvents: EntityEvents{Data: []*EntityEvent{
+			{ID: 83, SimpleEntityEvent: SimpleEntityEvent{Comment: "Born"}},
+			{ID: 84, SimpleEntityEvent: SimpleEntityEvent{Comment: "Joins guild", IsPrivate: true}},
+		}},
+		EntityFiles: EntityFiles{Data: []*EntityFile{
+			{ID: 85, Name: "map.png", Visibility: VisibilityAll},
+			{ID: 86, Name: "plot.pdf", Visibility: VisibilityAdmin},
+		}},
+		EntityNotes: EntityNotes{Data: []EntityNote{
+			{ID: 87, SimpleEntityNote: SimpleEntityNote{Name: "Public", Visibility: VisibilityAll, Entry: "Met [character:31]."}},
+			{ID: 88, SimpleEntityNote: SimpleEntityNote{Name: "Members", Visibility: VisibilityMembers}},
+			{ID: 89, SimpleEntityNote: SimpleEntityNote{Name: "Secret", Visibility: VisibilityAll, IsPrivate: true}},
+			{ID: 90, SimpleEntityNote: SimpleEntityNote{Name: "Unset"}},
+		}},
+		Relations: Relations{Data: []Relation{
+			{ID: 91, SimpleRelation: SimpleRelation{Relation: "Home", TargetID: 110}},
+			{ID: 92, SimpleRelation: SimpleRelation{Relation: "Master", TargetID: 131}},
+			{ID: 93, SimpleRelation: SimpleRelation{Relation: "Rival", TargetID: 199, IsPrivate: true}},
+		}},
+		Inventory: Inventory{ID: 94, ItemID: 41, Amount: 1},
+	}
+	orig := *ch
+
+	got, err := Redact(ch, testRedactCache())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Character{
+		ID:       30,
+		EntityID: 130,
+		SimpleCharacter: SimpleCharacter{
+			Name:       "Arya Stark",
+			Entry:      "<p>Trained in [location:10] by  and .</p>",
+			LocationID: 10,
+			RaceID:     99,
+			Tags:       []TagID{70},
+		},
+		Traits: Traits{Data: []*Trait{{ID: 1, Name: "Voice", Entry: "Like "}}},
+		Attributes: Attributes{Data: []*Attribute{
+			{ID: 80, SimpleAttribute: SimpleAttribute{Name: "Strength", Value: "12"}},
+			{ID: 82, SimpleAttribute: SimpleAttribute{Name: "Mentor"}},
+		}},
+		EntityEvents: EntityEvents{Data: []*EntityEvent{{ID: 83, SimpleEntityEvent: SimpleEntityEvent{Comment: "Born"}}}},
+		EntityFiles:  EntityFiles{Data: []*EntityFile{{ID: 85, Name: "map.png", Visibility: VisibilityAll}}},
+		EntityNotes:  EntityNotes{Data: []EntityNote{{ID: 87, SimpleEntityNote: SimpleEntityNote{Name: "Public", Visibility: VisibilityAll, Entry: "Met ."}}}},
+		Relations:    Relations{Data: []Relation{{ID: 91, SimpleRelation: SimpleRelation{Relation: "Home", TargetID: 110}}}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(&orig, ch); diff != "" {
+		t.Errorf("original changed (-want +got):\n%s", diff)
+	}
+}
+
+func TestRedact_References(t *testing.T) {
+	tag := &Tag{
+		ID:       72,
+		EntityID: 172,
+		SimpleTag: SimpleTag{
+			Name:  "Faceless",
+			TagID: 71,
+			Tags:  []TagID{70},
+		},
+		Entities: []EntityID{110, 131, 199},
+	}
+	orig := *tag
+
+	got, err := Redact(tag, testRedactCache())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Tag{
+		ID:        72,
+		EntityID:  172,
+		SimpleTag: SimpleTag{Name: "Faceless", Tags: []TagID{70}},
+		Entities:  []EntityID{110, 199},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(&orig, tag); diff != "" {
+		t.Errorf("original changed (-want +got):\n%s", diff)
+	}
+}
+
+func TestRedact_Errors(t *testing.T) {
+	mc := testRedactCache()
+
+	_, err := Redact(&Quest{ID: 60, SimpleQuest: SimpleQuest{Name: "Revenge", IsPrivate: true}}, mc)
+	if !errors.Is(err, ErrPrivate) {
+		t.Errorf("got err <%v> for private quest, want <%v>", err, ErrPrivate)
+	}
+
+	if _, err = Redact(&Calendar{}, mc); err == nil {
+		t.Error("got nil error for unsupported type, want error")
+	}
+	if _, err = Redact(&Location{}, nil); err == nil {
+		t.Error("got nil error for missing resolver, want error")
+	}
+
+	got, err := Redact(&Location{ID: 10, SimpleLocation: SimpleLocation{Name: "Winterfell", Entry: "[item:41|A vial]"}}, mc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loc := got.(*Location); loc.Entry != "" {
+		t.Errorf("got entry <%s>, want empty entry", loc.Entry)
+	}
+}
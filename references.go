@@ -0,0 +1,293 @@
+package kanka
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ReferenceKind identifies how one entity refers to another.
+type ReferenceKind string
+
+// Available reference kinds.
+const (
+	// RefRelation is a relation targeting the entity.
+	RefRelation ReferenceKind = "relation"
+	// RefInventory is an inventory holding the item.
+	RefInventory ReferenceKind = "inventory"
+	// RefQuestCharacter, RefQuestLocation, RefQuestItem, and
+	// RefQuestOrganization are quest elements listing the object.
+	RefQuestCharacter    ReferenceKind = "quest character"
+	RefQuestLocation     ReferenceKind = "quest location"
+	RefQuestItem         ReferenceKind = "quest item"
+	RefQuestOrganization ReferenceKind = "quest organization"
+	// RefMapPoint is a map point of a location targeting the entity.
+	RefMapPoint ReferenceKind = "map point"
+	// RefMember is an organization membership of the character.
+	RefMember ReferenceKind = "member"
+	// RefFamily is a character belonging to the family.
+	RefFamily ReferenceKind = "family"
+	// RefLocation is an object located in the location.
+	RefLocation ReferenceKind = "location"
+	// RefParent is an object nested in its parent of the same type.
+	RefParent ReferenceKind = "parent"
+	// RefTag is an object carrying the tag.
+	RefTag ReferenceKind = "tag"
+	// RefMention is a mention of the entity in an entry or entity note.
+	RefMention ReferenceKind = "mention"
+)
+
+// Reference is a single record referring to an entity. EntityID is the
+// entity referred to. FromEntityID, FromType, FromID, and FromName identify
+// the entity owning the referring record. RecordID is the ID of the
+// referring record, such as a relation, inventory, quest element, or
+// organization member, or the ID of the entity note holding a mention. It
+// is 0 for references held by the object itself and for map points, which
+// carry no ID.
+type Reference struct {
+	Kind         ReferenceKind
+	EntityID     EntityID
+	FromEntityID EntityID
+	FromType     EntityType
+	FromID       ObjectID
+	FromName     string
+	RecordID     int
+}
+
+// ReferenceIndex answers which records refer to an entity.
+type ReferenceIndex struct {
+	refs    map[int][]*Reference
+	objects map[EntityType]map[int]entityRef
+}
+
+// ReferenceIndex walks the Campaign associated with campID and returns the
+// ReferenceIndex of every reference between its entities.
+func (c *Client) ReferenceIndex(campID CampaignID) (*ReferenceIndex, error) {
+	b, err := c.Backup(campID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot index references of Campaign (ID: %d): %w", campID, err)
+	}
+
+	return b.ReferenceIndex(), nil
+}
+
+// ReferenceIndex returns the ReferenceIndex of every reference between the
+// entities of the Backup.
+func (b *Backup) ReferenceIndex() *ReferenceIndex {
+	ix := &ReferenceIndex{
+		refs:    make(map[int][]*Reference),
+		objects: make(map[EntityType]map[int]entityRef),
+	}
+
+	refs := b.refs()
+	byEntity := make(map[int]entityRef)
+	for _, ref := range refs {
+		if ix.objects[ref.Type] == nil {
+			ix.objects[ref.Type] = make(map[int]entityRef)
+		}
+		ix.objects[ref.Type][ref.ID] = ref
+		byEntity[ref.EntityID] = ref
+	}
+
+	// add indexes a reference from the entity of the referring object to
+	// the entity of the object of the provided type associated with id.
+	add := func(kind ReferenceKind, from entityRef, typ EntityType, id int, record int) {
+		to, ok := ix.objects[typ][id]
+		if !ok || id == 0 {
+			return
+		}
+		ix.add(kind, from, to.EntityID, record)
+	}
+
+	for _, ref := range refs {
+		if ref.Parent != 0 {
+			add(RefParent, ref, ref.Type, ref.Parent, 0)
+		}
+		for _, id := range ref.Tags {
+			add(RefTag, ref, TypeTag, id, 0)
+		}
+	}
+
+	for _, v := range b.Characters {
+		from := ix.objects[TypeCharacter][int(v.ID)]
+		add(RefFamily, from, TypeFamily, int(v.FamilyID), 0)
+		add(RefLocation, from, TypeLocation, int(v.LocationID), 0)
+		ix.addMentions(from, v.Entry, 0)
+	}
+	for _, v := range b.Locations {
+		ix.addMentions(ix.objects[TypeLocation][int(v.ID)], v.Entry, 0)
+	}
+	for _, v := range b.Families {
+		from := ix.objects[TypeFamily][int(v.ID)]
+		add(RefLocation, from, TypeLocation, int(v.LocationID), 0)
+		ix.addMentions(from, v.Entry, 0)
+	}
+	for _, v := range b.Organizations {
+		from := ix.objects[TypeOrganization][int(v.ID)]
+		add(RefLocation, from, TypeLocation, int(v.LocationID), 0)
+		ix.addMentions(from, v.Entry, 0)
+	}
+	for _, v := range b.Items {
+		from := ix.objects[TypeItem][int(v.ID)]
+		add(RefLocation, from, TypeLocation, int(v.LocationID), 0)
+		ix.addMentions(from, v.Entry, 0)
+	}
+	for _, v := range b.Notes {
+		ix.addMentions(ix.objects[TypeNote][int(v.ID)], v.Entry, 0)
+	}
+	for _, v := range b.Events {
+		from := ix.objects[TypeEvent][int(v.ID)]
+		add(RefLocation, from, TypeLocation, int(v.LocationID), 0)
+		ix.addMentions(from, v.Entry, 0)
+	}
+	for _, v := range b.Races {
+		ix.addMentions(ix.objects[TypeRace][int(v.ID)], v.Entry, 0)
+	}
+	for _, v := range b.Quests {
+		ix.addMentions(ix.objects[TypeQuest][int(v.ID)], v.Entry, 0)
+	}
+	for _, v := range b.Journals {
+		from := ix.objects[TypeJournal][int(v.ID)]
+		add(RefLocation, from, TypeLocation, int(v.LocationID), 0)
+		ix.addMentions(from, v.Entry, 0)
+	}
+	for _, v := range b.Tags {
+		ix.addMentions(ix.objects[TypeTag][int(v.ID)], v.Entry, 0)
+	}
+
+	for _, v := range b.QuestCharacters {
+		add(RefQuestCharacter, ix.objects[TypeQuest][int(v.QuestID)], TypeCharacter, int(v.CharacterID), v.ID)
+	}
+	for _, v := range b.QuestLocations {
+		add(RefQuestLocation, ix.objects[TypeQuest][int(v.QuestID)], TypeLocation, int(v.LocationID), v.ID)
+	}
+	for _, v := range b.QuestItems {
+		add(RefQuestItem, ix.objects[TypeQuest][int(v.QuestID)], TypeItem, int(v.ItemID), v.ID)
+	}
+	for _, v := range b.QuestOrganizations {
+		add(RefQuestOrganization, ix.objects[TypeQuest][int(v.QuestID)], TypeOrganization, int(v.OrganizationID), v.ID)
+	}
+	for _, v := range b.OrganizationMembers {
+		add(RefMember, ix.objects[TypeOrganization][int(v.OrganizationID)], TypeCharacter, int(v.CharacterID), v.ID)
+	}
+	for _, v := range b.MapPoints {
+		if from, ok := ix.objects[TypeLocation][int(v.LocationID)]; ok {
+			if _, ok := byEntity[int(v.TargetEntityID)]; ok {
+				ix.add(RefMapPoint, from, int(v.TargetEntityID), 0)
+			}
+		}
+	}
+
+	for _, ent := range b.Entities {
+		from, ok := byEntity[int(ent.EntityID)]
+		if !ok {
+			continue
+		}
+
+		for _, r := range ent.Relations {
+			if _, ok := byEntity[int(r.TargetID)]; ok {
+				ix.add(RefRelation, from, int(r.TargetID), r.ID)
+			}
+		}
+		for _, inv := range ent.Inventory {
+			add(RefInventory, from, TypeItem, int(inv.ItemID), inv.ID)
+		}
+		for _, n := range ent.EntityNotes {
+			ix.addMentions(from, n.Entry, n.ID)
+		}
+	}
+
+	for _, rs := range ix.refs {
+		sort.SliceStable(rs, func(i, j int) bool {
+			if rs[i].Kind != rs[j].Kind {
+				return rs[i].Kind < rs[j].Kind
+			}
+			if rs[i].FromEntityID != rs[j].FromEntityID {
+				return rs[i].FromEntityID < rs[j].FromEntityID
+			}
+			return rs[i].RecordID < rs[j].RecordID
+		})
+	}
+
+	return ix
+}
+
+// add indexes a reference from the referenced object to the entity
+// associated with entID. References from missing objects are ignored.
+func (ix *ReferenceIndex) add(kind ReferenceKind, from entityRef, entID int, record int) {
+	if from.EntityID == 0 {
+		return
+	}
+
+	ix.refs[entID] = append(ix.refs[entID], &Reference{
+		Kind:         kind,
+		EntityID:     EntityID(entID),
+		FromEntityID: EntityID(from.EntityID),
+		FromType:     from.Type,
+		FromID:       ObjectID(from.ID),
+		FromName:     from.Name,
+		RecordID:     record,
+	})
+}
+
+// addMentions indexes a reference for every distinct entity mentioned in
+// the entry of the referenced object, or of its entity note associated with
+// record.
+func (ix *ReferenceIndex) addMentions(from entityRef, entry string, record int) {
+	seen := make(map[int]bool)
+	for _, m := range Mentions(entry) {
+		entID := m.ID
+		if m.Type != MentionEntity {
+			to, ok := ix.objects[m.Type][m.ID]
+			if !ok {
+				continue
+			}
+			entID = to.EntityID
+		}
+
+		if seen[entID] {
+			continue
+		}
+		seen[entID] = true
+		ix.add(RefMention, from, entID, record)
+	}
+}
+
+// To returns every Reference to the entity associated with entID, ordered
+// by kind, referring entity, and record. If kinds are provided, only
+// references of those kinds are returned.
+func (ix *ReferenceIndex) To(entID EntityID, kinds ...ReferenceKind) []*Reference {
+	if len(kinds) == 0 {
+		return ix.refs[int(entID)]
+	}
+
+	want := make(map[ReferenceKind]bool)
+	for _, k := range kinds {
+		want[k] = true
+	}
+
+	var refs []*Reference
+	for _, r := range ix.refs[int(entID)] {
+		if want[r.Kind] {
+			refs = append(refs, r)
+		}
+	}
+
+	return refs
+}
+
+// ToObject returns every Reference to the entity of the object of the
+// provided type associated with id, as To does.
+func (ix *ReferenceIndex) ToObject(typ EntityType, id ObjectID, kinds ...ReferenceKind) []*Reference {
+	ref, ok := ix.objects[typ][int(id)]
+	if !ok {
+		return nil
+	}
+
+	return ix.To(EntityID(ref.EntityID), kinds...)
+}
+
+// Referenced returns true if any record refers to the entity associated
+// with entID.
+func (ix *ReferenceIndex) Referenced(entID EntityID) bool {
+	return len(ix.refs[int(entID)]) > 0
+}
@@ -0,0 +1,70 @@
+package kanka
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_ReferenceIndex(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+	fk.seed(t, "/campaigns/1/locations", &Location{ID: 11, EntityID: 111, SimpleLocation: SimpleLocation{Name: "Godswood", ParentLocationID: 10}})
+	fk.seed(t, "/campaigns/1/entities/131/entity_notes", &EntityNote{ID: 89, SimpleEntityNote: SimpleEntityNote{EntityID: 131, Name: "Letters", Entry: "From [entity:110] and [location:10]"}})
+
+	ix, err := c.ReferenceIndex(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jon := func(kind ReferenceKind, record int) *Reference {
+		return &Reference{Kind: kind, EntityID: 110, FromEntityID: 131, FromType: TypeCharacter, FromID: 31, FromName: "Jon Snow", RecordID: record}
+	}
+
+	want := []*Reference{
+		{Kind: RefLocation, EntityID: 110, FromEntityID: 120, FromType: TypeFamily, FromID: 20, FromName: "Stark"},
+		{Kind: RefLocation, EntityID: 110, FromEntityID: 130, FromType: TypeCharacter, FromID: 30, FromName: "Arya Stark"},
+		{Kind: RefMention, EntityID: 110, FromEntityID: 130, FromType: TypeCharacter, FromID: 30, FromName: "Arya Stark"},
+		jon(RefMention, 89),
+		{Kind: RefParent, EntityID: 110, FromEntityID: 111, FromType: TypeLocation, FromID: 11, FromName: "Godswood"},
+		{Kind: RefQuestLocation, EntityID: 110, FromEntityID: 160, FromType: TypeQuest, FromID: 60, FromName: "Beyond the Wall", RecordID: 62},
+	}
+	if diff := cmp.Diff(want, ix.ToObject(TypeLocation, 10)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	tests := []struct {
+		name  string
+		entID EntityID
+		kinds []ReferenceKind
+		want  []ReferenceKind
+	}{
+		{"character", 131, nil, []ReferenceKind{RefMember, RefQuestCharacter, RefRelation}},
+		{"item", 140, nil, []ReferenceKind{RefInventory, RefQuestItem}},
+		{"map point", 130, nil, []ReferenceKind{RefMapPoint, RefRelation}},
+		{"family", 120, nil, []ReferenceKind{RefFamily, RefFamily}},
+		{"filtered", 131, []ReferenceKind{RefRelation, RefMember}, []ReferenceKind{RefMember, RefRelation}},
+		{"unreferenced", 170, nil, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got []ReferenceKind
+			for _, r := range ix.To(test.entID, test.kinds...) {
+				got = append(got, r.Kind)
+			}
+
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+			if ix.Referenced(test.entID) != (len(ix.To(test.entID)) > 0) {
+				t.Errorf("got Referenced <%t>, want consistent with To", ix.Referenced(test.entID))
+			}
+		})
+	}
+
+	if got := ix.ToObject(TypeCharacter, 99); got != nil {
+		t.Errorf("got references <%v> for missing object, want none", got)
+	}
+}
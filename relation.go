@@ -22,12 +22,12 @@ type Relation struct {
 // SimpleRelation contains only the simple information about a relation.
 // SimpleRelation is primarily used to create new relations for posting to Kanka.
 type SimpleRelation struct {
-	Relation  string `json:"relation"`
-	OwnerID   int    `json:"owner_id"`
-	TargetID  int    `json:"target_id"`
-	Attitude  int    `json:"attitude"`
-	TwoWay    bool   `json:"two_way,omitempty"`
-	IsPrivate bool   `json:"is_private,omitempty"`
+	Relation  string   `json:"relation"`
+	OwnerID   EntityID `json:"owner_id"`
+	TargetID  EntityID `json:"target_id"`
+	Attitude  int      `json:"attitude"`
+	TwoWay    bool     `json:"two_way,omitempty"`
+	IsPrivate bool     `json:"is_private,omitempty"`
 }
 
 // For more information, visit: https://kanka.io/en-US/docs/1.0/relations#create-relation
@@ -70,6 +70,9 @@ type RelationService service
 // entID in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return Relations that have
 // been changed since that time.
+//
+// Deprecated: Use EntityHandle.Relations, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (rs *RelationService) Index(campID int, entID int, sync *time.Time) ([]*Relation, error) {
 	var err error
 	end := EndpointCampaign
@@ -101,6 +104,9 @@ func (rs *RelationService) Index(campID int, entID int, sync *time.Time) ([]*Rel
 
 // Get returns the Relation associated with relID for the entity associated
 // with entID from the Campaign associated with campID.
+//
+// Deprecated: Use EntityHandle.Relations, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (rs *RelationService) Get(campID int, entID int, relID int) (*Relation, error) {
 	var err error
 	end := EndpointCampaign
@@ -133,6 +139,9 @@ func (rs *RelationService) Get(campID int, entID int, relID int) (*Relation, err
 // Create creates a new Relation for the entity associated with entID in the
 // Campaign associated with campID using the provided SimpleRelation data.
 // Create returns the newly created Relation.
+//
+// Deprecated: Use EntityHandle.Relations, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (rs *RelationService) Create(campID int, entID int, rel SimpleRelation) (*Relation, error) {
 	var err error
 	end := EndpointCampaign
@@ -167,6 +176,9 @@ func (rs *RelationService) Create(campID int, entID int, rel SimpleRelation) (*R
 // associated with entID from the Campaign associated with campID using the
 // provided SimpleRelation data.
 // Update returns the newly updated Relation.
+//
+// Deprecated: Use EntityHandle.Relations, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (rs *RelationService) Update(campID int, entID int, relID int, rel SimpleRelation) (*Relation, error) {
 	var err error
 	end := EndpointCampaign
@@ -203,6 +215,9 @@ func (rs *RelationService) Update(campID int, entID int, relID int, rel SimpleRe
 
 // Delete deletes an existing Relation associated with relID from the
 // Campaign associated with campID.
+//
+// Deprecated: Use EntityHandle.Relations, which is returned by
+// CampaignHandle.Entity with a typed EntityID.
 func (rs *RelationService) Delete(campID int, entID int, relID int) error {
 	var err error
 	end := EndpointCampaign
@@ -0,0 +1,878 @@
+package kanka
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// IDMap maps the IDs of the objects in a Backup to the IDs of the objects
+// restored from them. IDMap is keyed by the name of each kind of object's
+// endpoint, such as "characters" or "entities".
+// IDMap can be saved and loaded so that an interrupted Restore can resume
+// where it left off.
+type IDMap map[string]map[int]int
+
+// Get returns the new ID of the object of the provided kind which was
+// restored from the object associated with id.
+func (m IDMap) Get(kind string, id int) (int, bool) {
+	newID, ok := m[kind][id]
+	return newID, ok
+}
+
+// set records newID as the new ID of the object of the provided kind which
+// was restored from the object associated with id.
+func (m IDMap) set(kind string, id int, newID int) {
+	if m[kind] == nil {
+		m[kind] = make(map[int]int)
+	}
+	m[kind][id] = newID
+}
+
+// Save writes the JSON encoding of the IDMap to the provided writer.
+func (m IDMap) Save(w io.Writer) error {
+	if err := json.NewEncoder(w).Encode(m); err != nil {
+		return fmt.Errorf("cannot save IDMap: %w", err)
+	}
+
+	return nil
+}
+
+// LoadIDMap reads an IDMap previously written by Save from the provided
+// reader.
+func LoadIDMap(r io.Reader) (IDMap, error) {
+	m := IDMap{}
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, fmt.Errorf("cannot load IDMap: %w", err)
+	}
+
+	return m, nil
+}
+
+// RestoreOptions configures the behavior of Restore.
+type RestoreOptions struct {
+	// IDs contains the IDs recorded by a previous, interrupted call to
+	// Restore. Objects found in IDs are not restored again.
+	IDs IDMap
+
+	// Checkpoint, if not nil, is called with the updated IDMap every time an
+	// object is restored. Checkpoint is typically used to persist the IDMap.
+	Checkpoint func(IDMap) error
+}
+
+// RestoreReport describes the result of Restore.
+type RestoreReport struct {
+	// IDs maps the IDs of every restored object in the Backup to its ID in
+	// the Campaign.
+	IDs IDMap
+
+	// Dropped lists the references to objects missing from the Backup which
+	// were dropped from the restored objects.
+	Dropped []string
+}
+
+// Restore recreates the contents of the provided Backup in the Campaign
+// associated with campID. Objects are created in dependency order and every
+// reference between them is remapped to the IDs of the newly created
+// objects. References to objects missing from the Backup are dropped and
+// listed in the RestoreReport.
+// Restore returns the RestoreReport of every restored object, even when an
+// error occurs, so that its IDMap can be passed to a later call to resume
+// the restoration.
+func (c *Client) Restore(campID CampaignID, b *Backup, opts *RestoreOptions) (*RestoreReport, error) {
+	if opts == nil {
+		opts = &RestoreOptions{}
+	}
+
+	r := &restorer{
+		client:     c,
+		campID:     int(campID),
+		ids:        opts.IDs,
+		checkpoint: opts.Checkpoint,
+	}
+	if r.ids == nil {
+		r.ids = IDMap{}
+	}
+
+	err := r.run(b)
+	rep := &RestoreReport{IDs: r.ids, Dropped: r.dropped}
+	if err != nil {
+		return rep, fmt.Errorf("cannot restore Backup to Campaign (ID: %d): %w", campID, err)
+	}
+
+	return rep, nil
+}
+
+// restorer holds the state of a single call to Restore.
+type restorer struct {
+	client     *Client
+	campID     int
+	ids        IDMap
+	checkpoint func(IDMap) error
+	dropped    []string
+}
+
+// run restores every object in the provided Backup in dependency order.
+func (r *restorer) run(b *Backup) error {
+	steps := []func(*Backup) error{
+		r.tags,
+		r.locations,
+		r.races,
+		r.families,
+		r.organizations,
+		r.characters,
+		r.items,
+		r.notes,
+		r.events,
+		r.journals,
+		r.quests,
+		r.organizationMembers,
+		r.questElements,
+		r.mapPoints,
+		r.entities,
+	}
+
+	for _, step := range steps {
+		if err := step(b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// done returns true if the object of the provided kind associated with id
+// has already been restored.
+func (r *restorer) done(end endpoint, id int) bool {
+	_, ok := r.ids.Get(string(end), id)
+	return ok
+}
+
+// ref returns the new ID of the object of the provided kind associated with
+// id. ref returns 0 and records the dropped reference if the object has not
+// been restored.
+func (r *restorer) ref(end endpoint, id int) int {
+	if id == 0 {
+		return 0
+	}
+
+	newID, ok := r.ids.Get(string(end), id)
+	if !ok {
+		r.drop(end, id)
+	}
+
+	return newID
+}
+
+// drop records a dropped reference to the object of the provided kind
+// associated with id.
+func (r *restorer) drop(end endpoint, id int) {
+	msg := fmt.Sprintf("%s (ID: %d)", end, id)
+	for _, d := range r.dropped {
+		if d == msg {
+			return
+		}
+	}
+
+	r.dropped = append(r.dropped, msg)
+}
+
+// refs returns the new IDs of the objects of the provided kind associated
+// with ids, dropping those that have not been restored.
+func (r *restorer) refs(end endpoint, ids []int) []int {
+	var newIDs []int
+	for _, id := range ids {
+		if newID := r.ref(end, id); newID != 0 {
+			newIDs = append(newIDs, newID)
+		}
+	}
+
+	return newIDs
+}
+
+// record records newID as the new ID of the object of the provided kind
+// associated with id.
+func (r *restorer) record(end endpoint, id int, newID int) error {
+	r.ids.set(string(end), id, newID)
+
+	if r.checkpoint != nil {
+		if err := r.checkpoint(r.ids); err != nil {
+			return fmt.Errorf("cannot checkpoint IDMap: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// recordObject records the new object and entity IDs of a restored core
+// object.
+func (r *restorer) recordObject(end endpoint, id int, newID int, entID int, newEntID int) error {
+	r.ids.set(string(endpointEntity), entID, newEntID)
+	return r.record(end, id, newID)
+}
+
+// parentOrder returns the indices of n objects ordered so that every parent
+// comes before its children. The key function returns the ID of the object
+// at index i and the ID of its parent. Objects caught in a cycle are ordered
+// arbitrarily.
+func parentOrder(n int, key func(i int) (id int, parent int)) []int {
+	index := make(map[int]int, n)
+	for i := 0; i < n; i++ {
+		id, _ := key(i)
+		index[id] = i
+	}
+
+	order := make([]int, 0, n)
+	seen := make([]bool, n)
+
+	var visit func(i int)
+	visit = func(i int) {
+		if seen[i] {
+			return
+		}
+		seen[i] = true
+
+		if _, parent := key(i); parent != 0 {
+			if p, ok := index[parent]; ok {
+				visit(p)
+			}
+		}
+		order = append(order, i)
+	}
+
+	for i := 0; i < n; i++ {
+		visit(i)
+	}
+
+	return order
+}
+
+func (r *restorer) tags(b *Backup) error {
+	order := parentOrder(len(b.Tags), func(i int) (int, int) { return int(b.Tags[i].ID), int(b.Tags[i].TagID) })
+
+	for _, i := range order {
+		v := b.Tags[i]
+		if r.done(EndpointTag, int(v.ID)) {
+			continue
+		}
+
+		s := v.SimpleTag
+		s.TagID = TagID(r.ref(EndpointTag, int(s.TagID)))
+		s.Tags = nil
+		s.Image = ""
+		s.ImageURL = customImage(v.HasCustomImage, v.ImageFull)
+
+		obj, err := r.client.Tags.Create(r.campID, s)
+		if err != nil {
+			return fmt.Errorf("cannot restore Tag (ID: %d): %w", v.ID, err)
+		}
+
+		if err = r.recordObject(EndpointTag, int(v.ID), int(obj.ID), int(v.EntityID), int(obj.EntityID)); err != nil {
+			return err
+		}
+	}
+
+	// Tags can be tagged with tags created after them, so their own tags are
+	// only applied once every tag exists.
+	for _, v := range b.Tags {
+		if len(v.Tags) == 0 {
+			continue
+		}
+
+		s := v.SimpleTag
+		s.TagID = TagID(r.ref(EndpointTag, int(s.TagID)))
+		s.Tags = intTags(r.refs(EndpointTag, tagInts(v.Tags)))
+		s.Image = ""
+		s.ImageURL = ""
+
+		if _, err := r.client.Tags.Update(r.campID, r.ref(EndpointTag, int(v.ID)), s); err != nil {
+			return fmt.Errorf("cannot restore tags of Tag (ID: %d): %w", v.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *restorer) locations(b *Backup) error {
+	order := parentOrder(len(b.Locations), func(i int) (int, int) { return int(b.Locations[i].ID), int(b.Locations[i].ParentLocationID) })
+
+	for _, i := range order {
+		v := b.Locations[i]
+		if r.done(EndpointLocation, int(v.ID)) {
+			continue
+		}
+
+		s := v.SimpleLocation
+		s.ParentLocationID = LocationID(r.ref(EndpointLocation, int(s.ParentLocationID)))
+		s.Tags = intTags(r.refs(EndpointTag, tagInts(s.Tags)))
+		s.Image = ""
+		s.ImageURL = customImage(v.HasCustomImage, v.ImageFull)
+		s.Map = ""
+
+		obj, err := r.client.Locations.Create(r.campID, s)
+		if err != nil {
+			return fmt.Errorf("cannot restore Location (ID: %d): %w", v.ID, err)
+		}
+
+		if err = r.recordObject(EndpointLocation, int(v.ID), int(obj.ID), int(v.EntityID), int(obj.EntityID)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *restorer) races(b *Backup) error {
+	order := parentOrder(len(b.Races), func(i int) (int, int) { return int(b.Races[i].ID), int(b.Races[i].RaceID) })
+
+	for _, i := range order {
+		v := b.Races[i]
+		if r.done(EndpointRace, int(v.ID)) {
+			continue
+		}
+
+		s := v.SimpleRace
+		s.RaceID = RaceID(r.ref(EndpointRace, int(s.RaceID)))
+		s.Tags = intTags(r.refs(EndpointTag, tagInts(s.Tags)))
+		s.Image = ""
+		s.ImageURL = customImage(v.HasCustomImage, v.ImageFull)
+
+		obj, err := r.client.Races.Create(r.campID, s)
+		if err != nil {
+			return fmt.Errorf("cannot restore Race (ID: %d): %w", v.ID, err)
+		}
+
+		if err = r.recordObject(EndpointRace, int(v.ID), int(obj.ID), int(v.EntityID), int(obj.EntityID)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *restorer) families(b *Backup) error {
+	order := parentOrder(len(b.Families), func(i int) (int, int) { return int(b.Families[i].ID), int(b.Families[i].FamilyID) })
+
+	for _, i := range order {
+		v := b.Families[i]
+		if r.done(EndpointFamily, int(v.ID)) {
+			continue
+		}
+
+		s := v.SimpleFamily
+		s.FamilyID = FamilyID(r.ref(EndpointFamily, int(s.FamilyID)))
+		s.LocationID = LocationID(r.ref(EndpointLocation, int(s.LocationID)))
+		s.Tags = intTags(r.refs(EndpointTag, tagInts(s.Tags)))
+		s.Image = ""
+		s.ImageURL = customImage(v.HasCustomImage, v.ImageFull)
+
+		obj, err := r.client.Families.Create(r.campID, s)
+		if err != nil {
+			return fmt.Errorf("cannot restore Family (ID: %d): %w", v.ID, err)
+		}
+
+		if err = r.recordObject(EndpointFamily, int(v.ID), int(obj.ID), int(v.EntityID), int(obj.EntityID)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *restorer) organizations(b *Backup) error {
+	order := parentOrder(len(b.Organizations), func(i int) (int, int) { return int(b.Organizations[i].ID), int(b.Organizations[i].OrganizationID) })
+
+	for _, i := range order {
+		v := b.Organizations[i]
+		if r.done(EndpointOrganization, int(v.ID)) {
+			continue
+		}
+
+		s := v.SimpleOrganization
+		s.OrganizationID = OrganizationID(r.ref(EndpointOrganization, int(s.OrganizationID)))
+		s.LocationID = LocationID(r.ref(EndpointLocation, int(s.LocationID)))
+		s.Tags = intTags(r.refs(EndpointTag, tagInts(s.Tags)))
+		s.Image = ""
+		s.ImageURL = customImage(v.HasCustomImage, v.ImageFull)
+
+		obj, err := r.client.Organizations.Create(r.campID, s)
+		if err != nil {
+			return fmt.Errorf("cannot restore Organization (ID: %d): %w", v.ID, err)
+		}
+
+		if err = r.recordObject(EndpointOrganization, int(v.ID), int(obj.ID), int(v.EntityID), int(obj.EntityID)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *restorer) characters(b *Backup) error {
+	for _, v := range b.Characters {
+		if r.done(EndpointCharacter, int(v.ID)) {
+			continue
+		}
+
+		s := v.SimpleCharacter
+		s.FamilyID = FamilyID(r.ref(EndpointFamily, int(s.FamilyID)))
+		s.LocationID = LocationID(r.ref(EndpointLocation, int(s.LocationID)))
+		s.RaceID = RaceID(r.ref(EndpointRace, int(s.RaceID)))
+		s.Tags = intTags(r.refs(EndpointTag, tagInts(s.Tags)))
+		s.Image = ""
+		s.ImageURL = customImage(v.HasCustomImage, v.ImageFull)
+		applyTraits(&s, v.Traits.Data)
+
+		obj, err := r.client.Characters.Create(r.campID, s)
+		if err != nil {
+			return fmt.Errorf("cannot restore Character (ID: %d): %w", v.ID, err)
+		}
+
+		if err = r.recordObject(EndpointCharacter, int(v.ID), int(obj.ID), int(v.EntityID), int(obj.EntityID)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyTraits stores the provided traits in the personality and appearance
+// fields of the SimpleCharacter.
+func applyTraits(s *SimpleCharacter, traits []*Trait) {
+	for _, tr := range traits {
+		switch tr.Section {
+		case "personality":
+			s.PersonalityName = append(s.PersonalityName, tr.Name)
+			s.PersonalityEntry = append(s.PersonalityEntry, tr.Entry)
+		case "appearance":
+			s.AppearanceName = append(s.AppearanceName, tr.Name)
+			s.AppearanceEntry = append(s.AppearanceEntry, tr.Entry)
+		}
+	}
+}
+
+func (r *restorer) items(b *Backup) error {
+	for _, v := range b.Items {
+		if r.done(EndpointItem, int(v.ID)) {
+			continue
+		}
+
+		s := v.SimpleItem
+		s.LocationID = LocationID(r.ref(EndpointLocation, int(s.LocationID)))
+		s.CharacterID = CharacterID(r.ref(EndpointCharacter, int(s.CharacterID)))
+		s.Tags = intTags(r.refs(EndpointTag, tagInts(s.Tags)))
+		s.Image = ""
+		s.ImageURL = customImage(v.HasCustomImage, v.ImageFull)
+
+		obj, err := r.client.Items.Create(r.campID, s)
+		if err != nil {
+			return fmt.Errorf("cannot restore Item (ID: %d): %w", v.ID, err)
+		}
+
+		if err = r.recordObject(EndpointItem, int(v.ID), int(obj.ID), int(v.EntityID), int(obj.EntityID)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *restorer) notes(b *Backup) error {
+	for _, v := range b.Notes {
+		if r.done(EndpointNote, int(v.ID)) {
+			continue
+		}
+
+		s := v.SimpleNote
+		s.Tags = intTags(r.refs(EndpointTag, tagInts(s.Tags)))
+		s.Image = ""
+		s.ImageURL = customImage(v.HasCustomImage, v.ImageFull)
+
+		obj, err := r.client.Notes.Create(r.campID, s)
+		if err != nil {
+			return fmt.Errorf("cannot restore Note (ID: %d): %w", v.ID, err)
+		}
+
+		if err = r.recordObject(EndpointNote, int(v.ID), int(obj.ID), int(v.EntityID), int(obj.EntityID)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *restorer) events(b *Backup) error {
+	for _, v := range b.Events {
+		if r.done(EndpointEvent, int(v.ID)) {
+			continue
+		}
+
+		s := v.SimpleEvent
+		s.LocationID = LocationID(r.ref(EndpointLocation, int(s.LocationID)))
+		s.Tags = intTags(r.refs(EndpointTag, tagInts(s.Tags)))
+		s.Image = ""
+		s.ImageURL = customImage(v.HasCustomImage, v.ImageFull)
+
+		obj, err := r.client.Events.Create(r.campID, s)
+		if err != nil {
+			return fmt.Errorf("cannot restore Event (ID: %d): %w", v.ID, err)
+		}
+
+		if err = r.recordObject(EndpointEvent, int(v.ID), int(obj.ID), int(v.EntityID), int(obj.EntityID)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *restorer) journals(b *Backup) error {
+	for _, v := range b.Journals {
+		if r.done(EndpointJournal, int(v.ID)) {
+			continue
+		}
+
+		s := v.SimpleJournal
+		s.LocationID = LocationID(r.ref(EndpointLocation, int(s.LocationID)))
+		s.CharacterID = CharacterID(r.ref(EndpointCharacter, int(s.CharacterID)))
+		s.Tags = intTags(r.refs(EndpointTag, tagInts(s.Tags)))
+		s.Image = ""
+		s.ImageURL = customImage(v.HasCustomImage, v.ImageFull)
+
+		obj, err := r.client.Journals.Create(r.campID, s)
+		if err != nil {
+			return fmt.Errorf("cannot restore Journal (ID: %d): %w", v.ID, err)
+		}
+
+		if err = r.recordObject(EndpointJournal, int(v.ID), int(obj.ID), int(v.EntityID), int(obj.EntityID)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *restorer) quests(b *Backup) error {
+	order := parentOrder(len(b.Quests), func(i int) (int, int) { return int(b.Quests[i].ID), int(b.Quests[i].QuestID) })
+
+	for _, i := range order {
+		v := b.Quests[i]
+		if r.done(EndpointQuest, int(v.ID)) {
+			continue
+		}
+
+		s := v.SimpleQuest
+		s.QuestID = QuestID(r.ref(EndpointQuest, int(s.QuestID)))
+		s.CharacterID = CharacterID(r.ref(EndpointCharacter, int(s.CharacterID)))
+		s.Tags = intTags(r.refs(EndpointTag, tagInts(s.Tags)))
+		s.Image = ""
+		s.ImageURL = customImage(v.HasCustomImage, v.ImageFull)
+
+		obj, err := r.client.Quests.Create(r.campID, s)
+		if err != nil {
+			return fmt.Errorf("cannot restore Quest (ID: %d): %w", v.ID, err)
+		}
+
+		if err = r.recordObject(EndpointQuest, int(v.ID), int(obj.ID), int(v.EntityID), int(obj.EntityID)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *restorer) organizationMembers(b *Backup) error {
+	for _, v := range b.OrganizationMembers {
+		if r.done(EndpointOrganizationMember, v.ID) {
+			continue
+		}
+
+		s := v.SimpleOrganizationMember
+		s.CharacterID = CharacterID(r.ref(EndpointCharacter, int(s.CharacterID)))
+		s.OrganizationID = OrganizationID(r.ref(EndpointOrganization, int(s.OrganizationID)))
+		if s.CharacterID == 0 || s.OrganizationID == 0 {
+			continue
+		}
+
+		obj, err := r.client.OrganizationMembers.Create(CampaignID(r.campID), s.OrganizationID, s)
+		if err != nil {
+			return fmt.Errorf("cannot restore OrganizationMember (ID: %d): %w", v.ID, err)
+		}
+
+		if err = r.record(EndpointOrganizationMember, v.ID, obj.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *restorer) questElements(b *Backup) error {
+	for _, v := range b.QuestCharacters {
+		if r.done(EndpointQuestCharacters, v.ID) {
+			continue
+		}
+
+		s := v.SimpleQuestCharacter
+		s.QuestID = QuestID(r.ref(EndpointQuest, int(s.QuestID)))
+		s.CharacterID = CharacterID(r.ref(EndpointCharacter, int(s.CharacterID)))
+		if s.QuestID == 0 || s.CharacterID == 0 {
+			continue
+		}
+
+		obj, err := r.client.QuestCharacters.Create(r.campID, int(s.QuestID), s)
+		if err != nil {
+			return fmt.Errorf("cannot restore QuestCharacter (ID: %d): %w", v.ID, err)
+		}
+
+		if err = r.record(EndpointQuestCharacters, v.ID, obj.ID); err != nil {
+			return err
+		}
+	}
+
+	for _, v := range b.QuestLocations {
+		if r.done(EndpointQuestLocation, v.ID) {
+			continue
+		}
+
+		s := v.SimpleQuestLocation
+		s.QuestID = QuestID(r.ref(EndpointQuest, int(s.QuestID)))
+		s.LocationID = LocationID(r.ref(EndpointLocation, int(s.LocationID)))
+		if s.QuestID == 0 || s.LocationID == 0 {
+			continue
+		}
+
+		obj, err := r.client.QuestLocations.Create(r.campID, int(s.QuestID), s)
+		if err != nil {
+			return fmt.Errorf("cannot restore QuestLocation (ID: %d): %w", v.ID, err)
+		}
+
+		if err = r.record(EndpointQuestLocation, v.ID, obj.ID); err != nil {
+			return err
+		}
+	}
+
+	for _, v := range b.QuestItems {
+		if r.done(EndpointQuestItem, v.ID) {
+			continue
+		}
+
+		s := v.SimpleQuestItem
+		s.QuestID = QuestID(r.ref(EndpointQuest, int(s.QuestID)))
+		s.ItemID = ItemID(r.ref(EndpointItem, int(s.ItemID)))
+		if s.QuestID == 0 || s.ItemID == 0 {
+			continue
+		}
+
+		obj, err := r.client.QuestItems.Create(r.campID, int(s.QuestID), s)
+		if err != nil {
+			return fmt.Errorf("cannot restore QuestItem (ID: %d): %w", v.ID, err)
+		}
+
+		if err = r.record(EndpointQuestItem, v.ID, obj.ID); err != nil {
+			return err
+		}
+	}
+
+	for _, v := range b.QuestOrganizations {
+		if r.done(EndpointQuestOrganization, v.ID) {
+			continue
+		}
+
+		s := v.SimpleQuestOrganization
+		s.QuestID = QuestID(r.ref(EndpointQuest, int(s.QuestID)))
+		s.OrganizationID = OrganizationID(r.ref(EndpointOrganization, int(s.OrganizationID)))
+		if s.QuestID == 0 || s.OrganizationID == 0 {
+			continue
+		}
+
+		obj, err := r.client.QuestOrganizations.Create(CampaignID(r.campID), s.QuestID, s)
+		if err != nil {
+			return fmt.Errorf("cannot restore QuestOrganization (ID: %d): %w", v.ID, err)
+		}
+
+		if err = r.record(EndpointQuestOrganization, v.ID, obj.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mapPoints restores the map points of the Backup. MapPoints carry no ID of
+// their own, so they are recorded by their index in the Backup.
+func (r *restorer) mapPoints(b *Backup) error {
+	for i, v := range b.MapPoints {
+		if r.done(EndpointMapPoint, i) {
+			continue
+		}
+
+		s := v.SimpleMapPoint
+		s.LocationID = LocationID(r.ref(EndpointLocation, int(s.LocationID)))
+		s.TargetEntityID = EntityID(r.ref(endpointEntity, int(s.TargetEntityID)))
+		if s.LocationID == 0 {
+			continue
+		}
+
+		if _, err := r.client.MapPoints.Create(r.campID, int(s.LocationID), s); err != nil {
+			return fmt.Errorf("cannot restore MapPoint (Name: %s): %w", v.Name, err)
+		}
+
+		if err := r.record(EndpointMapPoint, i, i); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// entities restores the sub-resources of every entity in the Backup.
+func (r *restorer) entities(b *Backup) error {
+	tagged := make(map[int][]int)
+	for _, ref := range b.refs() {
+		tagged[ref.EntityID] = ref.Tags
+	}
+
+	for _, ent := range b.Entities {
+		entID := r.ref(endpointEntity, int(ent.EntityID))
+		if entID == 0 {
+			continue
+		}
+
+		if err := r.entity(ent, entID, tagged[int(ent.EntityID)]); err != nil {
+			return fmt.Errorf("cannot restore Entity (ID: %d): %w", ent.EntityID, err)
+		}
+	}
+
+	return nil
+}
+
+// entity restores the sub-resources of a single entity to the entity
+// associated with entID. Tags already applied to the entity's object when it
+// was created are not restored a second time.
+func (r *restorer) entity(ent *EntityData, entID int, tagged []int) error {
+	for _, v := range ent.Attributes {
+		if r.done(EndpointAttribute, v.ID) {
+			continue
+		}
+
+		obj, err := r.client.Attributes.Create(r.campID, entID, v.SimpleAttribute)
+		if err != nil {
+			return err
+		}
+
+		if err = r.record(EndpointAttribute, v.ID, obj.ID); err != nil {
+			return err
+		}
+	}
+
+	for _, v := range ent.EntityEvents {
+		if r.done(EndpointEntityEvent, v.ID) {
+			continue
+		}
+
+		s := v.SimpleEntityEvent
+		s.EntityID = EntityID(entID)
+
+		obj, err := r.client.EntityEvents.Create(r.campID, entID, s)
+		if err != nil {
+			return err
+		}
+
+		if err = r.record(EndpointEntityEvent, v.ID, obj.ID); err != nil {
+			return err
+		}
+	}
+
+	for _, v := range ent.EntityNotes {
+		if r.done(EndpointEntityNote, v.ID) {
+			continue
+		}
+
+		s := v.SimpleEntityNote
+		s.EntityID = EntityID(entID)
+
+		obj, err := r.client.EntityNotes.Create(r.campID, entID, s)
+		if err != nil {
+			return err
+		}
+
+		if err = r.record(EndpointEntityNote, v.ID, obj.ID); err != nil {
+			return err
+		}
+	}
+
+	applied := make(map[int]bool)
+	for _, id := range tagged {
+		applied[id] = true
+	}
+
+	for _, v := range ent.EntityTags {
+		if r.done(EndpointEntityTag, v.ID) || applied[int(v.TagID)] {
+			continue
+		}
+
+		tagID := r.ref(EndpointTag, int(v.TagID))
+		if tagID == 0 {
+			continue
+		}
+
+		obj, err := r.client.EntityTags.Create(r.campID, entID, SimpleEntityTag{EntityID: EntityID(entID), TagID: TagID(tagID)})
+		if err != nil {
+			return err
+		}
+
+		if err = r.record(EndpointEntityTag, v.ID, obj.ID); err != nil {
+			return err
+		}
+	}
+
+	for _, v := range ent.Inventory {
+		if r.done(EndpointEntityInventory, v.ID) {
+			continue
+		}
+
+		s := v.SimpleEntityInventory
+		s.EntityID = EntityID(entID)
+		s.ItemID = ItemID(r.ref(EndpointItem, int(s.ItemID)))
+		if s.ItemID == 0 {
+			continue
+		}
+
+		obj, err := r.client.EntityInventories.Create(r.campID, entID, s)
+		if err != nil {
+			return err
+		}
+
+		if err = r.record(EndpointEntityInventory, v.ID, obj.ID); err != nil {
+			return err
+		}
+	}
+
+	// Both directions of a two-way relation are stored in the Backup, so
+	// each is restored as a one-way relation to avoid duplicating it.
+	for _, v := range ent.Relations {
+		if r.done(EndpointRelation, v.ID) {
+			continue
+		}
+
+		s := v.SimpleRelation
+		s.OwnerID = EntityID(entID)
+		s.TargetID = EntityID(r.ref(endpointEntity, int(s.TargetID)))
+		s.TwoWay = false
+		if s.TargetID == 0 {
+			continue
+		}
+
+		obj, err := r.client.Relations.Create(r.campID, entID, s)
+		if err != nil {
+			return err
+		}
+
+		if err = r.record(EndpointRelation, v.ID, obj.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+package kanka
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_Restore(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+	fk.seed(t, "/campaigns/1/locations", &Location{ID: 11, EntityID: 111, SimpleLocation: SimpleLocation{Name: "Great Hall", ParentLocationID: 12}})
+	fk.seed(t, "/campaigns/1/locations", &Location{ID: 12, EntityID: 112, SimpleLocation: SimpleLocation{Name: "Keep", ParentLocationID: 10}})
+	fk.seed(t, "/campaigns/1/locations", &Location{ID: 13, EntityID: 113, SimpleLocation: SimpleLocation{Name: "Harrenhal", ParentLocationID: 99}})
+
+	b, err := c.Backup(1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkpoints := 0
+	rep, err := c.Restore(2, b, &RestoreOptions{
+		Checkpoint: func(IDMap) error {
+			checkpoints++
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checkpoints == 0 {
+		t.Error("Checkpoint was never called")
+	}
+	if diff := cmp.Diff([]string{"locations (ID: 99)"}, rep.Dropped); diff != "" {
+		t.Errorf("dropped mismatch (-want +got):\n%s", diff)
+	}
+
+	ids := rep.IDs
+
+	loc := func(id int) int {
+		newID, ok := ids.Get("locations", id)
+		if !ok {
+			t.Fatalf("Location (ID: %d) was not restored", id)
+		}
+		return newID
+	}
+	ent := func(id int) int {
+		newID, ok := ids.Get("entities", id)
+		if !ok {
+			t.Fatalf("Entity (ID: %d) was not restored", id)
+		}
+		return newID
+	}
+
+	if got := intField(fk.object("/campaigns/2/locations", loc(12)), "parent_location_id"); got != loc(10) {
+		t.Errorf("got parent <%d>, want <%d>", got, loc(10))
+	}
+	if got := intField(fk.object("/campaigns/2/locations", loc(11)), "parent_location_id"); got != loc(12) {
+		t.Errorf("got parent <%d>, want <%d>", got, loc(12))
+	}
+
+	arya, _ := ids.Get("characters", 30)
+	family, _ := ids.Get("families", 20)
+	obj := fk.object("/campaigns/2/characters", arya)
+	if intField(obj, "family_id") != family || intField(obj, "location_id") != loc(10) {
+		t.Errorf("got family <%d> and location <%d>, want <%d> and <%d>", intField(obj, "family_id"), intField(obj, "location_id"), family, loc(10))
+	}
+
+	rels := fk.list(fmt.Sprintf("/campaigns/2/entities/%d/relations", ent(130)))
+	if len(rels) != 1 || intField(rels[0], "target_id") != ent(131) {
+		t.Errorf("got relations <%v>, want one targeting <%d>", rels, ent(131))
+	}
+
+	needle, _ := ids.Get("items", 40)
+	inv := fk.list(fmt.Sprintf("/campaigns/2/entities/%d/inventory", ent(130)))
+	if len(inv) != 1 || intField(inv[0], "item_id") != needle {
+		t.Errorf("got inventory <%v>, want one holding <%d>", inv, needle)
+	}
+
+	quest, _ := ids.Get("quests", 60)
+	jon, _ := ids.Get("characters", 31)
+	qchs := fk.list(fmt.Sprintf("/campaigns/2/quests/%d/quest_characters", quest))
+	if len(qchs) != 1 || intField(qchs[0], "character_id") != jon {
+		t.Errorf("got quest characters <%v>, want one for <%d>", qchs, jon)
+	}
+
+	pts := fk.list(fmt.Sprintf("/campaigns/2/locations/%d/map_points", loc(10)))
+	if len(pts) != 1 || intField(pts[0], "target_entity_id") != ent(130) {
+		t.Errorf("got map points <%v>, want one targeting <%d>", pts, ent(130))
+	}
+
+	tags := fk.list(fmt.Sprintf("/campaigns/2/entities/%d/entity_tags", ent(130)))
+	north, _ := ids.Get("tags", 70)
+	if len(tags) != 1 || intField(tags[0], "tag_id") != north {
+		t.Errorf("got entity tags <%v>, want one for <%d>", tags, north)
+	}
+}
+
+func TestClient_Restore_Resume(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+
+	b, err := c.Backup(1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var saved bytes.Buffer
+	save := func(m IDMap) error {
+		saved.Reset()
+		return m.Save(&saved)
+	}
+
+	fk.fail["POST /campaigns/2/items"] = http.StatusTooManyRequests
+	rep, err := c.Restore(2, b, &RestoreOptions{Checkpoint: save})
+	if err == nil {
+		t.Fatal("got nil error, want error")
+	}
+	if _, ok := rep.IDs.Get("characters", 30); !ok {
+		t.Error("got report missing restored Character (ID: 30), want it")
+	}
+	delete(fk.fail, "POST /campaigns/2/items")
+
+	ids, err := LoadIDMap(&saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ids.Get("characters", 30); !ok {
+		t.Fatal("IDMap is missing restored Character (ID: 30)")
+	}
+
+	if _, err = c.Restore(2, b, &RestoreOptions{IDs: ids, Checkpoint: save}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := len(fk.list("/campaigns/2/characters")); got != 2 {
+		t.Errorf("got <%d> Characters, want <%d>", got, 2)
+	}
+	if got := len(fk.list("/campaigns/2/items")); got != 1 {
+		t.Errorf("got <%d> Items, want <%d>", got, 1)
+	}
+}
+
+func TestParentOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		ids     []int
+		parents []int
+		want    []int
+	}{
+		{
+			name:    "Children before parents",
+			ids:     []int{3, 2, 1},
+			parents: []int{2, 1, 0},
+			want:    []int{2, 1, 0},
+		},
+		{
+			name:    "Missing parent",
+			ids:     []int{1, 2},
+			parents: []int{9, 1},
+			want:    []int{0, 1},
+		},
+		{
+			name:    "Cycle",
+			ids:     []int{1, 2},
+			parents: []int{2, 1},
+			want:    []int{1, 0},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := parentOrder(len(test.ids), func(i int) (int, int) { return test.ids[i], test.parents[i] })
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyTraits(t *testing.T) {
+	var s SimpleCharacter
+	applyTraits(&s, []*Trait{
+		{Name: "Temper", Entry: "Fierce", Section: "personality"},
+		{Name: "Eyes", Entry: "Grey", Section: "appearance"},
+		{Name: "Unknown", Entry: "Ignored", Section: "other"},
+	})
+
+	want := SimpleCharacter{
+		PersonalityName:  []string{"Temper"},
+		PersonalityEntry: []string{"Fierce"},
+		AppearanceName:   []string{"Eyes"},
+		AppearanceEntry:  []string{"Grey"},
+	}
+	if diff := cmp.Diff(want, s); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,260 @@
+package kanka
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Occurrence is a single happening of an entity event. Start and End are
+// the first and last days of the happening. Anniversary is the number of
+// years since the event first happened, 0 for the first happening.
+type Occurrence struct {
+	Event       *EntityEvent
+	EntityID    EntityID
+	Type        EntityType
+	Name        string
+	Start       CalendarDate
+	End         CalendarDate
+	Anniversary int
+}
+
+// scheduledEvent pairs an entity event with its entity.
+type scheduledEvent struct {
+	event *EntityEvent
+	ref   entityRef
+}
+
+// Schedule contains the entity events of a campaign on a single calendar.
+// Events of other calendars are ignored.
+type Schedule struct {
+	Calendar *Calendar
+	events   []scheduledEvent
+}
+
+// Schedule returns the Schedule of every entity event on the Calendar
+// associated with calID in the Campaign associated with campID.
+func (c *Client) Schedule(campID CampaignID, calID CalendarID) (*Schedule, error) {
+	cal, err := c.Calendars.Get(campID, calID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get schedule of Campaign (ID: %d): %w", campID, err)
+	}
+
+	b := &Backup{}
+	if err = c.backupObjects(int(campID), b); err != nil {
+		return nil, fmt.Errorf("cannot get schedule of Campaign (ID: %d): %w", campID, err)
+	}
+
+	s := &Schedule{Calendar: cal}
+	for _, ref := range b.refs() {
+		evs, err := c.EntityEvents.Index(int(campID), ref.EntityID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get schedule of Campaign (ID: %d): %w", campID, err)
+		}
+
+		s.add(ref, evs)
+	}
+
+	return s, nil
+}
+
+// Schedule returns the Schedule of every entity event in the Backup on the
+// provided Calendar.
+func (b *Backup) Schedule(cal *Calendar) *Schedule {
+	refs := make(map[int]entityRef)
+	for _, ref := range b.refs() {
+		refs[ref.EntityID] = ref
+	}
+
+	s := &Schedule{Calendar: cal}
+	for _, ent := range b.Entities {
+		ref, ok := refs[int(ent.EntityID)]
+		if !ok {
+			ref = entityRef{Type: ent.Type, ID: int(ent.ID), EntityID: int(ent.EntityID)}
+		}
+
+		s.add(ref, ent.EntityEvents)
+	}
+
+	return s
+}
+
+// add adds the entity events of the referenced entity that are on the
+// Schedule's calendar. Events without a calendar are assumed to be on it.
+func (s *Schedule) add(ref entityRef, evs []*EntityEvent) {
+	for _, ev := range evs {
+		if ev.CalendarID != 0 && s.Calendar.ID != 0 && ev.CalendarID != s.Calendar.ID {
+			continue
+		}
+
+		s.events = append(s.events, scheduledEvent{event: ev, ref: ref})
+	}
+}
+
+// Between returns every Occurrence of the Schedule's events that overlaps
+// the days from one CalendarDate to the other, inclusive. Recurring events
+// happen every year until their RecurringUntil year, if any, and on the last
+// day of their month in years where their day does not exist. Occurrences
+// are ordered by their start, entity ID, and event ID. Events whose dates do
+// not exist on the calendar are left out and listed by Invalid.
+func (s *Schedule) Between(from CalendarDate, to CalendarDate) ([]*Occurrence, error) {
+	for _, d := range []CalendarDate{from, to} {
+		if err := s.Calendar.Validate(d); err != nil {
+			return nil, fmt.Errorf("cannot get occurrences from date '%s' to date '%s': %w", from, to, err)
+		}
+	}
+
+	var occs []*Occurrence
+	for _, se := range s.events {
+		o, err := s.expand(se, from, to)
+		if err != nil {
+			continue
+		}
+		occs = append(occs, o...)
+	}
+
+	sort.SliceStable(occs, func(i, j int) bool {
+		if c := occs[i].Start.Compare(occs[j].Start); c != 0 {
+			return c < 0
+		}
+		if occs[i].EntityID != occs[j].EntityID {
+			return occs[i].EntityID < occs[j].EntityID
+		}
+		return occs[i].Event.ID < occs[j].Event.ID
+	})
+
+	return occs, nil
+}
+
+// Invalid returns the events of the Schedule whose dates do not exist on its
+// calendar, which are left out of every Occurrence.
+func (s *Schedule) Invalid() []*EntityEvent {
+	var evs []*EntityEvent
+	for _, se := range s.events {
+		if err := s.Calendar.Validate(se.event.CalendarDate()); err != nil {
+			evs = append(evs, se.event)
+		}
+	}
+
+	return evs
+}
+
+// expand returns the occurrences of the scheduled event that overlap the
+// days from one CalendarDate to the other.
+func (s *Schedule) expand(se scheduledEvent, from CalendarDate, to CalendarDate) ([]*Occurrence, error) {
+	cal := s.Calendar
+	ev := se.event
+
+	first := ev.CalendarDate()
+	if err := cal.Validate(first); err != nil {
+		return nil, fmt.Errorf("invalid date of entity event (ID: %d): %w", ev.ID, err)
+	}
+
+	length := ev.Length
+	if length < 1 {
+		length = 1
+	}
+
+	lo, hi := ev.Year, ev.Year
+	if ev.IsRecurring {
+		lo = cal.indexYear(cal.yearIndex(from.Year) - length/cal.YearLength(from.Year) - 1)
+		if lo < ev.Year {
+			lo = ev.Year
+		}
+		hi = to.Year
+		if ev.RecurringUntil != 0 && ev.RecurringUntil < hi {
+			hi = ev.RecurringUntil
+		}
+	}
+
+	var occs []*Occurrence
+	for y := lo; y <= hi; y++ {
+		if y == 0 && !cal.HasYearZero {
+			continue
+		}
+
+		start := CalendarDate{Year: y, Month: first.Month, Day: minInt(first.Day, cal.MonthLength(y, first.Month))}
+		if start.Compare(to) > 0 {
+			break
+		}
+
+		end, err := cal.AddDays(start, length-1)
+		if err != nil {
+			return nil, fmt.Errorf("invalid length of entity event (ID: %d): %w", ev.ID, err)
+		}
+		if end.Compare(from) < 0 {
+			continue
+		}
+
+		occs = append(occs, &Occurrence{
+			Event:       ev,
+			EntityID:    EntityID(se.ref.EntityID),
+			Type:        se.ref.Type,
+			Name:        se.ref.Name,
+			Start:       start,
+			End:         end,
+			Anniversary: cal.yearIndex(y) - cal.yearIndex(ev.Year),
+		})
+	}
+
+	return occs, nil
+}
+
+// Upcoming returns every Occurrence that overlaps the provided number of
+// days starting with the current date of the Schedule's calendar.
+func (s *Schedule) Upcoming(days int) ([]*Occurrence, error) {
+	if days < 1 {
+		return nil, nil
+	}
+
+	from, err := s.Calendar.Current()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get upcoming occurrences: %w", err)
+	}
+
+	to, err := s.Calendar.AddDays(from, days-1)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get upcoming occurrences: %w", err)
+	}
+
+	return s.Between(from, to)
+}
+
+// Anniversaries returns every Occurrence of the recurring events of
+// characters that overlaps the days from one CalendarDate to the other,
+// excluding the first happening of each event.
+func (s *Schedule) Anniversaries(from CalendarDate, to CalendarDate) ([]*Occurrence, error) {
+	occs, err := s.Between(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var anns []*Occurrence
+	for _, o := range occs {
+		if o.Type == TypeCharacter && o.Event.IsRecurring && o.Anniversary > 0 {
+			anns = append(anns, o)
+		}
+	}
+
+	return anns, nil
+}
+
+// Birthdays returns the anniversaries from one CalendarDate to the other
+// whose event is commented as a birth, such as "Birthday" or "Born". The
+// Anniversary of each Occurrence is the character's age.
+func (s *Schedule) Birthdays(from CalendarDate, to CalendarDate) ([]*Occurrence, error) {
+	anns, err := s.Anniversaries(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var bdays []*Occurrence
+	for _, o := range anns {
+		c := strings.ToLower(o.Event.Comment)
+		if strings.Contains(c, "birth") || strings.Contains(c, "born") {
+			bdays = append(bdays, o)
+		}
+	}
+
+	return bdays, nil
+}
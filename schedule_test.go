@@ -0,0 +1,183 @@
+package kanka
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// testSchedule returns the Schedule of the test Backup on the test calendar.
+func testSchedule(t *testing.T) *Schedule {
+	cal := testCalendar()
+	cal.ID = 7
+
+	return testBackup(t).Schedule(cal)
+}
+
+// occurrenceKey summarizes an Occurrence for comparison.
+type occurrenceKey struct {
+	Event       int
+	Name        string
+	Start       string
+	End         string
+	Anniversary int
+}
+
+// occurrenceKeys returns the keys of the provided occurrences.
+func occurrenceKeys(occs []*Occurrence) []occurrenceKey {
+	var keys []occurrenceKey
+	for _, o := range occs {
+		keys = append(keys, occurrenceKey{o.Event.ID, o.Name, o.Start.String(), o.End.String(), o.Anniversary})
+	}
+	return keys
+}
+
+func TestSchedule_Between(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    CalendarDate
+		to      CalendarDate
+		want    []occurrenceKey
+		wantErr bool
+	}{
+		{
+			name: "Overlapping start",
+			from: CalendarDate{6, 1, 1},
+			to:   CalendarDate{6, 4, 5},
+			want: []occurrenceKey{
+				{3, "Jon Snow", "5-5-29", "6-1-1", 0},
+				{1, "Arya Stark", "6-1-10", "6-1-10", 5},
+				{2, "Winterfell", "6-3-1", "6-4-2", 3},
+			},
+		},
+		{
+			name: "After recurrence ends",
+			from: CalendarDate{7, 1, 1},
+			to:   CalendarDate{7, 5, 29},
+			want: []occurrenceKey{
+				{1, "Arya Stark", "7-1-10", "7-1-10", 6},
+				{4, "Jon Snow", "7-5-29", "7-5-29", 3},
+			},
+		},
+		{
+			name: "Before first happening",
+			from: CalendarDate{-3, 1, 1},
+			to:   CalendarDate{-1, 5, 29},
+			want: nil,
+		},
+		{
+			name:    "Invalid range",
+			from:    CalendarDate{0, 1, 1},
+			to:      CalendarDate{1, 1, 1},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := testSchedule(t).Between(test.from, test.to)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err <%v>, want err <%t>", err, test.wantErr)
+			}
+
+			if diff := cmp.Diff(test.want, occurrenceKeys(got)); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSchedule_Invalid(t *testing.T) {
+	s := testSchedule(t)
+	bad := &EntityEvent{ID: 9, SimpleEntityEvent: SimpleEntityEvent{Day: 40, Month: 1, Year: 6}}
+	s.add(entityRef{Type: TypeCharacter, ID: 30, EntityID: 130, Name: "Arya Stark"}, []*EntityEvent{bad})
+
+	got, err := s.Between(CalendarDate{6, 1, 1}, CalendarDate{6, 4, 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []occurrenceKey{
+		{3, "Jon Snow", "5-5-29", "6-1-1", 0},
+		{1, "Arya Stark", "6-1-10", "6-1-10", 5},
+		{2, "Winterfell", "6-3-1", "6-4-2", 3},
+	}
+	if diff := cmp.Diff(want, occurrenceKeys(got)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]*EntityEvent{bad}, s.Invalid()); diff != "" {
+		t.Errorf("invalid mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSchedule_Upcoming(t *testing.T) {
+	got, err := testSchedule(t).Upcoming(11)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []occurrenceKey{
+		{4, "Jon Snow", "4-5-30", "4-5-30", 0},
+		{1, "Arya Stark", "5-1-10", "5-1-10", 4},
+	}
+	if diff := cmp.Diff(want, occurrenceKeys(got)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	if got, _ := testSchedule(t).Upcoming(0); len(got) != 0 {
+		t.Errorf("got <%d> occurrences for no days, want none", len(got))
+	}
+}
+
+func TestSchedule_Birthdays(t *testing.T) {
+	s := testSchedule(t)
+
+	got, err := s.Birthdays(CalendarDate{5, 1, 1}, CalendarDate{5, 5, 29})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []occurrenceKey{
+		{1, "Arya Stark", "5-1-10", "5-1-10", 4},
+		{4, "Jon Snow", "5-5-29", "5-5-29", 1},
+	}
+	if diff := cmp.Diff(want, occurrenceKeys(got)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	got, err = s.Anniversaries(CalendarDate{3, 1, 1}, CalendarDate{4, 5, 29})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []occurrenceKey{{1, "Arya Stark", "3-1-10", "3-1-10", 2}, {1, "Arya Stark", "4-1-10", "4-1-10", 3}}
+	if diff := cmp.Diff(want, occurrenceKeys(got)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestClient_Schedule(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+	calID := fk.seed(t, "/campaigns/1/calendars", testCalendar())
+
+	s, err := c.Schedule(1, CalendarID(calID))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.Between(CalendarDate{290, 1, 1}, CalendarDate{290, 2, 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []occurrenceKey{{81, "Arya Stark", "290-2-1", "290-2-1", 0}}
+	if diff := cmp.Diff(want, occurrenceKeys(got)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	fk.fail["GET /campaigns/1/entities/130/entity_events"] = http.StatusInternalServerError
+	if _, err = c.Schedule(1, CalendarID(calID)); err == nil {
+		t.Error("got nil error, want error")
+	}
+}
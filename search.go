@@ -11,7 +11,7 @@ import (
 // For more information, visit: https://kanka.io/en-US/docs/1.0/search
 type Result struct {
 	ID                  int       `json:"id"`
-	EntityID            int       `json:"entity_id"`
+	EntityID            EntityID  `json:"entity_id"`
 	Name                string    `json:"name"`
 	Image               string    `json:"image"`
 	ImageThumb          string    `json:"image_thumb"`
@@ -35,6 +35,9 @@ type Results struct {
 }
 
 // Search searches the Campaign associated with campID for the provided query.
+//
+// Deprecated: Use CampaignHandle.Search, which is returned by Client.Campaign
+// with a typed CampaignID.
 func (c *Client) Search(campID int, qry string, sync *time.Time) ([]*Result, error) {
 	if blank.Is(qry) {
 		return nil, fmt.Errorf("invalid search query")
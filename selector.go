@@ -0,0 +1,155 @@
+package kanka
+
+import (
+	"fmt"
+	"sort"
+)
+
+// EntitySelector chooses entities of a campaign. An entity is selected if it
+// matches every criterion set. At least one criterion must be set.
+type EntitySelector struct {
+	// TagID selects the entities carrying the tag, or one of its
+	// descendants if ChildTags is true.
+	TagID     TagID
+	ChildTags bool
+
+	// Types selects the entities of any of the types.
+	Types []EntityType
+
+	// LocationID selects the location, its descendant locations, and every
+	// entity located in any of them.
+	LocationID LocationID
+}
+
+// String returns a description of the EntitySelector.
+func (s EntitySelector) String() string {
+	desc := ""
+	if s.TagID != 0 {
+		desc += fmt.Sprintf(" tag %d", s.TagID)
+		if s.ChildTags {
+			desc += " and its children"
+		}
+	}
+	if len(s.Types) > 0 {
+		desc += fmt.Sprintf(" types %v", s.Types)
+	}
+	if s.LocationID != 0 {
+		desc += fmt.Sprintf(" location %d and its children", s.LocationID)
+	}
+	if desc == "" {
+		return "nothing"
+	}
+
+	return desc[1:]
+}
+
+// tagSet returns the ID of the tag associated with tagID and, if children is
+// true, the IDs of its descendants.
+func (b *Backup) tagSet(tagID int, children bool) map[int]bool {
+	tags := map[int]bool{tagID: true}
+	if !children {
+		return tags
+	}
+
+	if n, ok := b.Tree(TypeTag).Node(ObjectID(tagID)); ok {
+		for _, d := range n.Descendants() {
+			tags[int(d.ID)] = true
+		}
+	}
+
+	return tags
+}
+
+// locations returns the ID of the location of every located object of the
+// Backup keyed by entity ID.
+func (b *Backup) locations() map[int]int {
+	locs := make(map[int]int)
+	for _, v := range b.Characters {
+		locs[int(v.EntityID)] = int(v.LocationID)
+	}
+	for _, v := range b.Locations {
+		locs[int(v.EntityID)] = int(v.ID)
+	}
+	for _, v := range b.Families {
+		locs[int(v.EntityID)] = int(v.LocationID)
+	}
+	for _, v := range b.Organizations {
+		locs[int(v.EntityID)] = int(v.LocationID)
+	}
+	for _, v := range b.Items {
+		locs[int(v.EntityID)] = int(v.LocationID)
+	}
+	for _, v := range b.Events {
+		locs[int(v.EntityID)] = int(v.LocationID)
+	}
+	for _, v := range b.Journals {
+		locs[int(v.EntityID)] = int(v.LocationID)
+	}
+
+	return locs
+}
+
+// selectRefs returns references to every object of the Backup matched by
+// sel, ordered by entity ID.
+func (b *Backup) selectRefs(sel EntitySelector) ([]entityRef, error) {
+	if sel.TagID == 0 && len(sel.Types) == 0 && sel.LocationID == 0 {
+		return nil, fmt.Errorf("cannot select entities: selector is empty")
+	}
+
+	var tags map[int]bool
+	if sel.TagID != 0 {
+		tags = b.tagSet(int(sel.TagID), sel.ChildTags)
+	}
+
+	var types map[EntityType]bool
+	if len(sel.Types) > 0 {
+		types = make(map[EntityType]bool)
+		for _, typ := range sel.Types {
+			types[typ] = true
+		}
+	}
+
+	var within map[int]bool
+	var locs map[int]int
+	if sel.LocationID != 0 {
+		n, ok := b.Tree(TypeLocation).Node(ObjectID(sel.LocationID))
+		if !ok {
+			return nil, fmt.Errorf("cannot select entities: cannot find Location (ID: %d)", sel.LocationID)
+		}
+
+		within = map[int]bool{int(n.ID): true}
+		for _, d := range n.Descendants() {
+			within[int(d.ID)] = true
+		}
+		locs = b.locations()
+	}
+
+	var refs []entityRef
+	for _, ref := range b.refs() {
+		if types != nil && !types[ref.Type] {
+			continue
+		}
+		if within != nil && !within[locs[ref.EntityID]] {
+			continue
+		}
+		if tags != nil && !hasTag(ref.Tags, tags) {
+			continue
+		}
+
+		refs = append(refs, ref)
+	}
+
+	sort.Slice(refs, func(i, j int) bool { return refs[i].EntityID < refs[j].EntityID })
+	return refs, nil
+}
+
+// hasTag returns true if any of the IDs is in the set of tags.
+func hasTag(ids []int, tags map[int]bool) bool {
+	for _, id := range ids {
+		if tags[id] {
+			return true
+		}
+	}
+
+	return false
+}
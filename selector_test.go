@@ -0,0 +1,57 @@
+package kanka
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBackup_selectRefs(t *testing.T) {
+	tests := []struct {
+		name    string
+		sel     EntitySelector
+		want    []int
+		wantErr bool
+	}{
+		{"tag", EntitySelector{TagID: 70}, []int{130, 150}, false},
+		{"child tags", EntitySelector{TagID: 70, ChildTags: true}, []int{130, 132, 133, 150}, false},
+		{"type", EntitySelector{Types: []EntityType{TypeItem, TypeTag}}, []int{140, 170, 171}, false},
+		{"location", EntitySelector{LocationID: 3}, []int{103, 109, 130, 133, 140, 150}, false},
+		{"nested location", EntitySelector{LocationID: 9}, []int{109, 133, 140}, false},
+		{"combined", EntitySelector{TagID: 70, ChildTags: true, Types: []EntityType{TypeCharacter}, LocationID: 3}, []int{130, 133}, false},
+		{"missing location", EntitySelector{LocationID: 99}, nil, true},
+		{"empty", EntitySelector{}, nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			refs, err := testBackup(t).selectRefs(test.sel)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err <%v>, want err <%t>", err, test.wantErr)
+			}
+
+			var got []int
+			for _, ref := range refs {
+				got = append(got, ref.EntityID)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEntitySelector_String(t *testing.T) {
+	tests := []struct {
+		sel  EntitySelector
+		want string
+	}{
+		{EntitySelector{}, "nothing"},
+		{EntitySelector{TagID: 70, ChildTags: true}, "tag 70 and its children"},
+		{EntitySelector{Types: []EntityType{TypeCharacter}, LocationID: 10}, "types [character] location 10 and its children"},
+	}
+	for _, test := range tests {
+		if got := test.sel.String(); got != test.want {
+			t.Errorf("got <%s>, want <%s>", got, test.want)
+		}
+	}
+}
@@ -13,16 +13,16 @@ import (
 // For more information, visit: https://kanka.io/en-US/docs/1.0/tags
 type Tag struct {
 	SimpleTag
-	ID             int       `json:"id"`
-	ImageFull      string    `json:"image_full"`
-	ImageThumb     string    `json:"image_thumb"`
-	HasCustomImage bool      `json:"has_custom_image"`
-	EntityID       int       `json:"entity_id"`
-	Entities       []int     `json:"entities"`
-	CreatedAt      time.Time `json:"created_at"`
-	CreatedBy      int       `json:"created_by"`
-	UpdatedAt      time.Time `json:"updated_at"`
-	UpdatedBy      int       `json:"updated_by"`
+	ID             TagID      `json:"id"`
+	ImageFull      string     `json:"image_full"`
+	ImageThumb     string     `json:"image_thumb"`
+	HasCustomImage bool       `json:"has_custom_image"`
+	EntityID       EntityID   `json:"entity_id"`
+	Entities       []EntityID `json:"entities"`
+	CreatedAt      time.Time  `json:"created_at"`
+	CreatedBy      int        `json:"created_by"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	UpdatedBy      int        `json:"updated_by"`
 
 	Attributes   Attributes   `json:"attributes"`
 	EntityEvents EntityEvents `json:"entity_events"`
@@ -35,15 +35,15 @@ type Tag struct {
 // SimpleTag contains only the simple information about a tag.
 // SimpleTag is primarily used to create new tags for posting to Kanka.
 type SimpleTag struct {
-	Name      string `json:"name"`
-	Entry     string `json:"entry,omitempty"`
-	Type      string `json:"type,omitempty"`
-	TagID     int    `json:"tag_id,omitempty"`
-	Color     string `json:"colour,omitempty"`
-	Tags      []int  `json:"tags,omitempty"`
-	IsPrivate bool   `json:"is_private,omitempty"`
-	Image     string `json:"image,omitempty"`
-	ImageURL  string `json:"image_url,omitempty"`
+	Name      string  `json:"name"`
+	Entry     string  `json:"entry,omitempty"`
+	Type      string  `json:"type,omitempty"`
+	TagID     TagID   `json:"tag_id,omitempty"`
+	Color     string  `json:"colour,omitempty"`
+	Tags      []TagID `json:"tags,omitempty"`
+	IsPrivate bool    `json:"is_private,omitempty"`
+	Image     string  `json:"image,omitempty"`
+	ImageURL  string  `json:"image_url,omitempty"`
 }
 
 // MarshalJSON marshals the SimpleTag into its JSON-encoded form if it
@@ -57,12 +57,25 @@ func (st SimpleTag) MarshalJSON() ([]byte, error) {
 	return json.Marshal(alias(st))
 }
 
+// EntryMarkdown returns the SimpleTag's Entry converted into Markdown.
+func (st SimpleTag) EntryMarkdown() string {
+	return EntryToMarkdown(st.Entry)
+}
+
+// SetEntryMarkdown sets the SimpleTag's Entry to the HTML of the provided
+// Markdown.
+func (st *SimpleTag) SetEntryMarkdown(md string) {
+	st.Entry = MarkdownToEntry(md)
+}
+
 // TagService handles communication with the Tag endpoint.
 type TagService service
 
 // Index returns the list of all Tags in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return Tags that have
 // been changed since that time.
+//
+// Deprecated: Use CampaignHandle.Tags, which takes typed IDs.
 func (ts *TagService) Index(campID int, sync *time.Time) ([]*Tag, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -88,6 +101,8 @@ func (ts *TagService) Index(campID int, sync *time.Time) ([]*Tag, error) {
 
 // Get returns the Tag associated with tagID from the Campaign
 // associated with campID.
+//
+// Deprecated: Use CampaignHandle.Tags, which takes typed IDs.
 func (ts *TagService) Get(campID int, tagID int) (*Tag, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -115,6 +130,8 @@ func (ts *TagService) Get(campID int, tagID int) (*Tag, error) {
 // Create creates a new Tag in the Campaign associated with campID using
 // the provided SimpleTag data.
 // Create returns the newly created Tag.
+//
+// Deprecated: Use CampaignHandle.Tags, which takes typed IDs.
 func (ts *TagService) Create(campID int, tag SimpleTag) (*Tag, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -142,6 +159,8 @@ func (ts *TagService) Create(campID int, tag SimpleTag) (*Tag, error) {
 // Update updates an existing Tag associated with tagID from the
 // Campaign associated with campID using the provided SimpleTag data.
 // Update returns the newly updated Tag.
+//
+// Deprecated: Use CampaignHandle.Tags, which takes typed IDs.
 func (ts *TagService) Update(campID int, tagID int, tag SimpleTag) (*Tag, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -173,6 +192,8 @@ func (ts *TagService) Update(campID int, tagID int, tag SimpleTag) (*Tag, error)
 
 // Delete deletes an existing Tag associated with tagID from the
 // Campaign associated with campID.
+//
+// Deprecated: Use CampaignHandle.Tags, which takes typed IDs.
 func (ts *TagService) Delete(campID int, tagID int) error {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
@@ -137,7 +137,7 @@ func TestTagService_Get(t *testing.T) {
 			Entry:     "\n<p>Just some references</p>\n",
 			Image:     "tags/clthzBLiqZTET8tKUKibQZeKcOjQosz6nNWj5C77.jpeg",
 			IsPrivate: false,
-			Tags:      []int{},
+			Tags:      []TagID{},
 			Type:      "Lore",
 			Color:     "red",
 		},
@@ -148,7 +148,7 @@ func TestTagService_Get(t *testing.T) {
 		EntityID:       436885,
 		CreatedBy:      5600,
 		UpdatedBy:      5600,
-		Entities:       []int{436884, 436892, 437024, 442245, 442249, 424136, 443498, 443499},
+		Entities:       []EntityID{436884, 436892, 437024, 442245, 442249, 424136, 443498, 443499},
 	}
 
 	type args struct {
@@ -0,0 +1,501 @@
+package kanka
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TimelineEntry is a single dated happening of a campaign, either the date
+// of an event or journal or an entity event. EventID is the ID of the
+// entity event, or 0 for the date of an event or journal. Raw is the date as
+// written in Kanka. Entry is HTML. LocationID and CharacterID are the
+// location and character of the happening's object, if any.
+type TimelineEntry struct {
+	Type        EntityType
+	ID          ObjectID
+	EntityID    EntityID
+	EventID     int
+	Title       string
+	Entry       string
+	Raw         string
+	Date        CalendarDate
+	End         CalendarDate
+	LocationID  LocationID
+	CharacterID CharacterID
+	Tags        []TagID
+	IsPrivate   bool
+}
+
+// Timeline contains the dated happenings of a campaign on a single calendar
+// in chronological order. Undated contains the happenings whose dates could
+// not be parsed with the calendar. Recurring entity events only appear on
+// the date they first happen.
+type Timeline struct {
+	Calendar *Calendar
+	Entries  []*TimelineEntry
+	Undated  []*TimelineEntry
+}
+
+// TimelineFilter selects the entries of a Timeline. An entry matches if it
+// has any of the Tags and belongs to the location associated with
+// LocationID and the character associated with CharacterID. Zero values
+// match every entry.
+type TimelineFilter struct {
+	Tags        []TagID
+	LocationID  LocationID
+	CharacterID CharacterID
+}
+
+// TimelineGroup contains the entries of a Timeline in a single year. Era is
+// nil if the year is before every era of the calendar.
+type TimelineGroup struct {
+	Era     *Era
+	Year    int
+	Entries []*TimelineEntry
+}
+
+// Timeline returns the Timeline of the Campaign associated with campID on
+// the Calendar associated with calID.
+func (c *Client) Timeline(campID CampaignID, calID CalendarID) (*Timeline, error) {
+	cal, err := c.Calendars.Get(campID, calID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get timeline of Campaign (ID: %d): %w", campID, err)
+	}
+
+	b := &Backup{}
+	if err = c.backupObjects(int(campID), b); err != nil {
+		return nil, fmt.Errorf("cannot get timeline of Campaign (ID: %d): %w", campID, err)
+	}
+
+	for _, ref := range b.refs() {
+		evs, err := c.EntityEvents.Index(int(campID), ref.EntityID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get timeline of Campaign (ID: %d): %w", campID, err)
+		}
+
+		b.Entities = append(b.Entities, &EntityData{EntityID: EntityID(ref.EntityID), Type: ref.Type, ID: ObjectID(ref.ID), EntityEvents: evs})
+	}
+
+	return b.Timeline(cal), nil
+}
+
+// Timeline returns the Timeline of the Backup on the provided Calendar.
+func (b *Backup) Timeline(cal *Calendar) *Timeline {
+	t := &Timeline{Calendar: cal}
+	subjects := b.timelineSubjects()
+
+	for _, v := range b.Events {
+		if v.Date != "" {
+			t.addDate(subjects[int(v.EntityID)], v.Entry, v.Date)
+		}
+	}
+	for _, v := range b.Journals {
+		if v.Date != "" {
+			t.addDate(subjects[int(v.EntityID)], v.Entry, v.Date)
+		}
+	}
+
+	for _, ent := range b.Entities {
+		sub, ok := subjects[int(ent.EntityID)]
+		if !ok {
+			sub = TimelineEntry{Type: ent.Type, ID: ent.ID, EntityID: ent.EntityID}
+		}
+
+		for _, ev := range ent.EntityEvents {
+			if ev.CalendarID != 0 && cal.ID != 0 && ev.CalendarID != cal.ID {
+				continue
+			}
+			t.addEvent(sub, ev)
+		}
+	}
+
+	t.sort()
+
+	return t
+}
+
+// timelineSubjects returns a TimelineEntry template for every core object
+// in the Backup, keyed by entity ID.
+func (b *Backup) timelineSubjects() map[int]TimelineEntry {
+	subs := make(map[int]TimelineEntry)
+	for _, ref := range b.refs() {
+		subs[ref.EntityID] = TimelineEntry{
+			Type:      ref.Type,
+			ID:        ObjectID(ref.ID),
+			EntityID:  EntityID(ref.EntityID),
+			Title:     ref.Name,
+			Tags:      intTags(ref.Tags),
+			IsPrivate: ref.IsPrivate,
+		}
+	}
+
+	set := func(entID, loc, char int) {
+		sub := subs[entID]
+		sub.LocationID = LocationID(loc)
+		sub.CharacterID = CharacterID(char)
+		subs[entID] = sub
+	}
+
+	for _, v := range b.Characters {
+		set(int(v.EntityID), int(v.LocationID), 0)
+	}
+	for _, v := range b.Families {
+		set(int(v.EntityID), int(v.LocationID), 0)
+	}
+	for _, v := range b.Organizations {
+		set(int(v.EntityID), int(v.LocationID), 0)
+	}
+	for _, v := range b.Items {
+		set(int(v.EntityID), int(v.LocationID), int(v.CharacterID))
+	}
+	for _, v := range b.Events {
+		set(int(v.EntityID), int(v.LocationID), 0)
+	}
+	for _, v := range b.Quests {
+		set(int(v.EntityID), 0, int(v.CharacterID))
+	}
+	for _, v := range b.Journals {
+		set(int(v.EntityID), int(v.LocationID), int(v.CharacterID))
+	}
+
+	return subs
+}
+
+// addDate adds the written date of an event or journal to the Timeline.
+func (t *Timeline) addDate(sub TimelineEntry, entry string, raw string) {
+	e := sub
+	e.Entry = entry
+	e.Raw = raw
+
+	d, err := t.Calendar.ParseDate(raw)
+	if err != nil {
+		t.Undated = append(t.Undated, &e)
+		return
+	}
+
+	e.Date, e.End = d, d
+	t.Entries = append(t.Entries, &e)
+}
+
+// addEvent adds the first happening of an entity event to the Timeline.
+func (t *Timeline) addEvent(sub TimelineEntry, ev *EntityEvent) {
+	e := sub
+	e.EventID = ev.ID
+	e.IsPrivate = e.IsPrivate || ev.IsPrivate
+	e.Raw = ev.Date
+	if e.Raw == "" {
+		e.Raw = ev.CalendarDate().String()
+	}
+	if ev.Comment != "" {
+		e.Entry = "<p>" + escapeText(ev.Comment) + "</p>"
+	}
+
+	length := ev.Length
+	if length < 1 {
+		length = 1
+	}
+
+	end, err := t.Calendar.AddDays(ev.CalendarDate(), length-1)
+	if err != nil {
+		t.Undated = append(t.Undated, &e)
+		return
+	}
+
+	e.Date, e.End = ev.CalendarDate(), end
+	t.Entries = append(t.Entries, &e)
+}
+
+// sort orders the entries of the Timeline by date, then entity ID, then
+// entity event ID, and the undated entries by entity ID and entity event ID.
+func (t *Timeline) sort() {
+	sort.SliceStable(t.Entries, func(i, j int) bool {
+		if c := t.Entries[i].Date.Compare(t.Entries[j].Date); c != 0 {
+			return c < 0
+		}
+		return timelineLess(t.Entries[i], t.Entries[j])
+	})
+	sort.SliceStable(t.Undated, func(i, j int) bool {
+		return timelineLess(t.Undated[i], t.Undated[j])
+	})
+}
+
+// timelineLess orders entries by entity ID and then entity event ID.
+func timelineLess(a *TimelineEntry, b *TimelineEntry) bool {
+	if a.EntityID != b.EntityID {
+		return a.EntityID < b.EntityID
+	}
+	return a.EventID < b.EventID
+}
+
+// Filter returns a Timeline of the entries matching the TimelineFilter.
+func (t *Timeline) Filter(f TimelineFilter) *Timeline {
+	ft := &Timeline{Calendar: t.Calendar}
+	for _, e := range t.Entries {
+		if f.match(e) {
+			ft.Entries = append(ft.Entries, e)
+		}
+	}
+	for _, e := range t.Undated {
+		if f.match(e) {
+			ft.Undated = append(ft.Undated, e)
+		}
+	}
+
+	return ft
+}
+
+// match returns true if the TimelineEntry matches the TimelineFilter. An
+// entry belongs to a location or character if it is one or is tied to one.
+func (f TimelineFilter) match(e *TimelineEntry) bool {
+	if f.LocationID != 0 && e.LocationID != f.LocationID && (e.Type != TypeLocation || e.ID != ObjectID(f.LocationID)) {
+		return false
+	}
+	if f.CharacterID != 0 && e.CharacterID != f.CharacterID && (e.Type != TypeCharacter || e.ID != ObjectID(f.CharacterID)) {
+		return false
+	}
+	if len(f.Tags) == 0 {
+		return true
+	}
+
+	for _, want := range f.Tags {
+		for _, tag := range e.Tags {
+			if tag == want {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Groups returns the entries of the Timeline grouped by year in
+// chronological order.
+func (t *Timeline) Groups() []*TimelineGroup {
+	var groups []*TimelineGroup
+	for _, e := range t.Entries {
+		if len(groups) == 0 || groups[len(groups)-1].Year != e.Date.Year {
+			era, _ := t.Calendar.Era(e.Date.Year)
+			groups = append(groups, &TimelineGroup{Era: era, Year: e.Date.Year})
+		}
+
+		g := groups[len(groups)-1]
+		g.Entries = append(g.Entries, e)
+	}
+
+	return groups
+}
+
+// formatSpan returns the formatted date of the TimelineEntry, including its
+// end if it lasts several days.
+func (t *Timeline) formatSpan(e *TimelineEntry) string {
+	if e.End == e.Date {
+		return t.Calendar.Format(e.Date)
+	}
+	return t.Calendar.Format(e.Date) + " – " + t.Calendar.Format(e.End)
+}
+
+// timelineJS is the root object of a TimelineJS document.
+// For more information, visit: https://timeline.knightlab.com/docs/json-format.html
+type timelineJS struct {
+	Title  *timelineJSSlide  `json:"title,omitempty"`
+	Events []timelineJSSlide `json:"events"`
+}
+
+type timelineJSSlide struct {
+	StartDate *timelineJSDate `json:"start_date,omitempty"`
+	EndDate   *timelineJSDate `json:"end_date,omitempty"`
+	Text      timelineJSText  `json:"text"`
+	Group     string          `json:"group,omitempty"`
+	UniqueID  string          `json:"unique_id,omitempty"`
+}
+
+type timelineJSDate struct {
+	Year        int    `json:"year"`
+	Month       int    `json:"month"`
+	Day         int    `json:"day"`
+	DisplayDate string `json:"display_date"`
+}
+
+type timelineJSText struct {
+	Headline string `json:"headline,omitempty"`
+	Text     string `json:"text,omitempty"`
+}
+
+// gregorianDays lists the number of days of each Gregorian month in a leap
+// year.
+var gregorianDays = [12]int{31, 29, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+
+// fitsGregorian returns true if every date of the Timeline's calendar is
+// also a Gregorian month and day of a leap year.
+func (t *Timeline) fitsGregorian() bool {
+	if len(t.Calendar.Months) > len(gregorianDays) {
+		return false
+	}
+	for m, days := range gregorianDays[:len(t.Calendar.Months)] {
+		n := t.Calendar.Months[m].Length
+		if m+1 == t.Calendar.LeapYearMonth {
+			n += t.Calendar.leapDays()
+		}
+		if n > days {
+			return false
+		}
+	}
+
+	return true
+}
+
+// jsDate returns the TimelineJS date of the CalendarDate. TimelineJS only
+// understands Gregorian dates, so the month and day are copied only if the
+// calendar fits within the Gregorian months. Otherwise, the day of the year
+// is scaled onto a Gregorian year to keep entries in order. Either way, the
+// display date is the CalendarDate as written in the calendar.
+func (t *Timeline) jsDate(d CalendarDate) *timelineJSDate {
+	js := &timelineJSDate{Year: d.Year, Month: 1, Day: 1, DisplayDate: t.Calendar.Format(d)}
+
+	if t.fitsGregorian() {
+		last := time.Date(d.Year, time.Month(d.Month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		js.Month, js.Day = d.Month, minInt(d.Day, last)
+		return js
+	}
+
+	n := t.Calendar.YearLength(d.Year)
+	if n <= 0 {
+		return js
+	}
+	day := (t.Calendar.ordinal(d) - t.Calendar.daysBefore(d.Year)) * 365 / n
+	g := time.Date(2001, time.January, 1+day, 0, 0, 0, 0, time.UTC)
+	js.Month, js.Day = int(g.Month()), g.Day()
+
+	return js
+}
+
+// WriteJSON writes the dated entries of the Timeline to w as a TimelineJS
+// document. Entries are grouped by era and display their calendar dates.
+// Dates of calendars that do not fit within the Gregorian months are scaled
+// onto Gregorian dates in the same order.
+func (t *Timeline) WriteJSON(w io.Writer) error {
+	doc := timelineJS{
+		Title:  &timelineJSSlide{Text: timelineJSText{Headline: t.Calendar.Name}},
+		Events: []timelineJSSlide{},
+	}
+
+	for _, e := range t.Entries {
+		s := timelineJSSlide{
+			StartDate: t.jsDate(e.Date),
+			Text:      timelineJSText{Headline: e.Title, Text: e.Entry},
+			UniqueID:  fmt.Sprintf("entity-%d", e.EntityID),
+		}
+		if e.End != e.Date {
+			s.EndDate = t.jsDate(e.End)
+		}
+		if e.EventID != 0 {
+			s.UniqueID += fmt.Sprintf("-event-%d", e.EventID)
+		}
+		if era, _ := t.Calendar.Era(e.Date.Year); era != nil {
+			s.Group = era.Name
+		}
+
+		doc.Events = append(doc.Events, s)
+	}
+
+	if err := json.NewEncoder(w).Encode(doc); err != nil {
+		return fmt.Errorf("cannot write TimelineJS document: %w", err)
+	}
+
+	return nil
+}
+
+// WriteMarkdown writes the Timeline to w as a Markdown document with a
+// section for every era and year, followed by the undated entries.
+func (t *Timeline) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n", escapeMarkdown(t.Calendar.Name))
+
+	var era *Era
+	for _, g := range t.Groups() {
+		if g.Era != era && g.Era != nil {
+			fmt.Fprintf(&b, "\n## %s\n", escapeMarkdown(g.Era.Name))
+		}
+		era = g.Era
+
+		fmt.Fprintf(&b, "\n### %s\n\n", escapeMarkdown(t.Calendar.FormatYear(g.Year)))
+		for _, e := range g.Entries {
+			writeMarkdownEntry(&b, t.formatSpan(e), e)
+		}
+	}
+
+	if len(t.Undated) > 0 {
+		b.WriteString("\n## Undated\n\n")
+		for _, e := range t.Undated {
+			writeMarkdownEntry(&b, e.Raw, e)
+		}
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("cannot write Markdown timeline: %w", err)
+	}
+
+	return nil
+}
+
+// writeMarkdownEntry writes the TimelineEntry as a Markdown list item with
+// the provided date.
+func writeMarkdownEntry(b *strings.Builder, date string, e *TimelineEntry) {
+	fmt.Fprintf(b, "- **%s** %s\n", escapeMarkdown(date), escapeMarkdown(e.Title))
+	if md := EntryToMarkdown(e.Entry); md != "" {
+		fmt.Fprintf(b, "\n%s\n", prefixLines(md, "  ", ""))
+	}
+}
+
+// WriteHTML writes the Timeline to w as an HTML fragment with a heading for
+// every era and year, followed by the undated entries.
+func (t *Timeline) WriteHTML(w io.Writer) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "<h1>%s</h1>\n", escapeText(t.Calendar.Name))
+
+	var era *Era
+	for _, g := range t.Groups() {
+		if g.Era != era && g.Era != nil {
+			fmt.Fprintf(&b, "<h2>%s</h2>\n", escapeText(g.Era.Name))
+		}
+		era = g.Era
+
+		fmt.Fprintf(&b, "<h3>%s</h3>\n<ul class=\"timeline\">\n", escapeText(t.Calendar.FormatYear(g.Year)))
+		for _, e := range g.Entries {
+			fmt.Fprintf(&b, "<li><time data-date=\"%s\">%s</time> ", e.Date, escapeText(t.formatSpan(e)))
+			writeHTMLEntry(&b, e)
+		}
+		b.WriteString("</ul>\n")
+	}
+
+	if len(t.Undated) > 0 {
+		b.WriteString("<h2>Undated</h2>\n<ul class=\"timeline\">\n")
+		for _, e := range t.Undated {
+			fmt.Fprintf(&b, "<li><span class=\"date\">%s</span> ", escapeText(e.Raw))
+			writeHTMLEntry(&b, e)
+		}
+		b.WriteString("</ul>\n")
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("cannot write HTML timeline: %w", err)
+	}
+
+	return nil
+}
+
+// writeHTMLEntry writes the title and entry of the TimelineEntry and closes
+// its list item.
+func writeHTMLEntry(b *strings.Builder, e *TimelineEntry) {
+	fmt.Fprintf(b, "<strong>%s</strong>", escapeText(e.Title))
+	if e.Entry != "" {
+		fmt.Fprintf(b, "<div class=\"entry\">%s</div>", e.Entry)
+	}
+	b.WriteString("</li>\n")
+}
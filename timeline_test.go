@@ -0,0 +1,245 @@
+package kanka
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// testTimeline returns the Timeline of the test Backup on the test calendar.
+func testTimeline(t *testing.T) *Timeline {
+	cal := testCalendar()
+	cal.ID = 7
+
+	return testBackup(t).Timeline(cal)
+}
+
+// timelineTitles returns the titles of the provided entries.
+func timelineTitles(entries []*TimelineEntry) []string {
+	var titles []string
+	for _, e := range entries {
+		titles = append(titles, e.Title)
+	}
+	return titles
+}
+
+func TestBackup_Timeline(t *testing.T) {
+	tl := testTimeline(t)
+
+	want := []string{"Jon's diary", "Arya Stark", "Jon Snow", "Winterfell", "Jon Snow", "Arya Stark", "Battle of the Bells", "Jon Snow"}
+	if diff := cmp.Diff(want, timelineTitles(tl.Entries)); diff != "" {
+		t.Errorf("entries mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"Midnight log"}, timelineTitles(tl.Undated)); diff != "" {
+		t.Errorf("undated mismatch (-want +got):\n%s", diff)
+	}
+
+	march := tl.Entries[2]
+	if march.Date != (CalendarDate{1, 5, 28}) || march.End != (CalendarDate{2, 1, 1}) || march.EventID != 7 {
+		t.Errorf("got entry <%+v>, want event 7 from 1-5-28 to 2-1-1", *march)
+	}
+}
+
+func TestTimeline_Filter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter TimelineFilter
+		want   []string
+	}{
+		{"Everything", TimelineFilter{}, []string{"Jon's diary", "Arya Stark", "Jon Snow", "Winterfell", "Jon Snow", "Arya Stark", "Battle of the Bells", "Jon Snow"}},
+		{"Tag", TimelineFilter{Tags: []TagID{70, 71}}, []string{"Arya Stark", "Arya Stark", "Battle of the Bells"}},
+		{"Location", TimelineFilter{LocationID: 3}, []string{"Arya Stark", "Winterfell", "Arya Stark", "Battle of the Bells"}},
+		{"Character", TimelineFilter{CharacterID: 31}, []string{"Jon's diary", "Jon Snow", "Jon Snow", "Jon Snow"}},
+		{"Combined", TimelineFilter{LocationID: 3, CharacterID: 31}, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := testTimeline(t).Filter(test.filter)
+			if diff := cmp.Diff(test.want, timelineTitles(got.Entries)); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTimeline_Groups(t *testing.T) {
+	var got []string
+	for _, g := range testTimeline(t).Groups() {
+		era := ""
+		if g.Era != nil {
+			era = g.Era.Name
+		}
+		got = append(got, era+": "+strings.Join(timelineTitles(g.Entries), ", "))
+	}
+
+	want := []string{
+		"First Age: Jon's diary",
+		"Crown Age: Arya Stark, Jon Snow",
+		"Crown Age: Winterfell",
+		"Crown Age: Jon Snow",
+		"Crown Age: Arya Stark, Battle of the Bells, Jon Snow",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestTimeline_WriteJSON(t *testing.T) {
+	var b bytes.Buffer
+	if err := testTimeline(t).WriteJSON(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	var doc timelineJS
+	if err := json.Unmarshal(b.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(doc.Events) != 8 || doc.Title.Text.Headline != "Reckoning" {
+		t.Fatalf("got <%d> events titled <%v>, want <%d> titled <%s>", len(doc.Events), doc.Title, 8, "Reckoning")
+	}
+
+	want := timelineJSSlide{
+		StartDate: &timelineJSDate{Year: 1, Month: 12, Day: 25, DisplayDate: "28 Harvest 1 CA"},
+		EndDate:   &timelineJSDate{Year: 2, Month: 1, Day: 1, DisplayDate: "1 Frost 2 CA"},
+		Text:      timelineJSText{Headline: "Jon Snow", Text: "<p>Long march</p>"},
+		Group:     "Crown Age",
+		UniqueID:  "entity-131-event-7",
+	}
+	if diff := cmp.Diff(want, doc.Events[2]); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestTimeline_jsDate(t *testing.T) {
+	gregorian := testCalendar()
+	gregorian.Months = []CalendarMonth{{Name: "January", Length: 31}, {Name: "February", Length: 28}}
+	gregorian.LeapYearMonth = 2
+
+	tests := []struct {
+		name string
+		cal  *Calendar
+		date CalendarDate
+		want timelineJSDate
+	}{
+		{"Scaled first day", testCalendar(), CalendarDate{5, 1, 1}, timelineJSDate{5, 1, 1, "1 Frost 5 CA"}},
+		{"Scaled intercalary day", testCalendar(), CalendarDate{5, 3, 1}, timelineJSDate{5, 7, 2, "1 Midfest 5 CA"}},
+		{"Scaled leap day", testCalendar(), CalendarDate{4, 5, 30}, timelineJSDate{4, 12, 28, "30 Harvest 4 CA"}},
+		{"Copied", gregorian, CalendarDate{5, 2, 14}, timelineJSDate{5, 2, 14, "14 February 5 CA"}},
+		{"Copied leap day", gregorian, CalendarDate{4, 2, 29}, timelineJSDate{4, 2, 29, "29 February 4 CA"}},
+		{"Clamped leap day", gregorian, CalendarDate{100, 2, 29}, timelineJSDate{100, 2, 28, "29 February 100 CA"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tl := &Timeline{Calendar: test.cal}
+			if diff := cmp.Diff(test.want, *tl.jsDate(test.date)); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTimeline_WriteMarkdown(t *testing.T) {
+	var b bytes.Buffer
+	if err := testTimeline(t).WriteMarkdown(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.Join([]string{
+		"# Reckoning",
+		"",
+		"## First Age",
+		"",
+		"### 95 FA",
+		"",
+		"- **3 Frost 95 FA** Jon's diary",
+		"",
+		"## Crown Age",
+		"",
+		"### 1 CA",
+		"",
+		"- **10 Frost 1 CA** Arya Stark",
+		"",
+		"  Birthday",
+		"- **28 Harvest 1 CA – 1 Frost 2 CA** Jon Snow",
+		"",
+		"  Long march",
+		"",
+		"### 3 CA",
+		"",
+		"- **1 Midfest 3 CA – 2 Deep Bloom 3 CA** Winterfell",
+		"",
+		"  Festival",
+		"",
+		"### 4 CA",
+		"",
+		"- **30 Harvest 4 CA** Jon Snow",
+		"",
+		"  Born",
+		"",
+		"### 5 CA",
+		"",
+		"- **12 Thaw 5 CA** Arya Stark",
+		"",
+		"  Rang bells",
+		"- **12 Thaw 5 CA** Battle of the Bells",
+		"",
+		"  Bells rang.",
+		"- **29 Harvest 5 CA – 1 Frost 6 CA** Jon Snow",
+		"",
+		"  Battle",
+		"",
+		"## Undated",
+		"",
+		"- **the long night** Midnight log",
+		"",
+	}, "\n")
+
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestTimeline_WriteHTML(t *testing.T) {
+	var b bytes.Buffer
+	if err := testTimeline(t).WriteHTML(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"<h1>Reckoning</h1>\n<h2>First Age</h2>\n<h3>95 FA</h3>\n",
+		"<li><time data-date=\"5-2-12\">12 Thaw 5 CA</time> <strong>Battle of the Bells</strong><div class=\"entry\"><p>Bells rang.</p></div></li>\n",
+		"<li><span class=\"date\">the long night</span> <strong>Midnight log</strong></li>\n",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("got HTML:\n%s\nwant it to contain <%s>", b.String(), want)
+		}
+	}
+	if got := strings.Count(b.String(), "<h2>Crown Age</h2>"); got != 1 {
+		t.Errorf("got <%d> Crown Age headings, want <%d>", got, 1)
+	}
+}
+
+func TestClient_Timeline(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+	calID := fk.seed(t, "/campaigns/1/calendars", testCalendar())
+
+	tl, err := c.Timeline(1, CalendarID(calID))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff([]string{"Arya Stark"}, timelineTitles(tl.Entries)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err = c.Timeline(1, 9999); err == nil {
+		t.Error("got nil error for missing Calendar, want error")
+	}
+}
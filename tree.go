@@ -0,0 +1,362 @@
+package kanka
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// parentKey returns the JSON key of the parent of objects of the
+// EntityType, or an empty string if the objects cannot be nested.
+func (t EntityType) parentKey() string {
+	switch t {
+	case TypeLocation:
+		return "parent_location_id"
+	case TypeFamily:
+		return "family_id"
+	case TypeOrganization:
+		return "organisation_id"
+	case TypeRace:
+		return "race_id"
+	case TypeQuest:
+		return "quest_id"
+	case TypeTag:
+		return "tag_id"
+	default:
+		return ""
+	}
+}
+
+// TreeNode is an object in a Tree.
+// ParentID is the ID of the object's parent as stored in Kanka, even if the
+// parent is missing or the object was detached from it to break a cycle.
+type TreeNode struct {
+	Type     EntityType
+	ID       ObjectID
+	EntityID EntityID
+	Name     string
+	ParentID ObjectID
+
+	Parent   *TreeNode
+	Children []*TreeNode
+}
+
+// Ancestors returns every ancestor of the TreeNode, nearest first.
+func (n *TreeNode) Ancestors() []*TreeNode {
+	var nodes []*TreeNode
+	for p := n.Parent; p != nil; p = p.Parent {
+		nodes = append(nodes, p)
+	}
+
+	return nodes
+}
+
+// Descendants returns every descendant of the TreeNode in depth-first
+// order.
+func (n *TreeNode) Descendants() []*TreeNode {
+	var nodes []*TreeNode
+	for _, c := range n.Children {
+		nodes = append(nodes, c)
+		nodes = append(nodes, c.Descendants()...)
+	}
+
+	return nodes
+}
+
+// Depth returns the number of ancestors of the TreeNode.
+func (n *TreeNode) Depth() int {
+	return len(n.Ancestors())
+}
+
+// Path returns the names of the TreeNode and its ancestors from its root
+// down, joined by sep.
+func (n *TreeNode) Path(sep string) string {
+	anc := n.Ancestors()
+	names := make([]string, 0, len(anc)+1)
+	for i := len(anc) - 1; i >= 0; i-- {
+		names = append(names, anc[i].Name)
+	}
+	names = append(names, n.Name)
+
+	return strings.Join(names, sep)
+}
+
+// contains returns true if node is the TreeNode or one of its descendants.
+func (n *TreeNode) contains(node *TreeNode) bool {
+	for p := node; p != nil; p = p.Parent {
+		if p == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Tree is a forest of the self-referencing objects of a single type, such
+// as locations nested in their parent locations.
+type Tree struct {
+	Type  EntityType
+	nodes map[ObjectID]*TreeNode
+
+	roots   []*TreeNode
+	orphans []*TreeNode
+	cycles  [][]*TreeNode
+}
+
+// Tree returns the Tree of the objects of the provided type in the Campaign
+// associated with campID. Only locations, families, organizations, races,
+// quests, and tags can be nested.
+func (c *Client) Tree(campID CampaignID, typ EntityType) (*Tree, error) {
+	if typ.parentKey() == "" {
+		return nil, fmt.Errorf("cannot build tree of type '%s': objects cannot be nested", typ)
+	}
+
+	refs, err := c.indexRefs(int(campID), typ)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get %s tree of Campaign (ID: %d): %w", typ, campID, err)
+	}
+
+	return newTree(typ, refs), nil
+}
+
+// Tree returns the Tree of the objects of the provided type in the Backup.
+func (b *Backup) Tree(typ EntityType) *Tree {
+	var refs []entityRef
+	for _, ref := range b.refs() {
+		if ref.Type == typ {
+			refs = append(refs, ref)
+		}
+	}
+
+	return newTree(typ, refs)
+}
+
+// newTree returns the Tree of the referenced objects. Objects whose parent
+// is missing are orphans and become roots. Every cycle is broken by making
+// its object with the lowest ID a root.
+func newTree(typ EntityType, refs []entityRef) *Tree {
+	t := &Tree{Type: typ, nodes: make(map[ObjectID]*TreeNode)}
+
+	for _, ref := range refs {
+		t.nodes[ObjectID(ref.ID)] = &TreeNode{
+			Type:     ref.Type,
+			ID:       ObjectID(ref.ID),
+			EntityID: EntityID(ref.EntityID),
+			Name:     ref.Name,
+			ParentID: ObjectID(ref.Parent),
+		}
+	}
+
+	for _, n := range t.sorted() {
+		if n.ParentID == 0 {
+			continue
+		}
+		if p, ok := t.nodes[n.ParentID]; ok {
+			n.Parent = p
+			continue
+		}
+		t.orphans = append(t.orphans, n)
+	}
+
+	state := make(map[*TreeNode]int)
+	for _, n := range t.sorted() {
+		var chain []*TreeNode
+		for p := n; p != nil && state[p] == 0; p = p.Parent {
+			state[p] = 1
+			chain = append(chain, p)
+		}
+		if len(chain) == 0 {
+			continue
+		}
+
+		if last := chain[len(chain)-1].Parent; last != nil && state[last] == 1 {
+			var cycle []*TreeNode
+			for i := len(chain) - 1; i >= 0; i-- {
+				cycle = append(cycle, chain[i])
+				if chain[i] == last {
+					break
+				}
+			}
+			sort.Slice(cycle, func(i, j int) bool { return cycle[i].ID < cycle[j].ID })
+			cycle[0].Parent = nil
+			t.cycles = append(t.cycles, cycle)
+		}
+
+		for _, p := range chain {
+			state[p] = 2
+		}
+	}
+
+	t.link()
+
+	return t
+}
+
+// sorted returns every TreeNode ordered by ID.
+func (t *Tree) sorted() []*TreeNode {
+	nodes := make([]*TreeNode, 0, len(t.nodes))
+	for _, n := range t.nodes {
+		nodes = append(nodes, n)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+
+	return nodes
+}
+
+// link rebuilds the roots and children of the Tree from the parents of its
+// nodes. Roots and children are ordered by name and then ID.
+func (t *Tree) link() {
+	t.roots = nil
+	for _, n := range t.nodes {
+		n.Children = nil
+	}
+
+	for _, n := range t.sorted() {
+		if n.Parent == nil {
+			t.roots = append(t.roots, n)
+			continue
+		}
+		n.Parent.Children = append(n.Parent.Children, n)
+	}
+
+	sortNodes(t.roots)
+	for _, n := range t.nodes {
+		sortNodes(n.Children)
+	}
+}
+
+// sortNodes orders the nodes by name and then ID.
+func sortNodes(nodes []*TreeNode) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].Name != nodes[j].Name {
+			return nodes[i].Name < nodes[j].Name
+		}
+		return nodes[i].ID < nodes[j].ID
+	})
+}
+
+// Node returns the TreeNode of the object associated with id, if any.
+func (t *Tree) Node(id ObjectID) (*TreeNode, bool) {
+	n, ok := t.nodes[id]
+	return n, ok
+}
+
+// Roots returns the top-level nodes of the Tree, including orphans and the
+// nodes detached to break cycles.
+func (t *Tree) Roots() []*TreeNode {
+	return t.roots
+}
+
+// Orphans returns the nodes whose parents do not exist.
+func (t *Tree) Orphans() []*TreeNode {
+	return t.orphans
+}
+
+// Cycles returns the groups of nodes whose parents form a cycle, each
+// ordered by ID. The first node of each cycle was made a root.
+func (t *Tree) Cycles() [][]*TreeNode {
+	return t.cycles
+}
+
+// Walk calls fn for every node of the Tree in depth-first order, starting
+// with the roots.
+func (t *Tree) Walk(fn func(n *TreeNode)) {
+	for _, r := range t.roots {
+		fn(r)
+		for _, d := range r.Descendants() {
+			fn(d)
+		}
+	}
+}
+
+// Move makes the node associated with parent the parent of the node
+// associated with id, moving the node's whole subtree. A parent of 0 makes
+// the node a root. Move only changes the Tree; use the Client's MoveSubtree
+// to also update Kanka.
+func (t *Tree) Move(id ObjectID, parent ObjectID) error {
+	n, ok := t.nodes[id]
+	if !ok {
+		return fmt.Errorf("cannot find %s (ID: %d) in tree", t.Type, id)
+	}
+
+	var p *TreeNode
+	if parent != 0 {
+		if p, ok = t.nodes[parent]; !ok {
+			return fmt.Errorf("cannot find %s (ID: %d) in tree", t.Type, parent)
+		}
+		if n.contains(p) {
+			return fmt.Errorf("cannot move %s (ID: %d) under its own descendant (ID: %d)", t.Type, id, parent)
+		}
+	}
+
+	n.Parent = p
+	n.ParentID = parent
+	for i, o := range t.orphans {
+		if o == n {
+			t.orphans = append(t.orphans[:i], t.orphans[i+1:]...)
+			break
+		}
+	}
+	t.link()
+
+	return nil
+}
+
+// MoveSubtree moves the object associated with id in the provided Tree of
+// the Campaign associated with campID under the object associated with
+// parent, along with all of its descendants. A parent of 0 makes the object
+// a root. The move is validated against the Tree and the Tree is updated
+// after Kanka.
+func (c *Client) MoveSubtree(campID CampaignID, t *Tree, id ObjectID, parent ObjectID) error {
+	n, ok := t.Node(id)
+	if !ok {
+		return fmt.Errorf("cannot find %s (ID: %d) in tree", t.Type, id)
+	}
+	if p, ok := t.Node(parent); parent != 0 && (!ok || n.contains(p)) {
+		return fmt.Errorf("cannot move %s (ID: %d) under %s (ID: %d)", t.Type, id, t.Type, parent)
+	}
+
+	if err := c.setParent(int(campID), t.Type, int(id), n.Name, int(parent)); err != nil {
+		return err
+	}
+
+	return t.Move(id, parent)
+}
+
+// setParent updates the parent of the object of the provided type
+// associated with id. A parent of 0 clears the object's parent.
+func (c *Client) setParent(campID int, typ EntityType, id int, name string, parent int) error {
+	end, err := EndpointCampaign.id(campID)
+	if err != nil {
+		return fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(typ.endpoint())
+
+	end, err = end.id(id)
+	if err != nil {
+		return fmt.Errorf("invalid %s ID: %w", typ, err)
+	}
+
+	body := map[string]interface{}{"name": name, typ.parentKey(): nil}
+	if parent != 0 {
+		body[typ.parentKey()] = parent
+	}
+
+	b, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("cannot marshal parent of %s (ID: %d): %w", typ, id, err)
+	}
+
+	var wrap struct {
+		Data json.RawMessage `json:"data"`
+	}
+
+	err = c.put(end, bytes.NewReader(b), &wrap)
+	if err != nil {
+		return fmt.Errorf("cannot update parent of %s (ID: %d) for Campaign (ID: %d): %w", typ, id, campID, err)
+	}
+
+	return nil
+}
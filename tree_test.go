@@ -0,0 +1,138 @@
+package kanka
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// nodeIDs returns the IDs of the provided nodes.
+func nodeIDs(nodes []*TreeNode) []ObjectID {
+	var ids []ObjectID
+	for _, n := range nodes {
+		ids = append(ids, n.ID)
+	}
+	return ids
+}
+
+func TestBackup_Tree(t *testing.T) {
+	tr := testBackup(t).Tree(TypeLocation)
+
+	if diff := cmp.Diff([]ObjectID{7, 6, 1}, nodeIDs(tr.Roots())); diff != "" {
+		t.Errorf("roots mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]ObjectID{6}, nodeIDs(tr.Orphans())); diff != "" {
+		t.Errorf("orphans mismatch (-want +got):\n%s", diff)
+	}
+	if len(tr.Cycles()) != 1 || !cmp.Equal([]ObjectID{7, 8}, nodeIDs(tr.Cycles()[0])) {
+		t.Errorf("got cycles <%v>, want one of <%v>", tr.Cycles(), []ObjectID{7, 8})
+	}
+
+	wf, _ := tr.Node(3)
+	if got := wf.Path(" / "); got != "Westeros / The North / Winterfell" {
+		t.Errorf("got path <%s>, want <%s>", got, "Westeros / The North / Winterfell")
+	}
+	if got := wf.Depth(); got != 2 {
+		t.Errorf("got depth <%d>, want <%d>", got, 2)
+	}
+	if diff := cmp.Diff([]ObjectID{2, 1}, nodeIDs(wf.Ancestors())); diff != "" {
+		t.Errorf("ancestors mismatch (-want +got):\n%s", diff)
+	}
+
+	root, _ := tr.Node(1)
+	if diff := cmp.Diff([]ObjectID{10, 2, 4, 3, 9, 5}, nodeIDs(root.Descendants())); diff != "" {
+		t.Errorf("descendants mismatch (-want +got):\n%s", diff)
+	}
+
+	var walked []ObjectID
+	tr.Walk(func(n *TreeNode) { walked = append(walked, n.ID) })
+	if diff := cmp.Diff([]ObjectID{7, 8, 6, 1, 10, 2, 4, 3, 9, 5}, walked); diff != "" {
+		t.Errorf("walk mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestTree_Move(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      ObjectID
+		parent  ObjectID
+		wantErr bool
+		want    []ObjectID
+	}{
+		{"Under sibling", 3, 4, false, []ObjectID{10, 2, 4, 3, 9, 5}},
+		{"To root", 2, 0, false, []ObjectID{10, 5}},
+		{"Under descendant", 1, 3, true, []ObjectID{10, 2, 4, 3, 9, 5}},
+		{"Under itself", 2, 2, true, []ObjectID{10, 2, 4, 3, 9, 5}},
+		{"Missing parent", 2, 99, true, []ObjectID{10, 2, 4, 3, 9, 5}},
+		{"Missing node", 99, 1, true, []ObjectID{10, 2, 4, 3, 9, 5}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tr := testBackup(t).Tree(TypeLocation)
+
+			err := tr.Move(test.id, test.parent)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error <%v>, want error <%t>", err, test.wantErr)
+			}
+
+			if n, ok := tr.Node(test.id); ok && !test.wantErr && n.ParentID != test.parent {
+				t.Errorf("got parent <%d>, want <%d>", n.ParentID, test.parent)
+			}
+
+			root, _ := tr.Node(1)
+			if diff := cmp.Diff(test.want, nodeIDs(root.Descendants())); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_MoveSubtree(t *testing.T) {
+	c, fk, ts := newFakeKanka(t)
+	defer ts.Close()
+
+	seedCampaign(t, fk, "")
+	fk.seed(t, "/campaigns/1/locations", &Location{ID: 11, EntityID: 111, SimpleLocation: SimpleLocation{Name: "The North"}})
+	fk.seed(t, "/campaigns/1/locations", &Location{ID: 12, EntityID: 112, SimpleLocation: SimpleLocation{Name: "Great Hall", ParentLocationID: 10}})
+
+	tr, err := c.Tree(1, TypeLocation)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = c.MoveSubtree(1, tr, 10, 11); err != nil {
+		t.Fatal(err)
+	}
+	if got := intField(fk.object("/campaigns/1/locations", 10), "parent_location_id"); got != 11 {
+		t.Errorf("got parent <%d>, want <%d>", got, 11)
+	}
+
+	hall, _ := tr.Node(12)
+	if got := hall.Path("/"); got != "The North/Winterfell/Great Hall" {
+		t.Errorf("got path <%s>, want <%s>", got, "The North/Winterfell/Great Hall")
+	}
+
+	if err = c.MoveSubtree(1, tr, 11, 12); err == nil {
+		t.Error("got nil error for move under descendant, want error")
+	}
+
+	if err = c.MoveSubtree(1, tr, 10, 0); err != nil {
+		t.Fatal(err)
+	}
+	if obj := fk.object("/campaigns/1/locations", 10); obj["parent_location_id"] != nil {
+		t.Errorf("got parent <%v>, want none", obj["parent_location_id"])
+	}
+
+	fk.fail["PUT /campaigns/1/locations/10"] = http.StatusForbidden
+	if err = c.MoveSubtree(1, tr, 10, 11); err == nil {
+		t.Error("got nil error, want error")
+	}
+	if n, _ := tr.Node(10); n.Parent != nil {
+		t.Error("got moved node after failed update, want it unchanged")
+	}
+
+	if _, err = c.Tree(1, TypeCharacter); err == nil {
+		t.Error("got nil error for characters, want error")
+	}
+}